@@ -7,10 +7,47 @@ import (
 	"github.com/BurntSushi/toml"
 )
 
+// режимы запуска сервера
+const (
+	ModeDev  = "dev"
+	ModeTest = "test"
+	ModeProd = "prod"
+)
+
+// политики приёма товара сверх вместимости ПВЗ
+const (
+	CapacityWarn   = "warn"
+	CapacityReject = "reject"
+)
+
+// DefaultStoragePeriod - сколько невостребованный товар ждёт клиента на ПВЗ
+const DefaultStoragePeriod = 7 * 24 * time.Hour
+
+// значения по умолчанию для автозакрытия забытых открытыми приёмок
+const (
+	DefaultReceptionIdleTimeout = 12 * time.Hour
+	DefaultAutoCloseInterval    = 5 * time.Minute
+)
+
 type Config struct {
+	// Mode - режим запуска: dev, test или prod. Если не задан, сервер запускается в prod
+	Mode         string        `toml:"mode"`
 	Addr         string        `toml:"addr"`
 	ReadTimeout  time.Duration `toml:"read_timeout"`
 	WriteTimeout time.Duration `toml:"write_timeout"`
+	// Roles - права ролей вида роль -> список разрешений. Если не задано, используется политика по умолчанию
+	Roles map[string][]string `toml:"roles"`
+	// CapacityPolicy - что делать с товаром сверх вместимости ПВЗ: warn принимает его с пометкой, reject отклоняет.
+	// Если не задано, используется warn
+	CapacityPolicy string `toml:"capacity_policy"`
+	// StoragePeriod - срок хранения товара, после которого невостребованный товар отправляется обратно на склад.
+	// Если не задан, используется DefaultStoragePeriod
+	StoragePeriod time.Duration `toml:"storage_period"`
+	// ReceptionIdleTimeout - через сколько после последнего товара открытая приёмка закрывается автоматически.
+	// Если не задан, используется DefaultReceptionIdleTimeout
+	ReceptionIdleTimeout time.Duration `toml:"reception_idle_timeout"`
+	// AutoCloseInterval - как часто искать простаивающие приёмки. Если не задан, используется DefaultAutoCloseInterval
+	AutoCloseInterval time.Duration `toml:"auto_close_interval"`
 }
 
 func loadConfig(configPath string) (*Config, error) {
@@ -32,5 +69,42 @@ func Parse(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("internal.Run: %w", err)
 	}
 
+	switch cfg.Mode {
+	case "":
+		cfg.Mode = ModeProd
+	case ModeDev, ModeTest, ModeProd:
+	default:
+		return nil, fmt.Errorf("config.Parse: unknown mode %q", cfg.Mode)
+	}
+
+	switch cfg.CapacityPolicy {
+	case "":
+		cfg.CapacityPolicy = CapacityWarn
+	case CapacityWarn, CapacityReject:
+	default:
+		return nil, fmt.Errorf("config.Parse: unknown capacity policy %q", cfg.CapacityPolicy)
+	}
+
+	switch {
+	case cfg.StoragePeriod == 0:
+		cfg.StoragePeriod = DefaultStoragePeriod
+	case cfg.StoragePeriod < 0:
+		return nil, fmt.Errorf("config.Parse: negative storage period %s", cfg.StoragePeriod)
+	}
+
+	switch {
+	case cfg.ReceptionIdleTimeout == 0:
+		cfg.ReceptionIdleTimeout = DefaultReceptionIdleTimeout
+	case cfg.ReceptionIdleTimeout < 0:
+		return nil, fmt.Errorf("config.Parse: negative reception idle timeout %s", cfg.ReceptionIdleTimeout)
+	}
+
+	switch {
+	case cfg.AutoCloseInterval == 0:
+		cfg.AutoCloseInterval = DefaultAutoCloseInterval
+	case cfg.AutoCloseInterval < 0:
+		return nil, fmt.Errorf("config.Parse: negative auto close interval %s", cfg.AutoCloseInterval)
+	}
+
 	return cfg, nil
 }
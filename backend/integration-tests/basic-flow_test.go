@@ -14,47 +14,93 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 
+	"pvz/config"
 	"pvz/internal/delivery/forms"
 	"pvz/internal/delivery/handlers"
 	"pvz/internal/delivery/middleware"
 	"pvz/internal/models"
 	"pvz/internal/repository/fakes"
 	"pvz/internal/usecase"
+	"pvz/internal/utils"
 )
 
 func SetupTest() *mux.Router {
 	fakePvzRepo := fakes.NewPostgresPvzRepository()
 	fakeReceptionRepo := fakes.NewFakeReceptionRepository()
 	fakeUserRepo := fakes.NewFakeUserRepository()
+	fakeTokenRepo := fakes.NewFakeTokenRepository()
+	fakeLoginFailureRepo := fakes.NewFakeLoginFailureRepository()
 
-	newAuthService := usecase.NewAuthService(fakeUserRepo)
-	newPvzService := usecase.NewPvzService(fakePvzRepo)
-	newReceptionService := usecase.NewReceptionService(fakeReceptionRepo)
+	newAuthService := usecase.NewAuthService(fakeUserRepo, fakeTokenRepo, fakeLoginFailureRepo, utils.NewBcryptHasher())
+	newPvzService := usecase.NewPvzService(fakePvzRepo, models.DefaultPolicy())
+	newReceptionService := usecase.NewReceptionService(fakeReceptionRepo, usecase.NewPvzAccessChecker(fakePvzRepo, true), config.CapacityWarn)
 
-	newAuthHandler := handlers.NewAuthHandler(newAuthService)
+	policy := models.DefaultPolicy()
+	newAuthHandler := handlers.NewAuthHandler(newAuthService, policy)
 	newPvzHandler := handlers.NewPvzHandler(newPvzService)
 	newReceptionHandler := handlers.NewReceptionHandler(newReceptionService)
 
+	// роутер собран как в режиме test: /dummyLogin зарегистрирован, dummy-токены допускаются к операциям приемки
+	// и к любому ПВЗ без закрепления
 	r := mux.NewRouter()
 
 	r.Use(middleware.RequestIDMiddleware)
 	r.HandleFunc("/dummyLogin", newAuthHandler.DummyLogin).Methods("POST")
 
-	// endpoints for moderators only
-	protectedModer := r.PathPrefix("/").Subrouter()
-	protectedModer.Use(middleware.RoleMiddleware(models.Moderator))
-	protectedModer.HandleFunc("/pvz", newPvzHandler.CreatePvz).Methods("POST")
+	permit := func(permission models.Permission, handler http.HandlerFunc) http.Handler {
+		return middleware.PermissionMiddleware(policy, permission)(handler)
+	}
 
-	//endpoints for employees only
-	protectedEmp := r.PathPrefix("/").Subrouter()
-	protectedEmp.Use(middleware.RoleMiddleware(models.Employee))
-	protectedEmp.HandleFunc("/receptions", newReceptionHandler.CreateReception).Methods("POST")
-	protectedEmp.HandleFunc("/products", newReceptionHandler.AddProduct).Methods("POST")
-	protectedEmp.HandleFunc("/pvz/{pvzId:[0-9a-fA-F-]{36}}/close_last_reception", newReceptionHandler.CloseReception).Methods("POST")
+	authorized := r.PathPrefix("/").Subrouter()
+	authorized.Use(middleware.AuthMiddleware(newAuthService))
+	authorized.Handle("/pvz", permit(models.PvzCreate, newPvzHandler.CreatePvz)).Methods("POST")
+	authorized.Handle("/receptions", permit(models.ReceptionCreate, newReceptionHandler.CreateReception)).Methods("POST")
+	authorized.Handle("/products", permit(models.ProductCreate, newReceptionHandler.AddProduct)).Methods("POST")
+	authorized.Handle("/products/batch", permit(models.ProductCreate, newReceptionHandler.AddProducts)).Methods("POST")
+	authorized.Handle("/products/search", permit(models.PvzRead, newReceptionHandler.SearchProducts)).Methods("GET")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/close_last_reception", permit(models.ReceptionClose, newReceptionHandler.CloseReception)).Methods("POST")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/manifest", permit(models.ManifestManage, newReceptionHandler.ReplaceManifest)).Methods("PUT")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/reopen", permit(models.ReceptionReopen, newReceptionHandler.ReopenReception)).Methods("POST")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/cancel", permit(models.ReceptionCancel, newReceptionHandler.CancelReception)).Methods("POST")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/verify", permit(models.ReceptionVerify, newReceptionHandler.VerifyReception)).Methods("POST")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/history", permit(models.PvzRead, newReceptionHandler.GetReceptionHistory)).Methods("GET")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/discrepancies", permit(models.PvzRead, newReceptionHandler.GetDiscrepancyReport)).Methods("GET")
 
 	return r
 }
 
+// apiClient отправляет запросы к тестовому серверу от имени пользователя с переданным токеном
+type apiClient struct {
+	t      *testing.T
+	server *httptest.Server
+}
+
+func newApiClient(t *testing.T, server *httptest.Server) *apiClient {
+	return &apiClient{t: t, server: server}
+}
+
+func (c *apiClient) do(token, method, url, body string) *http.Response {
+	req, err := http.NewRequest(method, c.server.URL+url, strings.NewReader(body))
+	require.NoError(c.t, err)
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := c.server.Client().Do(req)
+	require.NoError(c.t, err)
+	return resp
+}
+
+// login возвращает dummy-токен пользователя с ролью role
+func (c *apiClient) login(role string) string {
+	resp := c.do("", "POST", "/dummyLogin", fmt.Sprintf(`{"role": "%s"}`, role))
+	require.Equal(c.t, http.StatusOK, resp.StatusCode)
+
+	var token string
+	require.NoError(c.t, json.NewDecoder(resp.Body).Decode(&token))
+	return token
+}
+
 func TestBasicFlow(t *testing.T) {
 	router := SetupTest()
 	server := httptest.NewServer(router)
@@ -160,3 +206,295 @@ func TestBasicFlow(t *testing.T) {
 	}
 	require.NotEmpty(t, closedReception)
 }
+
+func TestBatchFlow(t *testing.T) {
+	server := httptest.NewServer(SetupTest())
+	defer server.Close()
+
+	api := newApiClient(t, server)
+
+	modToken := api.login("moderator")
+	empToken := api.login("employee")
+
+	pvzId := "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+	resp := api.do(modToken, "POST", "/pvz", fmt.Sprintf(`{"id": "%s", "registrationDate": "2025-04-23T01:52:52.102Z", "city": "Казань"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", "/receptions", fmt.Sprintf(`{"pvzId": "%s"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// пакет с неизвестной категорией отклоняется целиком
+	resp = api.do(empToken, "POST", "/products/batch", fmt.Sprintf(`{"pvzId": "%s", "items": [{"type": "обувь"}, {"type": "книги"}]}`, pvzId))
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var rejected forms.BatchProductsFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&rejected))
+	require.Equal(t, forms.BatchItemSkipped, rejected.Results[0].Status)
+	require.Equal(t, forms.BatchItemRejected, rejected.Results[1].Status)
+
+	// 50 товаров одним запросом вместо 50 отдельных
+	items := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		items = append(items, `{"type": "электроника"}`)
+	}
+	resp = api.do(empToken, "POST", "/products/batch", fmt.Sprintf(`{"pvzId": "%s", "items": [%s]}`, pvzId, strings.Join(items, ",")))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created forms.BatchProductsFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.Len(t, created.Results, 50)
+	for _, result := range created.Results {
+		require.Equal(t, forms.BatchItemCreated, result.Status)
+		require.NotNil(t, result.Product)
+	}
+
+	// штрихкод принимается в приёмку один раз, а затем находится поиском
+	productPayload := fmt.Sprintf(`{"pvzId": "%s", "type": "обувь", "barcode": "4600000000017", "externalOrderId": "WB-1"}`, pvzId)
+	resp = api.do(empToken, "POST", "/products", productPayload)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", "/products", productPayload)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = api.do(modToken, "GET", "/products/search?barcode=4600000000017", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var found []forms.ProductLocationFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&found))
+	require.Len(t, found, 1)
+	require.Equal(t, pvzId, found[0].PvzId.String())
+	require.Equal(t, "WB-1", found[0].Product.ExternalOrderId)
+
+	resp = api.do(empToken, "POST", fmt.Sprintf("/pvz/%s/close_last_reception", pvzId), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestManifestFlow(t *testing.T) {
+	server := httptest.NewServer(SetupTest())
+	defer server.Close()
+
+	api := newApiClient(t, server)
+
+	modToken := api.login("moderator")
+	empToken := api.login("employee")
+
+	pvzId := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	resp := api.do(modToken, "POST", "/pvz", fmt.Sprintf(`{"id": "%s", "registrationDate": "2025-04-23T01:52:52.102Z", "city": "Москва"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	manifest := `{"items": [
+		{"barcode": "A-1", "type": "обувь"},
+		{"barcode": "A-2", "type": "обувь"},
+		{"barcode": "A-3", "type": "электроника"}
+	]}`
+	resp = api.do(empToken, "PUT", fmt.Sprintf("/pvz/%s/manifest", pvzId), manifest)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = api.do(modToken, "PUT", fmt.Sprintf("/pvz/%s/manifest", pvzId), manifest)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", "/receptions", fmt.Sprintf(`{"pvzId": "%s"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var reception forms.ReceptionFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&reception))
+
+	// A-1 совпадает с манифестом, A-2 принят как одежда, B-1 не ожидался, A-3 не пришёл
+	resp = api.do(empToken, "POST", "/products/batch", fmt.Sprintf(`{"pvzId": "%s", "items": [
+		{"type": "обувь", "barcode": "A-1"},
+		{"type": "одежда", "barcode": "A-2"},
+		{"type": "одежда", "barcode": "B-1"}
+	]}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// отчёт появляется только после закрытия приёмки
+	resp = api.do(modToken, "GET", fmt.Sprintf("/receptions/%s/discrepancies", reception.Id), "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", fmt.Sprintf("/pvz/%s/close_last_reception", pvzId), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = api.do(modToken, "GET", fmt.Sprintf("/receptions/%s/discrepancies", reception.Id), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var report forms.DiscrepancyReportFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	require.Len(t, report.Shortages, 1)
+	require.Equal(t, "A-3", report.Shortages[0].Barcode)
+	require.Len(t, report.Surpluses, 1)
+	require.Equal(t, "B-1", report.Surpluses[0].Barcode)
+	require.Len(t, report.WrongCategory, 1)
+	require.Equal(t, "A-2", report.WrongCategory[0].Barcode)
+	require.Equal(t, "обувь", report.WrongCategory[0].ExpectedType)
+	require.Equal(t, "одежда", report.WrongCategory[0].ActualType)
+}
+
+func TestReopenFlow(t *testing.T) {
+	server := httptest.NewServer(SetupTest())
+	defer server.Close()
+
+	api := newApiClient(t, server)
+
+	modToken := api.login("moderator")
+	empToken := api.login("employee")
+
+	pvzId := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	resp := api.do(modToken, "POST", "/pvz", fmt.Sprintf(`{"id": "%s", "registrationDate": "2025-04-23T01:52:52.102Z", "city": "Москва"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = api.do(modToken, "PUT", fmt.Sprintf("/pvz/%s/manifest", pvzId), `{"items": [{"barcode": "R-1", "type": "обувь"}]}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", "/receptions", fmt.Sprintf(`{"pvzId": "%s"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var reception forms.ReceptionFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&reception))
+
+	resp = api.do(empToken, "POST", fmt.Sprintf("/pvz/%s/close_last_reception", pvzId), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	reportUrl := fmt.Sprintf("/receptions/%s/discrepancies", reception.Id)
+	resp = api.do(modToken, "GET", reportUrl, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var report forms.DiscrepancyReportFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	require.Len(t, report.Shortages, 1)
+	require.Equal(t, "R-1", report.Shortages[0].Barcode)
+
+	reopenUrl := fmt.Sprintf("/receptions/%s/reopen", reception.Id)
+	reason := `{"reason": "закрыта раньше времени"}`
+
+	resp = api.do(empToken, "POST", reopenUrl, reason)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = api.do(modToken, "POST", reopenUrl, `{"reason": ""}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = api.do(modToken, "POST", reopenUrl, reason)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var reopened forms.ReceptionFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&reopened))
+	require.Equal(t, string(models.InProgress), reopened.Status)
+
+	// отчёт первого закрытия устарел и строится заново при следующем закрытии
+	resp = api.do(modToken, "GET", reportUrl, "")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// после переоткрытия оставшиеся товары принимаются в ту же приёмку
+	resp = api.do(empToken, "POST", "/products", fmt.Sprintf(`{"pvzId": "%s", "type": "обувь", "barcode": "R-1"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = api.do(modToken, "POST", reopenUrl, reason)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = api.do(modToken, "GET", fmt.Sprintf("/receptions/%s/history", reception.Id), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history forms.ReceptionHistoryFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	require.Len(t, history.Events, 1)
+	require.Equal(t, string(models.AuditReceptionReopen), history.Events[0].Action)
+	require.Equal(t, "закрыта раньше времени", history.Events[0].Details["reason"])
+
+	// повторное закрытие сверяется с тем же манифестом, недостача закрыта
+	resp = api.do(empToken, "POST", fmt.Sprintf("/pvz/%s/close_last_reception", pvzId), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = api.do(modToken, "GET", reportUrl, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var reclosed forms.DiscrepancyReportFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&reclosed))
+	require.Equal(t, report.ManifestId, reclosed.ManifestId)
+	require.Empty(t, reclosed.Shortages)
+	require.Empty(t, reclosed.Surpluses)
+
+	// пока у ПВЗ есть другая открытая приёмка, закрытую переоткрыть нельзя
+
+	resp = api.do(empToken, "POST", "/receptions", fmt.Sprintf(`{"pvzId": "%s"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = api.do(modToken, "POST", reopenUrl, reason)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestStatusFlow(t *testing.T) {
+	server := httptest.NewServer(SetupTest())
+	defer server.Close()
+
+	api := newApiClient(t, server)
+
+	createReception := func(token, pvzId string) forms.ReceptionFormOut {
+		resp := api.do(token, "POST", "/receptions", fmt.Sprintf(`{"pvzId": "%s"}`, pvzId))
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var reception forms.ReceptionFormOut
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&reception))
+		return reception
+	}
+
+	modToken := api.login("moderator")
+	empToken := api.login("employee")
+
+	pvzId := "5f2b7c1e-3a4d-4e8f-9b0a-1c2d3e4f5a6b"
+	resp := api.do(modToken, "POST", "/pvz", fmt.Sprintf(`{"id": "%s", "registrationDate": "2025-04-23T01:52:52.102Z", "city": "Москва"}`, pvzId))
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// приёмку, открытую по ошибке, отменяет сотрудник, после чего можно открыть новую
+	mistaken := createReception(empToken, pvzId)
+	cancelUrl := fmt.Sprintf("/receptions/%s/cancel", mistaken.Id)
+	reason := `{"reason": "открыта по ошибке"}`
+
+	resp = api.do(modToken, "POST", cancelUrl, reason)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", cancelUrl, reason)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var cancelled forms.ReceptionFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&cancelled))
+	require.Equal(t, string(models.Cancelled), cancelled.Status)
+
+	resp = api.do(empToken, "POST", cancelUrl, reason)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = api.do(modToken, "POST", fmt.Sprintf("/receptions/%s/verify", mistaken.Id), "")
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = api.do(modToken, "POST", fmt.Sprintf("/receptions/%s/reopen", mistaken.Id), `{"reason": "отменена зря"}`)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// проверить можно только закрытую приёмку, после проверки она не меняется
+	reception := createReception(empToken, pvzId)
+	verifyUrl := fmt.Sprintf("/receptions/%s/verify", reception.Id)
+
+	resp = api.do(modToken, "POST", verifyUrl, "")
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", fmt.Sprintf("/pvz/%s/close_last_reception", pvzId), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = api.do(empToken, "POST", verifyUrl, "")
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = api.do(modToken, "POST", verifyUrl, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var verified forms.ReceptionFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&verified))
+	require.Equal(t, string(models.Verified), verified.Status)
+
+	resp = api.do(modToken, "POST", fmt.Sprintf("/receptions/%s/reopen", reception.Id), `{"reason": "нашлась недостача"}`)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = api.do(modToken, "GET", fmt.Sprintf("/receptions/%s/history", reception.Id), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history forms.ReceptionHistoryFormOut
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	require.Len(t, history.Events, 1)
+	require.Equal(t, string(models.AuditReceptionVerify), history.Events[0].Action)
+}
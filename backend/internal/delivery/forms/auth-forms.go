@@ -1,6 +1,10 @@
 package forms
 
-import "pvz/internal/models"
+import (
+	"time"
+
+	"pvz/internal/models"
+)
 
 type DummyLoginForm struct {
 	Role string `json:"role"`
@@ -29,4 +33,24 @@ func ToSignUpOut(user models.User) SignInFormOut {
 type LogInFormIn struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// ClientIP заполняется обработчиком из запроса, а не клиентом
+	ClientIP string `json:"-"`
+}
+
+type TokenPairFormOut struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+func ToTokenPairFormOut(pair models.TokenPair) TokenPairFormOut {
+	return TokenPairFormOut{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresAt:    pair.ExpiresAt,
+	}
+}
+
+type RefreshTokenFormIn struct {
+	RefreshToken string `json:"refreshToken"`
 }
@@ -0,0 +1,46 @@
+package forms
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+type CreateCategoryForm struct {
+	Code     string            `json:"code"`
+	Names    map[string]string `json:"names"`
+	ParentId uuid.UUID         `json:"parentId"`
+}
+
+// UpdateCategoryForm - частичное обновление. Нулевой parentId делает категорию корневой
+type UpdateCategoryForm struct {
+	Names    map[string]string `json:"names"`
+	Active   *bool             `json:"active"`
+	ParentId *uuid.UUID        `json:"parentId"`
+}
+
+type CategoryFormOut struct {
+	Id        uuid.UUID         `json:"id"`
+	Code      string            `json:"code"`
+	Names     map[string]string `json:"names"`
+	Active    bool              `json:"active"`
+	ParentId  *uuid.UUID        `json:"parentId,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+func ToCategoryFormOut(category models.Category) CategoryFormOut {
+	form := CategoryFormOut{
+		Id:        category.Id,
+		Code:      category.Code,
+		Names:     category.Names,
+		Active:    category.Active,
+		CreatedAt: category.CreatedAt,
+	}
+	if category.ParentId != uuid.Nil {
+		form.ParentId = &category.ParentId
+	}
+
+	return form
+}
@@ -0,0 +1,34 @@
+package forms
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+type CreateCityForm struct {
+	Name string `json:"name"`
+}
+
+type UpdateCityForm struct {
+	Name    *string `json:"name"`
+	Enabled *bool   `json:"enabled"`
+}
+
+type CityFormOut struct {
+	Id        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func ToCityFormOut(city models.City) CityFormOut {
+	return CityFormOut{
+		Id:        city.Id,
+		Name:      city.Name,
+		Enabled:   city.Enabled,
+		CreatedAt: city.CreatedAt,
+	}
+}
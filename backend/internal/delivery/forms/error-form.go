@@ -3,3 +3,8 @@ package forms
 type ErrorForm struct {
 	Message string `json:"message" example:"error message"`
 }
+
+type LoginLockedErrorForm struct {
+	Message    string `json:"message" example:"account is temporarily locked"`
+	RetryAfter int    `json:"retryAfter" example:"60"`
+}
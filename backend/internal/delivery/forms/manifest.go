@@ -0,0 +1,89 @@
+package forms
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+// ManifestForm - ожидаемая поставка для следующей приёмки ПВЗ, заменяет ранее загруженную
+type ManifestForm struct {
+	Items []ManifestItemForm `json:"items"`
+}
+
+type ManifestItemForm struct {
+	Barcode string `json:"barcode"`
+	Type    string `json:"type"`
+}
+
+type ManifestFormOut struct {
+	Id        uuid.UUID          `json:"id"`
+	PvzId     uuid.UUID          `json:"pvzId"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Items     []ManifestItemForm `json:"items"`
+}
+
+func ToManifestFormOut(manifest models.Manifest) ManifestFormOut {
+	items := make([]ManifestItemForm, 0, len(manifest.Items))
+	for _, item := range manifest.Items {
+		items = append(items, ManifestItemForm{Barcode: item.Barcode, Type: item.ProductType})
+	}
+
+	return ManifestFormOut{
+		Id:        manifest.Id,
+		PvzId:     manifest.PvzId,
+		CreatedAt: manifest.CreatedAt,
+		Items:     items,
+	}
+}
+
+type DiscrepancyFormOut struct {
+	Barcode      string     `json:"barcode,omitempty"`
+	ProductId    *uuid.UUID `json:"productId,omitempty"`
+	ExpectedType string     `json:"expectedType,omitempty"`
+	ActualType   string     `json:"actualType,omitempty"`
+}
+
+// DiscrepancyReportFormOut - расхождения приёмки с манифестом, сгруппированные по виду
+type DiscrepancyReportFormOut struct {
+	ReceptionId   uuid.UUID            `json:"receptionId"`
+	ManifestId    uuid.UUID            `json:"manifestId"`
+	Shortages     []DiscrepancyFormOut `json:"shortages"`
+	Surpluses     []DiscrepancyFormOut `json:"surpluses"`
+	WrongCategory []DiscrepancyFormOut `json:"wrongCategory"`
+}
+
+func ToDiscrepancyReportFormOut(report models.DiscrepancyReport) DiscrepancyReportFormOut {
+	out := DiscrepancyReportFormOut{
+		ReceptionId:   report.ReceptionId,
+		ManifestId:    report.ManifestId,
+		Shortages:     []DiscrepancyFormOut{},
+		Surpluses:     []DiscrepancyFormOut{},
+		WrongCategory: []DiscrepancyFormOut{},
+	}
+
+	for _, item := range report.Items {
+		discrepancy := DiscrepancyFormOut{
+			Barcode:      item.Barcode,
+			ExpectedType: item.ExpectedType,
+			ActualType:   item.ActualType,
+		}
+		if item.ProductId != uuid.Nil {
+			productId := item.ProductId
+			discrepancy.ProductId = &productId
+		}
+
+		switch item.Kind {
+		case models.Shortage:
+			out.Shortages = append(out.Shortages, discrepancy)
+		case models.Surplus:
+			out.Surpluses = append(out.Surpluses, discrepancy)
+		case models.WrongCategory:
+			out.WrongCategory = append(out.WrongCategory, discrepancy)
+		}
+	}
+
+	return out
+}
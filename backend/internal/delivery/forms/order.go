@@ -0,0 +1,60 @@
+package forms
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+type CreateOrderForm struct {
+	ClientId  uuid.UUID `json:"clientId"`
+	ProductId uuid.UUID `json:"productId"`
+}
+
+type IssueOrderForm struct {
+	PvzId      uuid.UUID `json:"pvzId"`
+	PickupCode string    `json:"pickupCode"`
+}
+
+type OrderFormOut struct {
+	Id        uuid.UUID  `json:"id"`
+	ClientId  string     `json:"clientId"`
+	PvzId     uuid.UUID  `json:"pvzId"`
+	ProductId uuid.UUID  `json:"productId"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	IssuedAt  *time.Time `json:"issuedAt,omitempty"`
+}
+
+func ToOrderFormOut(order models.Order) OrderFormOut {
+	out := OrderFormOut{
+		Id:        order.Id,
+		ClientId:  order.ClientId,
+		PvzId:     order.PvzId,
+		ProductId: order.ProductId,
+		Status:    string(order.Status),
+		CreatedAt: order.CreatedAt,
+	}
+
+	if !order.IssuedAt.IsZero() {
+		out.IssuedAt = &order.IssuedAt
+	}
+
+	return out
+}
+
+type PickupCodeFormOut struct {
+	OrderId   uuid.UUID `json:"orderId"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func ToPickupCodeFormOut(code models.PickupCode) PickupCodeFormOut {
+	return PickupCodeFormOut{
+		OrderId:   code.OrderId,
+		Code:      code.Code,
+		ExpiresAt: code.ExpiresAt,
+	}
+}
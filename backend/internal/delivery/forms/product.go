@@ -9,22 +9,135 @@ import (
 )
 
 type ProductForm struct {
-	PvzId uuid.UUID `json:"pvzId"`
-	Type  string    `json:"type"`
+	PvzId           uuid.UUID `json:"pvzId"`
+	Type            string    `json:"type"`
+	Barcode         string    `json:"barcode,omitempty"`
+	ExternalOrderId string    `json:"externalOrderId,omitempty"`
 }
 
 type ProductFormOut struct {
-	Id          uuid.UUID `json:"id"`
-	DateTime    time.Time `json:"dateTime"`
-	ProductType string    `json:"productType"`
-	ReceptionId uuid.UUID `json:"receptionId"`
+	Id              uuid.UUID  `json:"id"`
+	DateTime        time.Time  `json:"dateTime"`
+	ProductType     string     `json:"productType"`
+	ReceptionId     uuid.UUID  `json:"receptionId"`
+	Barcode         string     `json:"barcode,omitempty"`
+	ExternalOrderId string     `json:"externalOrderId,omitempty"`
+	OverCapacity    bool       `json:"overCapacity,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	IssuedAt        *time.Time `json:"issuedAt,omitempty"`
+	ReturnedAt      *time.Time `json:"returnedAt,omitempty"`
+	SentBackAt      *time.Time `json:"sentBackAt,omitempty"`
 }
 
 func ToProductFormOut(product models.Product) ProductFormOut {
-	return ProductFormOut{
-		Id:          product.Id,
-		DateTime:    product.DateTime,
-		ProductType: product.ProductType,
-		ReceptionId: product.ReceptionId,
+	out := ProductFormOut{
+		Id:              product.Id,
+		DateTime:        product.DateTime,
+		ProductType:     product.ProductType,
+		ReceptionId:     product.ReceptionId,
+		Barcode:         product.Barcode,
+		ExternalOrderId: product.ExternalOrderId,
+		OverCapacity:    product.OverCapacity,
+		Status:          string(product.Status),
 	}
+
+	if !product.IssuedAt.IsZero() {
+		out.IssuedAt = &product.IssuedAt
+	}
+	if !product.ReturnedAt.IsZero() {
+		out.ReturnedAt = &product.ReturnedAt
+	}
+	if !product.SentBackAt.IsZero() {
+		out.SentBackAt = &product.SentBackAt
+	}
+
+	return out
+}
+
+func ToProductsFormOut(products []models.Product) []ProductFormOut {
+	res := make([]ProductFormOut, 0, len(products))
+	for _, product := range products {
+		res = append(res, ToProductFormOut(product))
+	}
+
+	return res
+}
+
+// ProductLocationFormOut - результат поиска товара по штрихкоду
+type ProductLocationFormOut struct {
+	Product         ProductFormOut `json:"product"`
+	PvzId           uuid.UUID      `json:"pvzId"`
+	City            string         `json:"city"`
+	ReceptionStatus string         `json:"receptionStatus"`
+}
+
+func ToProductLocationsFormOut(locations []models.ProductLocation) []ProductLocationFormOut {
+	res := make([]ProductLocationFormOut, 0, len(locations))
+	for _, location := range locations {
+		res = append(res, ProductLocationFormOut{
+			Product:         ToProductFormOut(location.Product),
+			PvzId:           location.PvzId,
+			City:            location.City,
+			ReceptionStatus: string(location.ReceptionStatus),
+		})
+	}
+
+	return res
+}
+
+// BatchProductForm - пакет товаров для открытой приёмки одного ПВЗ
+type BatchProductForm struct {
+	PvzId uuid.UUID              `json:"pvzId"`
+	Items []BatchProductItemForm `json:"items"`
+}
+
+type BatchProductItemForm struct {
+	Type            string `json:"type"`
+	Barcode         string `json:"barcode,omitempty"`
+	ExternalOrderId string `json:"externalOrderId,omitempty"`
+}
+
+// статусы позиций пакета
+const (
+	BatchItemCreated  = "created"
+	BatchItemRejected = "rejected"
+	// BatchItemSkipped - позиция корректна, но пакет отклонён из-за других позиций
+	BatchItemSkipped = "skipped"
+)
+
+type BatchProductResultOut struct {
+	Index   int             `json:"index"`
+	Status  string          `json:"status"`
+	Product *ProductFormOut `json:"product,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+type BatchProductsFormOut struct {
+	Message string                  `json:"message,omitempty"`
+	Results []BatchProductResultOut `json:"results"`
+}
+
+func ToBatchProductsFormOut(products []models.Product) BatchProductsFormOut {
+	results := make([]BatchProductResultOut, 0, len(products))
+	for i, product := range products {
+		out := ToProductFormOut(product)
+		results = append(results, BatchProductResultOut{Index: i, Status: BatchItemCreated, Product: &out})
+	}
+
+	return BatchProductsFormOut{Results: results}
+}
+
+// ToRejectedBatchFormOut описывает отклонённый пакет: у ошибочных позиций указана причина, остальные пропущены
+func ToRejectedBatchFormOut(message string, itemsCount int, itemErrors map[int]error) BatchProductsFormOut {
+	results := make([]BatchProductResultOut, 0, itemsCount)
+	for i := 0; i < itemsCount; i++ {
+		result := BatchProductResultOut{Index: i, Status: BatchItemSkipped}
+		if err, ok := itemErrors[i]; ok {
+			result.Status = BatchItemRejected
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return BatchProductsFormOut{Message: message, Results: results}
 }
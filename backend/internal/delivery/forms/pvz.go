@@ -9,16 +9,126 @@ import (
 )
 
 type PvzForm struct {
-	Id               uuid.UUID `json:"id"`
-	RegistrationDate time.Time `json:"registrationDate"`
-	City             string    `json:"city"`
+	Id               uuid.UUID     `json:"id"`
+	RegistrationDate time.Time     `json:"registrationDate"`
+	City             string        `json:"city"`
+	Address          string        `json:"address,omitempty"`
+	Location         *LocationForm `json:"location,omitempty"`
+	OpeningHours     string        `json:"openingHours,omitempty"`
+	Phone            string        `json:"phone,omitempty"`
+	Capacity         int           `json:"capacity,omitempty"`
+	DecommissionedAt *time.Time    `json:"decommissionedAt,omitempty"`
+}
+
+type LocationForm struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+func (l *LocationForm) ToGeoPoint() *models.GeoPoint {
+	if l == nil {
+		return nil
+	}
+
+	return &models.GeoPoint{Latitude: l.Latitude, Longitude: l.Longitude}
 }
 
 func ToPvzForm(pvz models.Pvz) PvzForm {
-	return PvzForm{
+	form := PvzForm{
 		Id:               pvz.Id,
 		RegistrationDate: pvz.RegistrationDate,
 		City:             pvz.City,
+		Address:          pvz.Address,
+		OpeningHours:     pvz.OpeningHours,
+		Phone:            pvz.Phone,
+		Capacity:         pvz.Capacity,
+	}
+	if pvz.Location != nil {
+		form.Location = &LocationForm{Latitude: pvz.Location.Latitude, Longitude: pvz.Location.Longitude}
+	}
+	if !pvz.DecommissionedAt.IsZero() {
+		form.DecommissionedAt = &pvz.DecommissionedAt
+	}
+
+	return form
+}
+
+type UpdatePvzForm struct {
+	City         *string       `json:"city"`
+	Address      *string       `json:"address"`
+	Location     *LocationForm `json:"location"`
+	OpeningHours *string       `json:"openingHours"`
+	Phone        *string       `json:"phone"`
+	Capacity     *int          `json:"capacity"`
+}
+
+// IsEmpty - в запросе на изменение не передано ни одного поля
+func (f UpdatePvzForm) IsEmpty() bool {
+	return f.City == nil && f.Address == nil && f.Location == nil && f.OpeningHours == nil && f.Phone == nil &&
+		f.Capacity == nil
+}
+
+// NearbyPvzForm - поиск ПВЗ в радиусе Radius метров от точки
+type NearbyPvzForm struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+	Limit     int
+}
+
+type NearbyPvzFormOut struct {
+	Pvz      PvzForm `json:"pvz"`
+	Distance float64 `json:"distance"`
+}
+
+func ToNearbyPvzFormOut(result []models.NearbyPvz) []NearbyPvzFormOut {
+	ans := make([]NearbyPvzFormOut, 0, len(result))
+	for _, nearby := range result {
+		ans = append(ans, NearbyPvzFormOut{
+			Pvz:      ToPvzForm(nearby.Pvz),
+			Distance: nearby.Distance,
+		})
+	}
+
+	return ans
+}
+
+type PvzLoadFormOut struct {
+	Pvz         PvzForm `json:"pvz"`
+	Capacity    int     `json:"capacity"`
+	OnHand      int     `json:"onHand"`
+	Utilization float64 `json:"utilization"`
+}
+
+func ToPvzLoadFormOut(result []models.PvzLoad) []PvzLoadFormOut {
+	ans := make([]PvzLoadFormOut, 0, len(result))
+	for _, load := range result {
+		ans = append(ans, PvzLoadFormOut{
+			Pvz:         ToPvzForm(load.Pvz),
+			Capacity:    load.Pvz.Capacity,
+			OnHand:      load.OnHand,
+			Utilization: load.Utilization(),
+		})
+	}
+
+	return ans
+}
+
+type AssignEmployeeForm struct {
+	UserId uuid.UUID `json:"userId"`
+}
+
+type PvzEmployeeFormOut struct {
+	UserId     string    `json:"userId"`
+	PvzId      uuid.UUID `json:"pvzId"`
+	AssignedAt time.Time `json:"assignedAt"`
+}
+
+func ToPvzEmployeeFormOut(employee models.PvzEmployee) PvzEmployeeFormOut {
+	return PvzEmployeeFormOut{
+		UserId:     employee.UserId,
+		PvzId:      employee.PvzId,
+		AssignedAt: employee.AssignedAt,
 	}
 }
 
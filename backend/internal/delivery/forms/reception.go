@@ -10,21 +10,29 @@ import (
 
 type ReceptionForm struct {
 	PvzId uuid.UUID `json:"pvzId"`
+	// ScheduleOverride - открыть приёмку вне часов работы ПВЗ, она будет помечена для проверки модератором
+	ScheduleOverride bool `json:"scheduleOverride"`
+	// OverrideReason - зачем приёмка открывается вне часов работы, попадает в журнал приёмки
+	OverrideReason string `json:"overrideReason,omitempty"`
 }
 
 type ReceptionFormOut struct {
-	Id       uuid.UUID `json:"id"`
-	DateTime time.Time `json:"dateTime"`
-	PvzId    uuid.UUID `json:"pvzId"`
-	Status   string    `json:"status"`
+	Id              uuid.UUID `json:"id"`
+	DateTime        time.Time `json:"dateTime"`
+	PvzId           uuid.UUID `json:"pvzId"`
+	Status          string    `json:"status"`
+	OutsideSchedule bool      `json:"outsideSchedule,omitempty"`
+	AutoClosed      bool      `json:"autoClosed,omitempty"`
 }
 
 func ToReceptionFormOut(reception models.Reception) ReceptionFormOut {
 	return ReceptionFormOut{
-		Id:       reception.Id,
-		DateTime: reception.DateTime,
-		PvzId:    reception.PvzId,
-		Status:   string(reception.Status),
+		Id:              reception.Id,
+		DateTime:        reception.DateTime,
+		PvzId:           reception.PvzId,
+		Status:          string(reception.Status),
+		OutsideSchedule: reception.OutsideSchedule,
+		AutoClosed:      reception.AutoClosed,
 	}
 }
 
@@ -32,3 +40,54 @@ type ReceptionProductsFormOut struct {
 	Reception ReceptionFormOut `json:"reception"`
 	Products  []ProductFormOut `json:"products"`
 }
+
+func ToReceptionProductsFormOut(reception models.ReceptionProducts) ReceptionProductsFormOut {
+	products := make([]ProductFormOut, 0, len(reception.Products))
+	for _, product := range reception.Products {
+		products = append(products, ToProductFormOut(product))
+	}
+
+	return ReceptionProductsFormOut{
+		Reception: ToReceptionFormOut(reception.Reception),
+		Products:  products,
+	}
+}
+
+// ReopenReceptionForm - причина переоткрытия обязательна и попадает в журнал
+type ReopenReceptionForm struct {
+	Reason string `json:"reason"`
+}
+
+// CancelReceptionForm - причина отмены обязательна и попадает в журнал
+type CancelReceptionForm struct {
+	Reason string `json:"reason"`
+}
+
+type ReceptionEventFormOut struct {
+	Action    string            `json:"action"`
+	ActorId   string            `json:"actorId"`
+	Details   map[string]string `json:"details"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type ReceptionHistoryFormOut struct {
+	Reception ReceptionFormOut        `json:"reception"`
+	Events    []ReceptionEventFormOut `json:"events"`
+}
+
+func ToReceptionHistoryFormOut(history models.ReceptionHistory) ReceptionHistoryFormOut {
+	events := make([]ReceptionEventFormOut, 0, len(history.Events))
+	for _, event := range history.Events {
+		events = append(events, ReceptionEventFormOut{
+			Action:    string(event.Action),
+			ActorId:   event.ActorId,
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return ReceptionHistoryFormOut{
+		Reception: ToReceptionFormOut(history.Reception),
+		Events:    events,
+	}
+}
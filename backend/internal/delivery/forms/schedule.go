@@ -0,0 +1,92 @@
+package forms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+type WorkingHoursForm struct {
+	Weekday  int    `json:"weekday"`
+	OpensAt  string `json:"opensAt"`
+	ClosesAt string `json:"closesAt"`
+}
+
+type ScheduleExceptionForm struct {
+	Date        string `json:"date"`
+	OpensAt     string `json:"opensAt,omitempty"`
+	ClosesAt    string `json:"closesAt,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type PvzScheduleForm struct {
+	PvzId    uuid.UUID `json:"pvzId"`
+	Timezone string    `json:"timezone"`
+	// AlwaysOpen - расписание не задано, приёмки открываются в любое время
+	AlwaysOpen bool                    `json:"alwaysOpen"`
+	Week       []WorkingHoursForm      `json:"week"`
+	Exceptions []ScheduleExceptionForm `json:"exceptions"`
+}
+
+func ToPvzScheduleForm(schedule models.PvzSchedule) PvzScheduleForm {
+	form := PvzScheduleForm{
+		PvzId:      schedule.PvzId,
+		Timezone:   schedule.Timezone,
+		AlwaysOpen: !schedule.IsConfigured(),
+		Week:       make([]WorkingHoursForm, 0, len(schedule.Week)),
+		Exceptions: make([]ScheduleExceptionForm, 0, len(schedule.Exceptions)),
+	}
+
+	for _, hours := range schedule.Week {
+		form.Week = append(form.Week, WorkingHoursForm{
+			Weekday:  hours.Weekday,
+			OpensAt:  hours.OpensAt,
+			ClosesAt: hours.ClosesAt,
+		})
+	}
+
+	for _, exception := range schedule.Exceptions {
+		form.Exceptions = append(form.Exceptions, ScheduleExceptionForm{
+			Date:        exception.Date.Format(models.DateLayout),
+			OpensAt:     exception.OpensAt,
+			ClosesAt:    exception.ClosesAt,
+			Description: exception.Description,
+		})
+	}
+
+	return form
+}
+
+func ToPvzSchedule(pvzId uuid.UUID, form PvzScheduleForm) (models.PvzSchedule, error) {
+	schedule := models.PvzSchedule{
+		PvzId:    pvzId,
+		Timezone: form.Timezone,
+	}
+
+	for _, hours := range form.Week {
+		schedule.Week = append(schedule.Week, models.WorkingHours{
+			Weekday:  hours.Weekday,
+			OpensAt:  hours.OpensAt,
+			ClosesAt: hours.ClosesAt,
+		})
+	}
+
+	for _, exception := range form.Exceptions {
+		date, err := time.Parse(models.DateLayout, exception.Date)
+		if err != nil {
+			return models.PvzSchedule{}, fmt.Errorf("date %q must be in YYYY-MM-DD format", exception.Date)
+		}
+
+		schedule.Exceptions = append(schedule.Exceptions, models.ScheduleException{
+			Date:        date,
+			OpensAt:     exception.OpensAt,
+			ClosesAt:    exception.ClosesAt,
+			Description: exception.Description,
+		})
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,27 @@
+package forms
+
+import "pvz/internal/models"
+
+type UserFormOut struct {
+	Id     string `json:"id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+func ToUserFormOut(user models.User) UserFormOut {
+	return UserFormOut{
+		Id:     user.Id,
+		Email:  user.Email,
+		Role:   user.Role,
+		Status: string(user.Status),
+	}
+}
+
+type ChangeRoleForm struct {
+	Role string `json:"role"`
+}
+
+type ResetPasswordFormOut struct {
+	TemporaryPassword string `json:"temporaryPassword"`
+}
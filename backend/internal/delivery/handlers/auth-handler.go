@@ -3,11 +3,17 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"pvz/internal/delivery/forms"
 	"pvz/internal/models"
+	"pvz/internal/usecase"
 	"pvz/internal/utils"
 	"pvz/pkg/logger"
 )
@@ -16,16 +22,23 @@ type AuthUseCase interface {
 	DummyLogin(ctx context.Context, role string) (string, error)
 	CreateUser(ctx context.Context, signUpForm forms.SignUpFormIn) (models.User, error)
 	IsUserExist(ctx context.Context, email string) (bool, error)
-	LogInUser(ctx context.Context, logInForm forms.LogInFormIn) (string, error)
+	LogInUser(ctx context.Context, logInForm forms.LogInFormIn) (models.TokenPair, error)
+	RefreshToken(ctx context.Context, refreshToken string) (models.TokenPair, error)
+	Logout(ctx context.Context, principal models.Principal, refreshToken string) error
+	GetJWKS(ctx context.Context) models.JSONWebKeySet
+	UnlockUser(ctx context.Context, userId string) error
 }
 
 type AuthHandler struct {
 	authUseCase AuthUseCase
+	policy      *models.Policy
 }
 
-func NewAuthHandler(authUseCase AuthUseCase) *AuthHandler {
+// NewAuthHandler - policy задаёт роли, с которыми можно зарегистрироваться и получить dummy-токен
+func NewAuthHandler(authUseCase AuthUseCase, policy *models.Policy) *AuthHandler {
 	return &AuthHandler{
 		authUseCase: authUseCase,
+		policy:      policy,
 	}
 }
 
@@ -41,7 +54,7 @@ func (a *AuthHandler) DummyLogin(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info(r.Context(), "Successfully parsed json")
 
-	if utils.ValidateRole(dummyLoginForm.Role) == false {
+	if utils.ValidateRole(a.policy, dummyLoginForm.Role) == false {
 		logger.Error(r.Context(), fmt.Sprintf("Role %s is not valid", dummyLoginForm.Role))
 		utils.WriteJsonError(w, "Incorrect role was given", http.StatusBadRequest)
 		return
@@ -70,7 +83,7 @@ func (a *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info(r.Context(), "Successfully parsed json")
 
-	if err := utils.ValidateAll(signUpForm.Email, signUpForm.Role); err != nil {
+	if err := utils.ValidateAll(a.policy, signUpForm.Email, signUpForm.Role); err != nil {
 		logger.Error(r.Context(), err.Error())
 		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
 		return
@@ -107,19 +120,123 @@ func (a *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	role, err := a.authUseCase.LogInUser(r.Context(), logInForm)
+	logInForm.ClientIP = utils.ClientIP(r)
+
+	tokenPair, err := a.authUseCase.LogInUser(r.Context(), logInForm)
+	var lockErr *usecase.LoginLockError
+	if errors.As(err, &lockErr) {
+		writeLoginLocked(w, lockErr)
+		return
+	}
+	if errors.Is(err, usecase.AccountDeactivated) {
+		utils.WriteJsonError(w, "account is deactivated", http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		utils.WriteJsonError(w, "Wrong auth data", http.StatusUnauthorized)
 		return
 	}
 
-	token, err := a.authUseCase.DummyLogin(r.Context(), role)
+	utils.WriteJson(w, forms.ToTokenPairFormOut(tokenPair), http.StatusOK)
+
+	logger.Info(r.Context(), "Successfully processed login request")
+}
+
+func (a *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got refresh token request")
+
+	var refreshForm forms.RefreshTokenFormIn
+	if err := json.NewDecoder(r.Body).Decode(&refreshForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if refreshForm.RefreshToken == "" {
+		logger.Error(r.Context(), "Refresh token is missing")
+		utils.WriteJsonError(w, "refresh token is required", http.StatusBadRequest)
+		return
+	}
+
+	tokenPair, err := a.authUseCase.RefreshToken(r.Context(), refreshForm.RefreshToken)
 	if err != nil {
-		utils.WriteJsonError(w, "failed to gen.bat token", http.StatusUnauthorized)
+		utils.WriteJsonError(w, "invalid refresh token", http.StatusUnauthorized)
 		return
 	}
 
-	utils.WriteJson(w, token, http.StatusOK)
+	utils.WriteJson(w, forms.ToTokenPairFormOut(tokenPair), http.StatusOK)
 
-	logger.Info(r.Context(), "Successfully processed login request")
+	logger.Info(r.Context(), "Successfully processed refresh token request")
+}
+
+func (a *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got logout request")
+
+	principal, ok := utils.GetPrincipal(r.Context())
+	if !ok {
+		logger.Error(r.Context(), "Principal is missing in context")
+		utils.WriteJsonError(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	var logoutForm forms.RefreshTokenFormIn
+	if err := json.NewDecoder(r.Body).Decode(&logoutForm); err != nil && !errors.Is(err, io.EOF) {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.authUseCase.Logout(r.Context(), principal, logoutForm.RefreshToken); err != nil {
+		utils.WriteJsonError(w, "failed to logout", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	logger.Info(r.Context(), "Successfully processed logout request")
+}
+
+func (a *AuthHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	utils.WriteJson(w, a.authUseCase.GetJWKS(r.Context()), http.StatusOK)
+}
+
+func (a *AuthHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got unlock user request, trying to parse path params")
+
+	userId, ok := parseUserId(w, r)
+	if !ok {
+		return
+	}
+
+	err := a.authUseCase.UnlockUser(r.Context(), userId)
+	if errors.Is(err, usecase.UserNotFound) {
+		utils.WriteJsonError(w, "user not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to unlock user", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeLoginLocked отвечает 423 на блокировку аккаунта и 429 на блокировку IP, время ожидания в Retry-After
+func writeLoginLocked(w http.ResponseWriter, lockErr *usecase.LoginLockError) {
+	retryAfter := int(math.Ceil(time.Until(lockErr.Until).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	statusCode := http.StatusLocked
+	if errors.Is(lockErr, usecase.TooManyLoginAttempts) {
+		statusCode = http.StatusTooManyRequests
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	utils.WriteJson(w, forms.LoginLockedErrorForm{
+		Message:    lockErr.Reason.Error(),
+		RetryAfter: retryAfter,
+	}, statusCode)
 }
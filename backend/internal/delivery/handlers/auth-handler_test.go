@@ -9,14 +9,19 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"pvz/internal/delivery/forms"
 	"pvz/internal/delivery/handlers"
 	"pvz/internal/delivery/mocks"
 	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/utils"
 )
 
 func TestDummyLogin(t *testing.T) {
@@ -24,7 +29,7 @@ func TestDummyLogin(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUC := mocks.NewMockAuthUseCase(ctrl)
-	handler := handlers.NewAuthHandler(mockUC)
+	handler := handlers.NewAuthHandler(mockUC, models.DefaultPolicy())
 
 	tests := []struct {
 		name         string
@@ -114,7 +119,7 @@ func TestRegister(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUC := mocks.NewMockAuthUseCase(ctrl)
-	handler := handlers.NewAuthHandler(mockUC)
+	handler := handlers.NewAuthHandler(mockUC, models.DefaultPolicy())
 
 	validUser := models.User{
 		Id:    "123",
@@ -241,15 +246,15 @@ func TestLogin(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUC := mocks.NewMockAuthUseCase(ctrl)
-	handler := handlers.NewAuthHandler(mockUC)
+	handler := handlers.NewAuthHandler(mockUC, models.DefaultPolicy())
+
+	expiresAt := time.Date(2025, 4, 23, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name         string
 		input        forms.LogInFormIn
-		role         string
+		tokenPair    models.TokenPair
 		loginErr     error
-		token        string
-		tokenErr     error
 		expectStatus int
 		expectBody   string
 	}{
@@ -259,10 +264,9 @@ func TestLogin(t *testing.T) {
 				Email:    "email@test.com",
 				Password: "pass",
 			},
-			role:         string(models.Moderator),
-			token:        "token",
+			tokenPair:    models.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: expiresAt},
 			expectStatus: http.StatusOK,
-			expectBody:   `"token"`,
+			expectBody:   `{"accessToken":"access","refreshToken":"refresh","expiresAt":"2025-04-23T12:00:00Z"}`,
 		},
 		{
 			name: "invalid credentials",
@@ -275,15 +279,34 @@ func TestLogin(t *testing.T) {
 			expectBody:   `{"message":"Wrong auth data"}`,
 		},
 		{
-			name: "token generation error",
+			name: "deactivated account",
 			input: forms.LogInFormIn{
 				Email:    "email@test.com",
 				Password: "pass",
 			},
-			role:         string(models.Client),
-			tokenErr:     errors.New("token gen.bat fail"),
-			expectStatus: http.StatusUnauthorized,
-			expectBody:   `{"message":"failed to gen.bat token"}`,
+			loginErr:     usecase.AccountDeactivated,
+			expectStatus: http.StatusForbidden,
+			expectBody:   `{"message":"account is deactivated"}`,
+		},
+		{
+			name: "locked account",
+			input: forms.LogInFormIn{
+				Email:    "email@test.com",
+				Password: "pass",
+			},
+			loginErr:     &usecase.LoginLockError{Reason: usecase.AccountLocked, Until: time.Now().Add(90 * time.Second)},
+			expectStatus: http.StatusLocked,
+			expectBody:   `{"message":"account is temporarily locked","retryAfter":90}`,
+		},
+		{
+			name: "locked ip",
+			input: forms.LogInFormIn{
+				Email:    "email@test.com",
+				Password: "pass",
+			},
+			loginErr:     &usecase.LoginLockError{Reason: usecase.TooManyLoginAttempts, Until: time.Now().Add(90 * time.Second)},
+			expectStatus: http.StatusTooManyRequests,
+			expectBody:   `{"message":"too many login attempts","retryAfter":90}`,
 		},
 		{
 			name:         "failed to parse json",
@@ -306,17 +329,10 @@ func TestLogin(t *testing.T) {
 				return
 			}
 
-			if tt.loginErr == nil {
-				mockUC.EXPECT().LogInUser(gomock.Any(), tt.input).Return(tt.role, nil)
-			} else {
-				mockUC.EXPECT().LogInUser(gomock.Any(), tt.input).Return("", tt.loginErr)
-			}
-
-			if tt.tokenErr == nil && tt.expectStatus == http.StatusOK {
-				mockUC.EXPECT().DummyLogin(gomock.Any(), tt.role).Return(tt.token, nil)
-			} else if tt.tokenErr != nil {
-				mockUC.EXPECT().DummyLogin(gomock.Any(), tt.role).Return("", tt.tokenErr)
-			}
+			// httptest.NewRequest выставляет RemoteAddr 192.0.2.1:1234
+			expected := tt.input
+			expected.ClientIP = "192.0.2.1"
+			mockUC.EXPECT().LogInUser(gomock.Any(), expected).Return(tt.tokenPair, tt.loginErr)
 
 			body, _ := json.Marshal(tt.input)
 			req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
@@ -327,7 +343,222 @@ func TestLogin(t *testing.T) {
 			assert.Equal(t, tt.expectStatus, rec.Code)
 			respBody := rec.Body.String()
 			assert.JSONEq(t, tt.expectBody, respBody)
+			if tt.expectStatus == http.StatusLocked || tt.expectStatus == http.StatusTooManyRequests {
+				assert.Equal(t, "90", rec.Header().Get("Retry-After"))
+			}
+		})
+
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockAuthUseCase(ctrl)
+	handler := handlers.NewAuthHandler(mockUC, models.DefaultPolicy())
+
+	expiresAt := time.Date(2025, 4, 23, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		body         string
+		mock         func()
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name: "success",
+			body: `{"refreshToken":"refresh"}`,
+			mock: func() {
+				mockUC.EXPECT().RefreshToken(gomock.Any(), "refresh").
+					Return(models.TokenPair{AccessToken: "access2", RefreshToken: "refresh2", ExpiresAt: expiresAt}, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `{"accessToken":"access2","refreshToken":"refresh2","expiresAt":"2025-04-23T12:00:00Z"}`,
+		},
+		{
+			name:         "missing refresh token",
+			body:         `{}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"refresh token is required"}`,
+		},
+		{
+			name:         "invalid json",
+			body:         `{invalid`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"failed to parse json"}`,
+		},
+		{
+			name: "invalid refresh token",
+			body: `{"refreshToken":"stolen"}`,
+			mock: func() {
+				mockUC.EXPECT().RefreshToken(gomock.Any(), "stolen").Return(models.TokenPair{}, errors.New("invalid"))
+			},
+			expectStatus: http.StatusUnauthorized,
+			expectBody:   `{"message":"invalid refresh token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPost, "/token/refresh", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handler.RefreshToken(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			assert.JSONEq(t, tt.expectBody, rec.Body.String())
 		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockAuthUseCase(ctrl)
+	handler := handlers.NewAuthHandler(mockUC, models.DefaultPolicy())
+
+	principal := models.Principal{UserId: "user", TokenId: "jti", Role: string(models.Employee)}
 
+	tests := []struct {
+		name          string
+		body          string
+		withPrincipal bool
+		mock          func()
+		expectStatus  int
+	}{
+		{
+			name:          "logout without body",
+			withPrincipal: true,
+			mock: func() {
+				mockUC.EXPECT().Logout(gomock.Any(), principal, "").Return(nil)
+			},
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:          "logout with refresh token",
+			body:          `{"refreshToken":"refresh"}`,
+			withPrincipal: true,
+			mock: func() {
+				mockUC.EXPECT().Logout(gomock.Any(), principal, "refresh").Return(nil)
+			},
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:         "missing principal",
+			mock:         func() {},
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "usecase error",
+			withPrincipal: true,
+			mock: func() {
+				mockUC.EXPECT().Logout(gomock.Any(), principal, "").Return(errors.New("db error"))
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPost, "/logout", strings.NewReader(tt.body))
+			if tt.withPrincipal {
+				req = req.WithContext(utils.SetPrincipal(req.Context(), principal))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.Logout(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestGetJWKS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockAuthUseCase(ctrl)
+	handler := handlers.NewAuthHandler(mockUC, models.DefaultPolicy())
+
+	mockUC.EXPECT().GetJWKS(gomock.Any()).Return(models.JSONWebKeySet{Keys: []models.JSONWebKey{
+		{Kty: "OKP", Use: "sig", Kid: "key-1", Alg: "EdDSA", Crv: "Ed25519", X: "x"},
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+
+	handler.GetJWKS(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"keys":[{"kty":"OKP","use":"sig","kid":"key-1","alg":"EdDSA","crv":"Ed25519","x":"x"}]}`, rec.Body.String())
+}
+
+func TestUnlockUser(t *testing.T) {
+	userId := uuid.NewString()
+
+	tests := []struct {
+		name         string
+		userId       string
+		expectCall   bool
+		unlockErr    error
+		expectStatus int
+	}{
+		{
+			name:         "ok",
+			userId:       userId,
+			expectCall:   true,
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:         "invalid userId",
+			userId:       "invalid",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "user not found",
+			userId:       userId,
+			expectCall:   true,
+			unlockErr:    usecase.UserNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "usecase error",
+			userId:       userId,
+			expectCall:   true,
+			unlockErr:    errors.New("db error"),
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUC := mocks.NewMockAuthUseCase(ctrl)
+			handler := handlers.NewAuthHandler(mockUC, models.DefaultPolicy())
+
+			if tt.expectCall {
+				mockUC.EXPECT().UnlockUser(gomock.Any(), tt.userId).Return(tt.unlockErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/"+tt.userId+"/unlock", nil)
+			req = mux.SetURLVars(req, map[string]string{"userId": tt.userId})
+			rec := httptest.NewRecorder()
+
+			handler.UnlockUser(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
 	}
 }
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type CategoryUseCase interface {
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	CreateCategory(ctx context.Context, form forms.CreateCategoryForm) (models.Category, error)
+	UpdateCategory(ctx context.Context, categoryId uuid.UUID, form forms.UpdateCategoryForm) (models.Category, error)
+}
+
+type CategoryHandler struct {
+	categoryUseCase CategoryUseCase
+}
+
+func NewCategoryHandler(categoryUseCase CategoryUseCase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+func (ch *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got list categories request")
+
+	categories, err := ch.categoryUseCase.ListCategories(r.Context())
+	if err != nil {
+		utils.WriteJsonError(w, "failed to list categories", http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]forms.CategoryFormOut, 0, len(categories))
+	for _, category := range categories {
+		res = append(res, forms.ToCategoryFormOut(category))
+	}
+
+	utils.WriteJson(w, res, http.StatusOK)
+}
+
+func (ch *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got create category request, trying to parse json")
+
+	var createForm forms.CreateCategoryForm
+	if err := json.NewDecoder(r.Body).Decode(&createForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.ValidateCategoryCode(createForm.Code); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Category validation error: %s", err.Error()))
+		utils.WriteJsonError(w, "Invalid category code", http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.ValidateCategoryNames(createForm.Names); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Category validation error: %s", err.Error()))
+		utils.WriteJsonError(w, "Invalid category names", http.StatusBadRequest)
+		return
+	}
+
+	category, err := ch.categoryUseCase.CreateCategory(r.Context(), createForm)
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToCategoryFormOut(category), http.StatusCreated)
+}
+
+func (ch *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got update category request, trying to parse path params")
+
+	categoryId, err := uuid.Parse(mux.Vars(r)["categoryId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid categoryId")
+		utils.WriteJsonError(w, "invalid categoryId", http.StatusBadRequest)
+		return
+	}
+
+	var updateForm forms.UpdateCategoryForm
+	if err = json.NewDecoder(r.Body).Decode(&updateForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if updateForm.Names != nil {
+		if err = utils.ValidateCategoryNames(updateForm.Names); err != nil {
+			logger.Error(r.Context(), fmt.Sprintf("Category validation error: %s", err.Error()))
+			utils.WriteJsonError(w, "Invalid category names", http.StatusBadRequest)
+			return
+		}
+	}
+
+	category, err := ch.categoryUseCase.UpdateCategory(r.Context(), categoryId, updateForm)
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToCategoryFormOut(category), http.StatusOK)
+}
+
+func writeCategoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.CategoryNotFound):
+		utils.WriteJsonError(w, "category not found", http.StatusNotFound)
+	case errors.Is(err, usecase.ParentCategoryNotFound):
+		utils.WriteJsonError(w, "parent category not found", http.StatusBadRequest)
+	case errors.Is(err, usecase.CategoryCycle):
+		utils.WriteJsonError(w, "category can't be nested into itself or its descendant", http.StatusBadRequest)
+	case errors.Is(err, usecase.CategoryAlreadyExists):
+		utils.WriteJsonError(w, "category already exists", http.StatusConflict)
+	default:
+		utils.WriteJsonError(w, "failed to save category", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,189 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/delivery/handlers"
+	"pvz/internal/delivery/mocks"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+)
+
+func TestListCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockCategoryUseCase(ctrl)
+	handler := handlers.NewCategoryHandler(mockUC)
+
+	parentId := uuid.New()
+	mockUC.EXPECT().ListCategories(gomock.Any()).Return([]models.Category{
+		{Id: parentId, Code: "электроника", Names: map[string]string{"ru": "Электроника"}, Active: true},
+		{Id: uuid.New(), Code: "телефоны", Names: map[string]string{"ru": "Телефоны"}, Active: true, ParentId: parentId},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+	rec := httptest.NewRecorder()
+	handler.ListCategories(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"parentId":"`+parentId.String()+`"`)
+	assert.Equal(t, 1, strings.Count(rec.Body.String(), `"parentId"`))
+
+	mockUC.EXPECT().ListCategories(gomock.Any()).Return(nil, errors.New("db error"))
+	rec = httptest.NewRecorder()
+	handler.ListCategories(rec, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestCreateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockCategoryUseCase(ctrl)
+	handler := handlers.NewCategoryHandler(mockUC)
+
+	tests := []struct {
+		name         string
+		body         string
+		mock         func()
+		expectStatus int
+	}{
+		{
+			name: "success",
+			body: `{"code":"книги","names":{"ru":"Книги","en":"Books"}}`,
+			mock: func() {
+				mockUC.EXPECT().CreateCategory(gomock.Any(), forms.CreateCategoryForm{
+					Code:  "книги",
+					Names: map[string]string{"ru": "Книги", "en": "Books"},
+				}).Return(models.Category{Id: uuid.New(), Code: "книги", Active: true}, nil)
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "empty code",
+			body:         `{"code":"","names":{"ru":"Книги"}}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "without russian name",
+			body:         `{"code":"книги","names":{"en":"Books"}}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "parent not found",
+			body: `{"code":"книги","names":{"ru":"Книги"},"parentId":"` + uuid.NewString() + `"}`,
+			mock: func() {
+				mockUC.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(models.Category{}, usecase.ParentCategoryNotFound)
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "already exists",
+			body: `{"code":"обувь","names":{"ru":"Обувь"}}`,
+			mock: func() {
+				mockUC.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(models.Category{}, usecase.CategoryAlreadyExists)
+			},
+			expectStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handler.CreateCategory(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockCategoryUseCase(ctrl)
+	handler := handlers.NewCategoryHandler(mockUC)
+
+	categoryId := uuid.New()
+
+	tests := []struct {
+		name         string
+		categoryId   string
+		body         string
+		mock         func()
+		expectStatus int
+	}{
+		{
+			name:       "deactivate",
+			categoryId: categoryId.String(),
+			body:       `{"active":false}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateCategory(gomock.Any(), categoryId, gomock.Any()).Return(models.Category{Id: categoryId}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "invalid categoryId",
+			categoryId:   "invalid",
+			body:         `{}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid names",
+			categoryId:   categoryId.String(),
+			body:         `{"names":{"en":"Shoes"}}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "cycle",
+			categoryId: categoryId.String(),
+			body:       `{"parentId":"` + categoryId.String() + `"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateCategory(gomock.Any(), categoryId, gomock.Any()).Return(models.Category{}, usecase.CategoryCycle)
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "not found",
+			categoryId: categoryId.String(),
+			body:       `{"active":true}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateCategory(gomock.Any(), categoryId, gomock.Any()).Return(models.Category{}, usecase.CategoryNotFound)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPatch, "/categories/"+tt.categoryId, strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"categoryId": tt.categoryId})
+			rec := httptest.NewRecorder()
+
+			handler.UpdateCategory(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type CityUseCase interface {
+	ListCities(ctx context.Context) ([]models.City, error)
+	CreateCity(ctx context.Context, form forms.CreateCityForm) (models.City, error)
+	UpdateCity(ctx context.Context, cityId uuid.UUID, form forms.UpdateCityForm) (models.City, error)
+}
+
+type CityHandler struct {
+	cityUseCase CityUseCase
+}
+
+func NewCityHandler(cityUseCase CityUseCase) *CityHandler {
+	return &CityHandler{
+		cityUseCase: cityUseCase,
+	}
+}
+
+func (ch *CityHandler) ListCities(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got list cities request")
+
+	cities, err := ch.cityUseCase.ListCities(r.Context())
+	if err != nil {
+		utils.WriteJsonError(w, "failed to list cities", http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]forms.CityFormOut, 0, len(cities))
+	for _, city := range cities {
+		res = append(res, forms.ToCityFormOut(city))
+	}
+
+	utils.WriteJson(w, res, http.StatusOK)
+}
+
+func (ch *CityHandler) CreateCity(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got create city request, trying to parse json")
+
+	var createForm forms.CreateCityForm
+	if err := json.NewDecoder(r.Body).Decode(&createForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.ValidateCityName(createForm.Name); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("City validation error: %s", err.Error()))
+		utils.WriteJsonError(w, "Invalid city name", http.StatusBadRequest)
+		return
+	}
+
+	city, err := ch.cityUseCase.CreateCity(r.Context(), createForm)
+	if errors.Is(err, usecase.CityAlreadyExists) {
+		utils.WriteJsonError(w, "city already exists", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to create city", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToCityFormOut(city), http.StatusCreated)
+}
+
+func (ch *CityHandler) UpdateCity(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got update city request, trying to parse path params")
+
+	cityId, err := uuid.Parse(mux.Vars(r)["cityId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid cityId")
+		utils.WriteJsonError(w, "invalid cityId", http.StatusBadRequest)
+		return
+	}
+
+	var updateForm forms.UpdateCityForm
+	if err = json.NewDecoder(r.Body).Decode(&updateForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if updateForm.Name != nil {
+		if err = utils.ValidateCityName(*updateForm.Name); err != nil {
+			logger.Error(r.Context(), fmt.Sprintf("City validation error: %s", err.Error()))
+			utils.WriteJsonError(w, "Invalid city name", http.StatusBadRequest)
+			return
+		}
+	}
+
+	city, err := ch.cityUseCase.UpdateCity(r.Context(), cityId, updateForm)
+	switch {
+	case errors.Is(err, usecase.CityNotFound):
+		utils.WriteJsonError(w, "city not found", http.StatusNotFound)
+		return
+	case errors.Is(err, usecase.CityAlreadyExists):
+		utils.WriteJsonError(w, "city already exists", http.StatusConflict)
+		return
+	case err != nil:
+		utils.WriteJsonError(w, "failed to update city", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToCityFormOut(city), http.StatusOK)
+}
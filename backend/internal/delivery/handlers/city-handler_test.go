@@ -0,0 +1,178 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/delivery/handlers"
+	"pvz/internal/delivery/mocks"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+)
+
+func TestListCities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockCityUseCase(ctrl)
+	handler := handlers.NewCityHandler(mockUC)
+
+	cityId := uuid.New()
+	mockUC.EXPECT().ListCities(gomock.Any()).Return([]models.City{{Id: cityId, Name: "Казань", Enabled: true}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/cities", nil)
+	rec := httptest.NewRecorder()
+	handler.ListCities(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"Казань"`)
+	assert.Contains(t, rec.Body.String(), cityId.String())
+
+	mockUC.EXPECT().ListCities(gomock.Any()).Return(nil, errors.New("db error"))
+	rec = httptest.NewRecorder()
+	handler.ListCities(rec, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestCreateCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockCityUseCase(ctrl)
+	handler := handlers.NewCityHandler(mockUC)
+
+	tests := []struct {
+		name         string
+		body         string
+		mock         func()
+		expectStatus int
+	}{
+		{
+			name: "success",
+			body: `{"name":"Томск"}`,
+			mock: func() {
+				mockUC.EXPECT().CreateCity(gomock.Any(), forms.CreateCityForm{Name: "Томск"}).
+					Return(models.City{Id: uuid.New(), Name: "Томск", Enabled: true}, nil)
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "empty name",
+			body:         `{"name":""}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid json",
+			body:         `{`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "already exists",
+			body: `{"name":"Казань"}`,
+			mock: func() {
+				mockUC.EXPECT().CreateCity(gomock.Any(), gomock.Any()).Return(models.City{}, usecase.CityAlreadyExists)
+			},
+			expectStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPost, "/cities", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handler.CreateCity(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestUpdateCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockCityUseCase(ctrl)
+	handler := handlers.NewCityHandler(mockUC)
+
+	cityId := uuid.New()
+	enabled := false
+
+	tests := []struct {
+		name         string
+		cityId       string
+		body         string
+		mock         func()
+		expectStatus int
+	}{
+		{
+			name:   "disable",
+			cityId: cityId.String(),
+			body:   `{"enabled":false}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateCity(gomock.Any(), cityId, forms.UpdateCityForm{Enabled: &enabled}).
+					Return(models.City{Id: cityId, Name: "Казань"}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "invalid cityId",
+			cityId:       "invalid",
+			body:         `{}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid name",
+			cityId:       cityId.String(),
+			body:         `{"name":" "}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "not found",
+			cityId: cityId.String(),
+			body:   `{"name":"Томск"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateCity(gomock.Any(), cityId, gomock.Any()).Return(models.City{}, usecase.CityNotFound)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:   "name is taken",
+			cityId: cityId.String(),
+			body:   `{"name":"Москва"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateCity(gomock.Any(), cityId, gomock.Any()).Return(models.City{}, usecase.CityAlreadyExists)
+			},
+			expectStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPatch, "/cities/"+tt.cityId, strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"cityId": tt.cityId})
+			rec := httptest.NewRecorder()
+
+			handler.UpdateCity(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
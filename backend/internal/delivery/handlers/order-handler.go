@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type OrderUseCase interface {
+	CreateOrder(ctx context.Context, orderForm forms.CreateOrderForm) (models.Order, error)
+	GetMyOrders(ctx context.Context) ([]models.Order, error)
+	CreatePickupCode(ctx context.Context, orderId uuid.UUID) (models.PickupCode, error)
+	IssueOrder(ctx context.Context, issueForm forms.IssueOrderForm) (models.Order, error)
+}
+
+type OrderHandler struct {
+	orderUseCase OrderUseCase
+}
+
+func NewOrderHandler(orderUseCase OrderUseCase) *OrderHandler {
+	return &OrderHandler{
+		orderUseCase: orderUseCase,
+	}
+}
+
+func (oh *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got create order request")
+
+	var orderForm forms.CreateOrderForm
+	if err := json.NewDecoder(r.Body).Decode(&orderForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "Error decoding json", http.StatusBadRequest)
+		return
+	}
+
+	if orderForm.ClientId == uuid.Nil || orderForm.ProductId == uuid.Nil {
+		logger.Error(r.Context(), "Missing clientId or productId")
+		utils.WriteJsonError(w, "clientId and productId are required", http.StatusBadRequest)
+		return
+	}
+
+	order, err := oh.orderUseCase.CreateOrder(r.Context(), orderForm)
+	if errors.Is(err, usecase.ProductNotFound) {
+		utils.WriteJsonError(w, "product not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, usecase.ProductNotOrderable) {
+		utils.WriteJsonError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "non-existing client or product already has an order", http.StatusBadRequest)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToOrderFormOut(order), http.StatusCreated)
+}
+
+func (oh *OrderHandler) GetMyOrders(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got get my orders request")
+
+	orders, err := oh.orderUseCase.GetMyOrders(r.Context())
+	if err != nil {
+		utils.WriteJsonError(w, "unable to get orders", http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]forms.OrderFormOut, 0, len(orders))
+	for _, order := range orders {
+		res = append(res, forms.ToOrderFormOut(order))
+	}
+
+	utils.WriteJson(w, res, http.StatusOK)
+}
+
+func (oh *OrderHandler) CreatePickupCode(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got create pickup code request, trying to parse path params")
+
+	orderId, err := uuid.Parse(mux.Vars(r)["orderId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid orderId")
+		utils.WriteJsonError(w, "invalid orderId", http.StatusBadRequest)
+		return
+	}
+
+	code, err := oh.orderUseCase.CreatePickupCode(r.Context(), orderId)
+	if errors.Is(err, usecase.OrderNotFound) {
+		utils.WriteJsonError(w, "order not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, usecase.OrderAlreadyIssued) {
+		utils.WriteJsonError(w, "order is already issued", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to create pickup code", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	utils.WriteJson(w, forms.ToPickupCodeFormOut(code), http.StatusCreated)
+}
+
+func (oh *OrderHandler) IssueOrder(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got issue order request")
+
+	var issueForm forms.IssueOrderForm
+	if err := json.NewDecoder(r.Body).Decode(&issueForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "Error decoding json", http.StatusBadRequest)
+		return
+	}
+
+	if issueForm.PvzId == uuid.Nil || issueForm.PickupCode == "" {
+		logger.Error(r.Context(), "Missing pvzId or pickupCode")
+		utils.WriteJsonError(w, "pvzId and pickupCode are required", http.StatusBadRequest)
+		return
+	}
+
+	order, err := oh.orderUseCase.IssueOrder(r.Context(), issueForm)
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, usecase.InvalidPickupCode) {
+		utils.WriteJsonError(w, "invalid or expired pickup code", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to issue order", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToOrderFormOut(order), http.StatusOK)
+}
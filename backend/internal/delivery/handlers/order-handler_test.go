@@ -0,0 +1,269 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/delivery/handlers"
+	"pvz/internal/delivery/mocks"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+)
+
+func TestOrderHandler_CreateOrder(t *testing.T) {
+	form := forms.CreateOrderForm{ClientId: uuid.New(), ProductId: uuid.New()}
+	orderId := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       io.Reader
+		expectCall bool
+		mockReturn models.Order
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "ok",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockReturn: models.Order{Id: orderId, ClientId: form.ClientId.String(), ProductId: form.ProductId, Status: models.OrderWaiting},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "invalid json",
+			body:       strings.NewReader("invalid"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing productId",
+			body:       toJSONBody(forms.CreateOrderForm{ClientId: form.ClientId}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "product not found",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  usecase.ProductNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "product is not in stock",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  &usecase.ProductNotOrderableError{ProductStatus: models.ProductIssued, ReceptionStatus: models.Closed},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "not assigned to pvz",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  usecase.PvzAccessDenied,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "usecase error",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  errors.New("some error"),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockOrderUseCase(ctrl)
+			h := handlers.NewOrderHandler(mockUseCase)
+
+			if tt.expectCall {
+				mockUseCase.EXPECT().CreateOrder(gomock.Any(), form).Return(tt.mockReturn, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/orders", tt.body)
+			rec := httptest.NewRecorder()
+
+			h.CreateOrder(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusCreated {
+				var out forms.OrderFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, orderId, out.Id)
+				require.Equal(t, string(models.OrderWaiting), out.Status)
+				require.Nil(t, out.IssuedAt)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_GetMyOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUseCase := mocks.NewMockOrderUseCase(ctrl)
+	h := handlers.NewOrderHandler(mockUseCase)
+
+	mockUseCase.EXPECT().GetMyOrders(gomock.Any()).Return(nil, nil)
+
+	rec := httptest.NewRecorder()
+	h.GetMyOrders(rec, httptest.NewRequest(http.MethodGet, "/orders/my", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, "[]", rec.Body.String())
+}
+
+func TestOrderHandler_CreatePickupCode(t *testing.T) {
+	orderId := uuid.New()
+	expiresAt := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		orderId    string
+		expectCall bool
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "ok",
+			orderId:    orderId.String(),
+			expectCall: true,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "invalid orderId",
+			orderId:    "invalid",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "order not found",
+			orderId:    orderId.String(),
+			expectCall: true,
+			mockError:  usecase.OrderNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "order already issued",
+			orderId:    orderId.String(),
+			expectCall: true,
+			mockError:  usecase.OrderAlreadyIssued,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockOrderUseCase(ctrl)
+			h := handlers.NewOrderHandler(mockUseCase)
+
+			if tt.expectCall {
+				code := models.PickupCode{}
+				if tt.mockError == nil {
+					code = models.PickupCode{OrderId: orderId, Code: "ABCD2345", ExpiresAt: expiresAt}
+				}
+				mockUseCase.EXPECT().CreatePickupCode(gomock.Any(), orderId).Return(code, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/orders/"+tt.orderId+"/pickup_code", nil)
+			req = mux.SetURLVars(req, map[string]string{"orderId": tt.orderId})
+			rec := httptest.NewRecorder()
+
+			h.CreatePickupCode(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusCreated {
+				var out forms.PickupCodeFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.PickupCodeFormOut{OrderId: orderId, Code: "ABCD2345", ExpiresAt: expiresAt}, out)
+				require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestOrderHandler_IssueOrder(t *testing.T) {
+	form := forms.IssueOrderForm{PvzId: uuid.New(), PickupCode: "ABCD2345"}
+	issuedAt := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		body       io.Reader
+		expectCall bool
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "ok",
+			body:       toJSONBody(form),
+			expectCall: true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing pickup code",
+			body:       toJSONBody(forms.IssueOrderForm{PvzId: form.PvzId}),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pickup code",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  usecase.InvalidPickupCode,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not assigned to pvz",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  usecase.PvzAccessDenied,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockOrderUseCase(ctrl)
+			h := handlers.NewOrderHandler(mockUseCase)
+
+			if tt.expectCall {
+				order := models.Order{}
+				if tt.mockError == nil {
+					order = models.Order{Id: uuid.New(), PvzId: form.PvzId, Status: models.OrderIssued, IssuedAt: issuedAt}
+				}
+				mockUseCase.EXPECT().IssueOrder(gomock.Any(), form).Return(order, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/orders/issue", tt.body)
+			rec := httptest.NewRecorder()
+
+			h.IssueOrder(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var out forms.OrderFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, string(models.OrderIssued), out.Status)
+				require.NotNil(t, out.IssuedAt)
+				require.True(t, issuedAt.Equal(*out.IssuedAt))
+			}
+		})
+	}
+}
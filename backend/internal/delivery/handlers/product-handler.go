@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type ProductUseCase interface {
+	IssueProduct(ctx context.Context, productId uuid.UUID) (models.Product, error)
+	ReturnProduct(ctx context.Context, productId uuid.UUID) (models.Product, error)
+	SendBackProducts(ctx context.Context, pvzId uuid.UUID) ([]models.Product, error)
+}
+
+type ProductHandler struct {
+	productUseCase ProductUseCase
+}
+
+func NewProductHandler(productUseCase ProductUseCase) *ProductHandler {
+	return &ProductHandler{
+		productUseCase: productUseCase,
+	}
+}
+
+func (ph *ProductHandler) IssueProduct(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got issue product request, trying to parse path params")
+
+	productId, err := uuid.Parse(mux.Vars(r)["productId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid productId")
+		utils.WriteJsonError(w, "invalid productId", http.StatusBadRequest)
+		return
+	}
+
+	product, err := ph.productUseCase.IssueProduct(r.Context(), productId)
+	if errors.Is(err, usecase.ProductHasOrder) {
+		utils.WriteJsonError(w, "product is reserved by order, issue it by pickup code", http.StatusConflict)
+		return
+	}
+	if errors.Is(err, usecase.ProductNotInStock) {
+		utils.WriteJsonError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		writeProductError(w, err, "unable to issue product")
+		return
+	}
+
+	utils.WriteJson(w, forms.ToProductFormOut(product), http.StatusOK)
+}
+
+func (ph *ProductHandler) ReturnProduct(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got return product request, trying to parse path params")
+
+	productId, err := uuid.Parse(mux.Vars(r)["productId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid productId")
+		utils.WriteJsonError(w, "invalid productId", http.StatusBadRequest)
+		return
+	}
+
+	product, err := ph.productUseCase.ReturnProduct(r.Context(), productId)
+	if errors.Is(err, usecase.ProductNotIssued) {
+		utils.WriteJsonError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		writeProductError(w, err, "unable to return product")
+		return
+	}
+
+	utils.WriteJson(w, forms.ToProductFormOut(product), http.StatusOK)
+}
+
+func (ph *ProductHandler) SendBackProducts(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got send back products request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	products, err := ph.productUseCase.SendBackProducts(r.Context(), pvzId)
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to send back products", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToProductsFormOut(products), http.StatusOK)
+}
+
+// writeProductError отвечает на ошибки, общие для всех операций с принятым товаром
+func writeProductError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ProductNotFound):
+		utils.WriteJsonError(w, "product not found", http.StatusNotFound)
+	case errors.Is(err, usecase.PvzAccessDenied):
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+	case errors.Is(err, usecase.ReceptionNotClosed):
+		utils.WriteJsonError(w, "reception of this product is not closed yet", http.StatusConflict)
+	default:
+		utils.WriteJsonError(w, message, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/delivery/handlers"
+	"pvz/internal/delivery/mocks"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+)
+
+func TestProductHandler_IssueAndReturnProduct(t *testing.T) {
+	productId := uuid.New()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	tests := []struct {
+		name       string
+		issue      bool
+		mockReturn models.Product
+		mockError  error
+		wantStatus int
+	}{
+		{name: "issued", issue: true, mockReturn: models.Product{Id: productId, Status: models.ProductIssued, IssuedAt: now}, wantStatus: http.StatusOK},
+		{name: "issue product not found", issue: true, mockError: usecase.ProductNotFound, wantStatus: http.StatusNotFound},
+		{name: "issue from foreign pvz", issue: true, mockError: usecase.PvzAccessDenied, wantStatus: http.StatusForbidden},
+		{name: "issue from open reception", issue: true, mockError: usecase.ReceptionNotClosed, wantStatus: http.StatusConflict},
+		{name: "issue product with order", issue: true, mockError: usecase.ProductHasOrder, wantStatus: http.StatusConflict},
+		{name: "issue product not in stock", issue: true, mockError: usecase.ProductNotInStock, wantStatus: http.StatusConflict},
+		{name: "issue usecase error", issue: true, mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+		{name: "returned", mockReturn: models.Product{Id: productId, Status: models.ProductReturned, IssuedAt: now, ReturnedAt: now}, wantStatus: http.StatusOK},
+		{name: "return product not issued", mockError: usecase.ProductNotIssued, wantStatus: http.StatusConflict},
+		{name: "return usecase error", mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockProductUseCase(ctrl)
+			h := handlers.NewProductHandler(mockUseCase)
+			rec := httptest.NewRecorder()
+
+			if tt.issue {
+				mockUseCase.EXPECT().IssueProduct(gomock.Any(), productId).Return(tt.mockReturn, tt.mockError)
+
+				req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/products/%s/issue", productId), nil)
+				h.IssueProduct(rec, mux.SetURLVars(req, map[string]string{"productId": productId.String()}))
+			} else {
+				mockUseCase.EXPECT().ReturnProduct(gomock.Any(), productId).Return(tt.mockReturn, tt.mockError)
+
+				req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/products/%s/return", productId), nil)
+				h.ReturnProduct(rec, mux.SetURLVars(req, map[string]string{"productId": productId.String()}))
+			}
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.ProductFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToProductFormOut(tt.mockReturn), out)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/products/invalid-uuid/issue", nil)
+	handlers.NewProductHandler(nil).IssueProduct(rec, mux.SetURLVars(req, map[string]string{"productId": "invalid-uuid"}))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestProductHandler_SendBackProducts(t *testing.T) {
+	pvzId := uuid.New()
+	products := []models.Product{{Id: uuid.New(), Status: models.ProductSentBack, SentBackAt: time.Now().UTC().Truncate(time.Millisecond)}}
+
+	tests := []struct {
+		name       string
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", wantStatus: http.StatusOK},
+		{name: "employee is not assigned", mockError: usecase.PvzAccessDenied, wantStatus: http.StatusForbidden},
+		{name: "usecase error", mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockProductUseCase(ctrl)
+			mockUseCase.EXPECT().SendBackProducts(gomock.Any(), pvzId).Return(products, tt.mockError)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/pvz/%s/send_back", pvzId), nil)
+			handlers.NewProductHandler(mockUseCase).SendBackProducts(rec, mux.SetURLVars(req, map[string]string{"pvzId": pvzId.String()}))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out []forms.ProductFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToProductsFormOut(products), out)
+			}
+		})
+	}
+}
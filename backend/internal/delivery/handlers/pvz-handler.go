@@ -3,14 +3,19 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
 	"pvz/config"
 	"pvz/internal/delivery/forms"
 	"pvz/internal/models"
+	"pvz/internal/usecase"
 	"pvz/internal/utils"
 	"pvz/pkg/logger"
 )
@@ -18,6 +23,15 @@ import (
 type PvzUseCase interface {
 	CreatePvz(ctx context.Context, pvzForm forms.PvzForm) (models.Pvz, error)
 	GetPvzInfo(ctx context.Context, form forms.GetPvzInfoForm) ([]models.PvzInfo, error)
+	AssignEmployee(ctx context.Context, pvzId uuid.UUID, userId uuid.UUID) (models.PvzEmployee, error)
+	UnassignEmployee(ctx context.Context, pvzId uuid.UUID, userId uuid.UUID) error
+	GetPvz(ctx context.Context, pvzId uuid.UUID) (models.Pvz, error)
+	UpdatePvz(ctx context.Context, pvzId uuid.UUID, form forms.UpdatePvzForm) (models.Pvz, error)
+	DecommissionPvz(ctx context.Context, pvzId uuid.UUID) error
+	GetNearbyPvz(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error)
+	GetSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error)
+	UpdateSchedule(ctx context.Context, pvzId uuid.UUID, form forms.PvzScheduleForm) (models.PvzSchedule, error)
+	GetOverloadedPvz(ctx context.Context, threshold float64) ([]models.PvzLoad, error)
 }
 
 type PvzHandler struct {
@@ -42,13 +56,15 @@ func (ph *PvzHandler) CreatePvz(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info(r.Context(), "Successfully parsed json")
 
-	if err := utils.ValidateCity(pvzForm.City); err != nil {
-		logger.Error(r.Context(), fmt.Sprintf("City validation error: %s", err.Error()))
+	pvz, err := ph.pvzUseCase.CreatePvz(r.Context(), pvzForm)
+	if errors.Is(err, usecase.CityNotAvailable) {
 		utils.WriteJsonError(w, "Invalid city", http.StatusBadRequest)
 		return
 	}
-
-	pvz, err := ph.pvzUseCase.CreatePvz(r.Context(), pvzForm)
+	if errors.Is(err, usecase.InvalidPvzDetails) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		utils.WriteJsonError(w, "failed to create pvz", http.StatusBadRequest)
 		return
@@ -105,3 +121,284 @@ func (ph *PvzHandler) GetPvzInfo(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteJson(w, forms.ToGetPvzInfoFormOut(res), http.StatusOK)
 }
+
+func (ph *PvzHandler) AssignEmployee(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got assign employee request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	var assignForm forms.AssignEmployeeForm
+	if err = json.NewDecoder(r.Body).Decode(&assignForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if assignForm.UserId == uuid.Nil {
+		logger.Error(r.Context(), "Missing userId")
+		utils.WriteJsonError(w, "userId is required", http.StatusBadRequest)
+		return
+	}
+
+	employee, err := ph.pvzUseCase.AssignEmployee(r.Context(), pvzId, assignForm.UserId)
+	if errors.Is(err, usecase.EmployeeOrPvzNotFound) {
+		utils.WriteJsonError(w, "employee or pvz not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to assign employee", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToPvzEmployeeFormOut(employee), http.StatusCreated)
+}
+
+func (ph *PvzHandler) UnassignEmployee(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got unassign employee request, trying to parse path params")
+
+	vars := mux.Vars(r)
+	pvzId, err := uuid.Parse(vars["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	userId, err := uuid.Parse(vars["userId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid userId")
+		utils.WriteJsonError(w, "invalid userId", http.StatusBadRequest)
+		return
+	}
+
+	err = ph.pvzUseCase.UnassignEmployee(r.Context(), pvzId, userId)
+	if errors.Is(err, usecase.EmployeeNotAssigned) {
+		utils.WriteJsonError(w, "employee is not assigned to pvz", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to unassign employee", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ph *PvzHandler) GetPvz(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got get pvz request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	pvz, err := ph.pvzUseCase.GetPvz(r.Context(), pvzId)
+	if errors.Is(err, usecase.PvzNotFound) {
+		utils.WriteJsonError(w, "pvz not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to get pvz", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToPvzForm(pvz), http.StatusOK)
+}
+
+func (ph *PvzHandler) UpdatePvz(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got update pvz request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	var updateForm forms.UpdatePvzForm
+	if err = json.NewDecoder(r.Body).Decode(&updateForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	pvz, err := ph.pvzUseCase.UpdatePvz(r.Context(), pvzId, updateForm)
+	if errors.Is(err, usecase.PvzNotFound) {
+		utils.WriteJsonError(w, "pvz not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, usecase.CityNotAvailable) {
+		utils.WriteJsonError(w, "Invalid city", http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, usecase.InvalidPvzDetails) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to update pvz", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToPvzForm(pvz), http.StatusOK)
+}
+
+func (ph *PvzHandler) DecommissionPvz(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got decommission pvz request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	err = ph.pvzUseCase.DecommissionPvz(r.Context(), pvzId)
+	switch {
+	case errors.Is(err, usecase.PvzNotFound):
+		utils.WriteJsonError(w, "pvz not found", http.StatusNotFound)
+		return
+	case errors.Is(err, usecase.PvzDecommissioned):
+		utils.WriteJsonError(w, "pvz is already decommissioned", http.StatusConflict)
+		return
+	case errors.Is(err, usecase.PvzHasOpenReception):
+		utils.WriteJsonError(w, "pvz has an open reception", http.StatusConflict)
+		return
+	case err != nil:
+		utils.WriteJsonError(w, "failed to decommission pvz", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ph *PvzHandler) GetNearbyPvz(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got nearby pvz request, trying to parse query params")
+
+	q := r.URL.Query()
+	var form forms.NearbyPvzForm
+	var err error
+
+	if form.Latitude, err = strconv.ParseFloat(q.Get("lat"), 64); err != nil {
+		logger.Error(r.Context(), "invalid lat")
+		utils.WriteJsonError(w, "lat is required and must be a number", http.StatusBadRequest)
+		return
+	}
+
+	if form.Longitude, err = strconv.ParseFloat(q.Get("lon"), 64); err != nil {
+		logger.Error(r.Context(), "invalid lon")
+		utils.WriteJsonError(w, "lon is required and must be a number", http.StatusBadRequest)
+		return
+	}
+
+	if q.Has("radius") {
+		if form.Radius, err = strconv.ParseFloat(q.Get("radius"), 64); err != nil || form.Radius <= 0 {
+			logger.Error(r.Context(), "invalid radius")
+			utils.WriteJsonError(w, "radius must be a positive number of meters", http.StatusBadRequest)
+			return
+		}
+	}
+
+	res, err := ph.pvzUseCase.GetNearbyPvz(r.Context(), form)
+	if errors.Is(err, usecase.InvalidNearbySearch) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to get nearby pvz", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToNearbyPvzFormOut(res), http.StatusOK)
+}
+
+func (ph *PvzHandler) GetUtilization(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got pvz utilization request, trying to parse query params")
+
+	var threshold float64
+	if q := r.URL.Query(); q.Has("threshold") {
+		var err error
+		if threshold, err = strconv.ParseFloat(q.Get("threshold"), 64); err != nil || threshold <= 0 {
+			logger.Error(r.Context(), "invalid threshold")
+			utils.WriteJsonError(w, "threshold must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	res, err := ph.pvzUseCase.GetOverloadedPvz(r.Context(), threshold)
+	if errors.Is(err, usecase.InvalidThreshold) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to get pvz utilization", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToPvzLoadFormOut(res), http.StatusOK)
+}
+
+func (ph *PvzHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got get schedule request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := ph.pvzUseCase.GetSchedule(r.Context(), pvzId)
+	if errors.Is(err, usecase.PvzNotFound) {
+		utils.WriteJsonError(w, "pvz not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "failed to get schedule", http.StatusInternalServerError)
+		return
+	}
+
+	form := forms.ToPvzScheduleForm(schedule)
+	form.PvzId = pvzId
+	utils.WriteJson(w, form, http.StatusOK)
+}
+
+func (ph *PvzHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got update schedule request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	var scheduleForm forms.PvzScheduleForm
+	if err = json.NewDecoder(r.Body).Decode(&scheduleForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := ph.pvzUseCase.UpdateSchedule(r.Context(), pvzId, scheduleForm)
+	switch {
+	case errors.Is(err, usecase.InvalidSchedule):
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, usecase.PvzNotFound):
+		utils.WriteJsonError(w, "pvz not found", http.StatusNotFound)
+		return
+	case err != nil:
+		utils.WriteJsonError(w, "failed to update schedule", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToPvzScheduleForm(schedule), http.StatusOK)
+}
@@ -5,13 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"pvz/config"
@@ -19,6 +22,7 @@ import (
 	"pvz/internal/delivery/handlers"
 	"pvz/internal/delivery/mocks"
 	"pvz/internal/models"
+	"pvz/internal/usecase"
 )
 
 func TestCreatePvz(t *testing.T) {
@@ -49,7 +53,7 @@ func TestCreatePvz(t *testing.T) {
 			input: forms.PvzForm{
 				City: "InvalidCity",
 			},
-			mockError:    errors.New("invalid city"),
+			mockError:    usecase.CityNotAvailable,
 			expectStatus: http.StatusBadRequest,
 			expectedBody: map[string]interface{}{"message": "Invalid city"},
 		},
@@ -66,6 +70,8 @@ func TestCreatePvz(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.mockError == nil {
 				mockUC.EXPECT().CreatePvz(gomock.Any(), gomock.Eq(tt.input)).Return(models.Pvz{City: tt.input.(forms.PvzForm).City}, nil)
+			} else if errors.Is(tt.mockError, usecase.CityNotAvailable) {
+				mockUC.EXPECT().CreatePvz(gomock.Any(), gomock.Eq(tt.input)).Return(models.Pvz{}, tt.mockError)
 			}
 
 			body, _ := json.Marshal(tt.input)
@@ -154,19 +160,19 @@ func TestGetPvzInfo(t *testing.T) {
 					"pvz": map[string]interface{}{
 						"city":             pvz.City,
 						"id":               pvz.Id.String(),
-						"registrationDate": pvz.RegistrationDate.Format(config.TimeStampLayout),
+						"registrationDate": pvz.RegistrationDate.Format(time.RFC3339Nano),
 					},
 					"receptions": []interface{}{
 						map[string]interface{}{
 							"reception": map[string]interface{}{
-								"dateTime": reception.DateTime.Format(config.TimeStampLayout),
+								"dateTime": reception.DateTime.Format(time.RFC3339Nano),
 								"id":       reception.Id.String(),
 								"pvzId":    reception.PvzId.String(),
 								"status":   string(reception.Status),
 							},
 							"products": []interface{}{
 								map[string]interface{}{
-									"dateTime":    product.DateTime.Format(config.TimeStampLayout),
+									"dateTime":    product.DateTime.Format(time.RFC3339Nano),
 									"id":          product.Id.String(),
 									"productType": product.ProductType,
 									"receptionId": product.ReceptionId.String(),
@@ -276,3 +282,552 @@ func mustParseTime(timeStr string) time.Time {
 	}
 	return t
 }
+
+func TestAssignEmployee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvzId := uuid.New()
+	userId := uuid.New()
+	assignedAt := time.Date(2025, 4, 23, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		pvzId        string
+		body         string
+		mock         func()
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:  "success",
+			pvzId: pvzId.String(),
+			body:  `{"userId":"` + userId.String() + `"}`,
+			mock: func() {
+				mockUC.EXPECT().AssignEmployee(gomock.Any(), pvzId, userId).
+					Return(models.PvzEmployee{UserId: userId.String(), PvzId: pvzId, AssignedAt: assignedAt}, nil)
+			},
+			expectStatus: http.StatusCreated,
+			expectBody:   `{"userId":"` + userId.String() + `","pvzId":"` + pvzId.String() + `","assignedAt":"2025-04-23T12:00:00Z"}`,
+		},
+		{
+			name:         "invalid pvzId",
+			pvzId:        "invalid",
+			body:         `{"userId":"` + userId.String() + `"}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"invalid pvzId"}`,
+		},
+		{
+			name:         "missing userId",
+			pvzId:        pvzId.String(),
+			body:         `{}`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"userId is required"}`,
+		},
+		{
+			name:  "employee not found",
+			pvzId: pvzId.String(),
+			body:  `{"userId":"` + userId.String() + `"}`,
+			mock: func() {
+				mockUC.EXPECT().AssignEmployee(gomock.Any(), pvzId, userId).Return(models.PvzEmployee{}, usecase.EmployeeOrPvzNotFound)
+			},
+			expectStatus: http.StatusNotFound,
+			expectBody:   `{"message":"employee or pvz not found"}`,
+		},
+		{
+			name:  "usecase error",
+			pvzId: pvzId.String(),
+			body:  `{"userId":"` + userId.String() + `"}`,
+			mock: func() {
+				mockUC.EXPECT().AssignEmployee(gomock.Any(), pvzId, userId).Return(models.PvzEmployee{}, errors.New("db error"))
+			},
+			expectStatus: http.StatusInternalServerError,
+			expectBody:   `{"message":"failed to assign employee"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPost, "/pvz/"+tt.pvzId+"/employees", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"pvzId": tt.pvzId})
+			rec := httptest.NewRecorder()
+
+			handler.AssignEmployee(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			assert.JSONEq(t, tt.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestUnassignEmployee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvzId := uuid.New()
+	userId := uuid.New()
+
+	tests := []struct {
+		name         string
+		vars         map[string]string
+		mock         func()
+		expectStatus int
+	}{
+		{
+			name: "success",
+			vars: map[string]string{"pvzId": pvzId.String(), "userId": userId.String()},
+			mock: func() {
+				mockUC.EXPECT().UnassignEmployee(gomock.Any(), pvzId, userId).Return(nil)
+			},
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:         "invalid userId",
+			vars:         map[string]string{"pvzId": pvzId.String(), "userId": "invalid"},
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not assigned",
+			vars: map[string]string{"pvzId": pvzId.String(), "userId": userId.String()},
+			mock: func() {
+				mockUC.EXPECT().UnassignEmployee(gomock.Any(), pvzId, userId).Return(usecase.EmployeeNotAssigned)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodDelete, "/pvz/employees", nil)
+			req = mux.SetURLVars(req, tt.vars)
+			rec := httptest.NewRecorder()
+
+			handler.UnassignEmployee(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestGetPvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvz := models.Pvz{Id: uuid.New(), RegistrationDate: time.Now().UTC(), City: "Москва"}
+
+	tests := []struct {
+		name         string
+		vars         map[string]string
+		mock         func()
+		expectStatus int
+	}{
+		{
+			name: "success",
+			vars: map[string]string{"pvzId": pvz.Id.String()},
+			mock: func() {
+				mockUC.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "invalid pvzId",
+			vars:         map[string]string{"pvzId": "invalid"},
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			vars: map[string]string{"pvzId": pvz.Id.String()},
+			mock: func() {
+				mockUC.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(models.Pvz{}, usecase.PvzNotFound)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodGet, "/pvz/"+tt.vars["pvzId"], nil)
+			req = mux.SetURLVars(req, tt.vars)
+			rec := httptest.NewRecorder()
+
+			handler.GetPvz(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestUpdatePvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvzId := uuid.New()
+	city := "Казань"
+
+	tests := []struct {
+		name         string
+		body         string
+		mock         func()
+		expectStatus int
+	}{
+		{
+			name: "success",
+			body: `{"city":"Казань"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdatePvz(gomock.Any(), pvzId, forms.UpdatePvzForm{City: &city}).
+					Return(models.Pvz{Id: pvzId, City: city}, nil)
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name: "invalid city",
+			body: `{"city":"Новосибирск"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdatePvz(gomock.Any(), pvzId, gomock.Any()).Return(models.Pvz{}, usecase.CityNotAvailable)
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid phone",
+			body: `{"phone":"12345"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdatePvz(gomock.Any(), pvzId, gomock.Any()).
+					Return(models.Pvz{}, fmt.Errorf("%w: phone 12345 must be in E.164 format", usecase.InvalidPvzDetails))
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid json",
+			body:         `{`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			body: `{"city":"Казань"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdatePvz(gomock.Any(), pvzId, gomock.Any()).Return(models.Pvz{}, usecase.PvzNotFound)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodPatch, "/pvz/"+pvzId.String(), strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"pvzId": pvzId.String()})
+			rec := httptest.NewRecorder()
+
+			handler.UpdatePvz(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestDecommissionPvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvzId := uuid.New()
+
+	tests := []struct {
+		name         string
+		mockErr      error
+		expectStatus int
+	}{
+		{name: "success", expectStatus: http.StatusNoContent},
+		{name: "not found", mockErr: usecase.PvzNotFound, expectStatus: http.StatusNotFound},
+		{name: "already decommissioned", mockErr: usecase.PvzDecommissioned, expectStatus: http.StatusConflict},
+		{name: "open reception", mockErr: usecase.PvzHasOpenReception, expectStatus: http.StatusConflict},
+		{name: "internal error", mockErr: errors.New("db error"), expectStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC.EXPECT().DecommissionPvz(gomock.Any(), pvzId).Return(tt.mockErr)
+
+			req := httptest.NewRequest(http.MethodDelete, "/pvz/"+pvzId.String(), nil)
+			req = mux.SetURLVars(req, map[string]string{"pvzId": pvzId.String()})
+			rec := httptest.NewRecorder()
+
+			handler.DecommissionPvz(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestGetNearbyPvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvzId := uuid.New()
+
+	tests := []struct {
+		name         string
+		query        string
+		mock         func()
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:  "success",
+			query: "lat=55.7558&lon=37.6173&radius=1000",
+			mock: func() {
+				mockUC.EXPECT().GetNearbyPvz(gomock.Any(), forms.NearbyPvzForm{Latitude: 55.7558, Longitude: 37.6173, Radius: 1000}).
+					Return([]models.NearbyPvz{{
+						Pvz:      models.Pvz{Id: pvzId, City: "Москва", Location: &models.GeoPoint{Latitude: 55.7578, Longitude: 37.6117}},
+						Distance: 412.5,
+					}}, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `"location":{"latitude":55.7578,"longitude":37.6117}`,
+		},
+		{
+			name:  "nothing nearby",
+			query: "lat=55.7558&lon=37.6173",
+			mock: func() {
+				mockUC.EXPECT().GetNearbyPvz(gomock.Any(), forms.NearbyPvzForm{Latitude: 55.7558, Longitude: 37.6173}).Return(nil, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `[]`,
+		},
+		{
+			name:         "missing lon",
+			query:        "lat=55.7558",
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "negative radius",
+			query:        "lat=55.7558&lon=37.6173&radius=-1",
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "out of range",
+			query: "lat=95&lon=37.6173",
+			mock: func() {
+				mockUC.EXPECT().GetNearbyPvz(gomock.Any(), gomock.Any()).Return(nil, usecase.InvalidNearbySearch)
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodGet, "/pvz/nearby?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetNearbyPvz(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectBody)
+		})
+	}
+}
+
+func TestGetUtilization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvzId := uuid.New()
+
+	tests := []struct {
+		name         string
+		query        string
+		mock         func()
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:  "success",
+			query: "?threshold=0.8",
+			mock: func() {
+				mockUC.EXPECT().GetOverloadedPvz(gomock.Any(), 0.8).
+					Return([]models.PvzLoad{{Pvz: models.Pvz{Id: pvzId, City: "Москва", Capacity: 200}, OnHand: 180}}, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `"capacity":200,"onHand":180,"utilization":0.9`,
+		},
+		{
+			name:  "default threshold",
+			query: "",
+			mock: func() {
+				mockUC.EXPECT().GetOverloadedPvz(gomock.Any(), float64(0)).Return(nil, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `[]`,
+		},
+		{
+			name:         "invalid threshold",
+			query:        "?threshold=much",
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "threshold out of range",
+			query: "?threshold=100",
+			mock: func() {
+				mockUC.EXPECT().GetOverloadedPvz(gomock.Any(), float64(100)).Return(nil, usecase.InvalidThreshold)
+			},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "usecase error",
+			query: "?threshold=1",
+			mock: func() {
+				mockUC.EXPECT().GetOverloadedPvz(gomock.Any(), float64(1)).Return(nil, errors.New("db error"))
+			},
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(http.MethodGet, "/pvz/utilization"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			handler.GetUtilization(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectBody)
+		})
+	}
+}
+
+func TestPvzSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockPvzUseCase(ctrl)
+	handler := handlers.NewPvzHandler(mockUC)
+
+	pvzId := uuid.New()
+	schedule := models.PvzSchedule{
+		PvzId:    pvzId,
+		Timezone: "Europe/Moscow",
+		Week:     []models.WorkingHours{{Weekday: 1, OpensAt: "09:00", ClosesAt: "21:00"}},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		mock         func()
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:   "get schedule",
+			method: http.MethodGet,
+			mock: func() {
+				mockUC.EXPECT().GetSchedule(gomock.Any(), pvzId).Return(schedule, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `"week":[{"weekday":1,"opensAt":"09:00","closesAt":"21:00"}]`,
+		},
+		{
+			name:   "get schedule that is not set",
+			method: http.MethodGet,
+			mock: func() {
+				mockUC.EXPECT().GetSchedule(gomock.Any(), pvzId).Return(models.PvzSchedule{}, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `"pvzId":"` + pvzId.String() + `","timezone":"","alwaysOpen":true`,
+		},
+		{
+			name:   "get schedule of unknown pvz",
+			method: http.MethodGet,
+			mock: func() {
+				mockUC.EXPECT().GetSchedule(gomock.Any(), pvzId).Return(models.PvzSchedule{}, usecase.PvzNotFound)
+			},
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:   "update schedule",
+			method: http.MethodPut,
+			body:   `{"timezone":"Europe/Moscow","week":[{"weekday":1,"opensAt":"09:00","closesAt":"21:00"}]}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateSchedule(gomock.Any(), pvzId, gomock.Any()).Return(schedule, nil)
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `"alwaysOpen":false`,
+		},
+		{
+			name:   "invalid schedule",
+			method: http.MethodPut,
+			body:   `{"timezone":"Moscow"}`,
+			mock: func() {
+				mockUC.EXPECT().UpdateSchedule(gomock.Any(), pvzId, gomock.Any()).
+					Return(models.PvzSchedule{}, fmt.Errorf("%w: unknown timezone Moscow", usecase.InvalidSchedule))
+			},
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `unknown timezone Moscow`,
+		},
+		{
+			name:         "invalid json",
+			method:       http.MethodPut,
+			body:         `{`,
+			mock:         func() {},
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			req := httptest.NewRequest(tt.method, "/pvz/"+pvzId.String()+"/schedule", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"pvzId": pvzId.String()})
+			rec := httptest.NewRecorder()
+
+			if tt.method == http.MethodGet {
+				handler.GetSchedule(rec, req)
+			} else {
+				handler.UpdateSchedule(rec, req)
+			}
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.expectBody)
+		})
+	}
+}
@@ -3,14 +3,18 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
 	"pvz/internal/delivery/forms"
 	"pvz/internal/models"
+	"pvz/internal/usecase"
 	"pvz/internal/utils"
 	"pvz/pkg/logger"
 )
@@ -18,8 +22,20 @@ import (
 type ReceptionUseCase interface {
 	CreateReception(ctx context.Context, receptionForm forms.ReceptionForm) (models.Reception, error)
 	AddProduct(ctx context.Context, productForm forms.ProductForm) (models.Product, error)
+	AddProducts(ctx context.Context, form forms.BatchProductForm) ([]models.Product, error)
 	RemoveProduct(ctx context.Context, pvzId uuid.UUID) error
+	RemoveProductById(ctx context.Context, receptionId uuid.UUID, productId uuid.UUID) error
 	CloseReception(ctx context.Context, pvzId uuid.UUID) (models.Reception, error)
+	ListReceptions(ctx context.Context, filter models.ReceptionFilter) ([]models.Reception, error)
+	GetReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error)
+	GetCurrentReception(ctx context.Context, pvzId uuid.UUID) (models.ReceptionProducts, error)
+	SearchProducts(ctx context.Context, barcode string) ([]models.ProductLocation, error)
+	ReplaceManifest(ctx context.Context, pvzId uuid.UUID, form forms.ManifestForm) (models.Manifest, error)
+	GetDiscrepancyReport(ctx context.Context, receptionId uuid.UUID) (models.DiscrepancyReport, error)
+	ReopenReception(ctx context.Context, receptionId uuid.UUID, form forms.ReopenReceptionForm) (models.Reception, error)
+	GetReceptionHistory(ctx context.Context, receptionId uuid.UUID) (models.ReceptionHistory, error)
+	CancelReception(ctx context.Context, receptionId uuid.UUID, form forms.CancelReceptionForm) (models.Reception, error)
+	VerifyReception(ctx context.Context, receptionId uuid.UUID) (models.Reception, error)
 }
 
 type ReceptionHandler struct {
@@ -46,8 +62,20 @@ func (rc *ReceptionHandler) CreateReception(w http.ResponseWriter, r *http.Reque
 	logger.Info(r.Context(), "Successfully parsed json")
 
 	reception, err := rc.receptionUseCase.CreateReception(r.Context(), receptionForm)
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, usecase.PvzClosed) {
+		utils.WriteJsonError(w, "pvz is closed by schedule, pass scheduleOverride with overrideReason to open reception for moderator review", http.StatusConflict)
+		return
+	}
+	if errors.Is(err, usecase.InvalidReason) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
-		utils.WriteJsonError(w, "unclosed reception or non-existing pvzId", http.StatusBadRequest)
+		utils.WriteJsonError(w, "unclosed reception, non-existing or decommissioned pvzId", http.StatusBadRequest)
 		return
 	}
 
@@ -67,13 +95,27 @@ func (rc *ReceptionHandler) AddProduct(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info(r.Context(), "Successfully parsed json")
 
-	if err = utils.ValidateProductType(productForm.Type); err != nil {
-		logger.Error(r.Context(), fmt.Sprintf("Error validating product type: %s", err.Error()))
+	product, err := rc.receptionUseCase.AddProduct(r.Context(), productForm)
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, usecase.CategoryNotAvailable) {
 		utils.WriteJsonError(w, "Product type not allowed", http.StatusBadRequest)
 		return
 	}
-
-	product, err := rc.receptionUseCase.AddProduct(r.Context(), productForm)
+	if errors.Is(err, usecase.InvalidProduct) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, usecase.DuplicateBarcode) {
+		utils.WriteJsonError(w, "product with this barcode is already received in this reception", http.StatusConflict)
+		return
+	}
+	if errors.Is(err, usecase.PvzOverCapacity) {
+		utils.WriteJsonError(w, "pvz is full", http.StatusConflict)
+		return
+	}
 	if err != nil {
 		utils.WriteJsonError(w, "There are no active receptions or non-existing pvzId", http.StatusBadRequest)
 		return
@@ -82,6 +124,46 @@ func (rc *ReceptionHandler) AddProduct(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJson(w, forms.ToProductFormOut(product), http.StatusCreated)
 }
 
+func (rc *ReceptionHandler) AddProducts(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got add products batch request, trying to parse json")
+
+	var batchForm forms.BatchProductForm
+	if err := json.NewDecoder(r.Body).Decode(&batchForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "Error decoding json", http.StatusBadRequest)
+		return
+	}
+
+	products, err := rc.receptionUseCase.AddProducts(r.Context(), batchForm)
+	var itemsErr *usecase.BatchItemsError
+	if errors.As(err, &itemsErr) {
+		utils.WriteJson(w, forms.ToRejectedBatchFormOut("batch is rejected", len(batchForm.Items), itemsErr.Items), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, usecase.InvalidBatch) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, usecase.ReceptionNotOpened) {
+		utils.WriteJsonError(w, "There are no active receptions or non-existing pvzId", http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, usecase.PvzOverCapacity) {
+		utils.WriteJsonError(w, "pvz has no room for the whole batch", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to add products", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToBatchProductsFormOut(products), http.StatusCreated)
+}
+
 func (rc *ReceptionHandler) RemoveProduct(w http.ResponseWriter, r *http.Request) {
 	logger.Info(r.Context(), "Got remove product request, trying to parse path params")
 
@@ -102,12 +184,52 @@ func (rc *ReceptionHandler) RemoveProduct(w http.ResponseWriter, r *http.Request
 
 	logger.Info(r.Context(), "Successfully parsed path params")
 
-	if err = rc.receptionUseCase.RemoveProduct(r.Context(), pvzId); err != nil {
+	err = rc.receptionUseCase.RemoveProduct(r.Context(), pvzId)
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if err != nil {
 		utils.WriteJsonError(w, "unable to remove product", http.StatusBadRequest)
 		return
 	}
 }
 
+func (rc *ReceptionHandler) RemoveProductById(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got remove product by id request, trying to parse path params")
+
+	vars := mux.Vars(r)
+	receptionId, err := uuid.Parse(vars["receptionId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid receptionId")
+		utils.WriteJsonError(w, "invalid receptionId", http.StatusBadRequest)
+		return
+	}
+
+	productId, err := uuid.Parse(vars["productId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid productId")
+		utils.WriteJsonError(w, "invalid productId", http.StatusBadRequest)
+		return
+	}
+
+	err = rc.receptionUseCase.RemoveProductById(r.Context(), receptionId, productId)
+	if errors.Is(err, usecase.ReceptionNotFound) || errors.Is(err, usecase.ProductNotFound) {
+		utils.WriteJsonError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, usecase.ReceptionNotOpened) {
+		utils.WriteJsonError(w, "reception is already closed", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to remove product", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (rc *ReceptionHandler) CloseReception(w http.ResponseWriter, r *http.Request) {
 	logger.Info(r.Context(), "Got close reception request")
 
@@ -127,6 +249,14 @@ func (rc *ReceptionHandler) CloseReception(w http.ResponseWriter, r *http.Reques
 	}
 
 	reception, err := rc.receptionUseCase.CloseReception(r.Context(), pvzId)
+	if errors.Is(err, usecase.PvzAccessDenied) {
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+		return
+	}
+	if errors.Is(err, usecase.IllegalStatusTransition) {
+		utils.WriteJsonError(w, err.Error(), http.StatusConflict)
+		return
+	}
 	if err != nil {
 		utils.WriteJsonError(w, "There are no active receptions or non-existing pvzId", http.StatusBadRequest)
 		return
@@ -134,3 +264,312 @@ func (rc *ReceptionHandler) CloseReception(w http.ResponseWriter, r *http.Reques
 
 	utils.WriteJson(w, forms.ToReceptionFormOut(reception), http.StatusOK)
 }
+
+func (rc *ReceptionHandler) ListReceptions(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got list receptions request, trying to parse params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := models.ReceptionFilter{
+		PvzId:   pvzId,
+		Status:  models.Status(q.Get("status")),
+		EndDate: time.Now(),
+	}
+
+	switch filter.Status {
+	case "", models.InProgress, models.Closed, models.Cancelled, models.Verified:
+	default:
+		logger.Error(r.Context(), fmt.Sprintf("Status %s is not valid", filter.Status))
+		utils.WriteJsonError(w, "Incorrect status was given", http.StatusBadRequest)
+		return
+	}
+
+	if q.Has("startDate") {
+		if filter.StartDate, err = time.Parse(time.RFC3339, q.Get("startDate")); err != nil {
+			logger.Error(r.Context(), "invalid startDate")
+			utils.WriteJsonError(w, "startDate must be in RFC 3339 format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if q.Has("endDate") {
+		if filter.EndDate, err = time.Parse(time.RFC3339, q.Get("endDate")); err != nil {
+			logger.Error(r.Context(), "invalid endDate")
+			utils.WriteJsonError(w, "endDate must be in RFC 3339 format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err = utils.ValidateTime(filter.StartDate, filter.EndDate); err != nil {
+		logger.Error(r.Context(), err.Error())
+		utils.WriteJsonError(w, "startDate must not be after endDate", http.StatusBadRequest)
+		return
+	}
+
+	if filter.Page, err = strconv.Atoi(q.Get("page")); err != nil || filter.Page < 1 {
+		filter.Page = 1
+	}
+
+	if filter.Limit, err = strconv.Atoi(q.Get("limit")); err != nil || filter.Limit < 1 {
+		filter.Limit = 10
+	}
+
+	receptions, err := rc.receptionUseCase.ListReceptions(r.Context(), filter)
+	if err != nil {
+		utils.WriteJsonError(w, "unable to list receptions", http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]forms.ReceptionFormOut, 0, len(receptions))
+	for _, reception := range receptions {
+		res = append(res, forms.ToReceptionFormOut(reception))
+	}
+
+	utils.WriteJson(w, res, http.StatusOK)
+}
+
+func (rc *ReceptionHandler) GetReception(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got get reception request, trying to parse path params")
+
+	receptionId, err := uuid.Parse(mux.Vars(r)["receptionId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid receptionId")
+		utils.WriteJsonError(w, "invalid receptionId", http.StatusBadRequest)
+		return
+	}
+
+	reception, err := rc.receptionUseCase.GetReception(r.Context(), receptionId)
+	if errors.Is(err, usecase.ReceptionNotFound) {
+		utils.WriteJsonError(w, "reception not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to get reception", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToReceptionProductsFormOut(reception), http.StatusOK)
+}
+
+func (rc *ReceptionHandler) GetCurrentReception(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got get current reception request, trying to parse path params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	reception, err := rc.receptionUseCase.GetCurrentReception(r.Context(), pvzId)
+	if errors.Is(err, usecase.ReceptionNotOpened) {
+		utils.WriteJsonError(w, "there is no open reception", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to get current reception", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToReceptionProductsFormOut(reception), http.StatusOK)
+}
+
+func (rc *ReceptionHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got search products request")
+
+	barcode := r.URL.Query().Get("barcode")
+	if barcode == "" {
+		logger.Error(r.Context(), "Error query params: missing barcode")
+		utils.WriteJsonError(w, "barcode is required", http.StatusBadRequest)
+		return
+	}
+
+	locations, err := rc.receptionUseCase.SearchProducts(r.Context(), barcode)
+	if errors.Is(err, usecase.InvalidProduct) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to search products", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToProductLocationsFormOut(locations), http.StatusOK)
+}
+
+func (rc *ReceptionHandler) ReplaceManifest(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got replace manifest request, trying to parse params")
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid pvzId")
+		utils.WriteJsonError(w, "invalid pvzId", http.StatusBadRequest)
+		return
+	}
+
+	var manifestForm forms.ManifestForm
+	if err = json.NewDecoder(r.Body).Decode(&manifestForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "Error decoding json", http.StatusBadRequest)
+		return
+	}
+
+	manifest, err := rc.receptionUseCase.ReplaceManifest(r.Context(), pvzId, manifestForm)
+	if errors.Is(err, usecase.InvalidManifest) {
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, usecase.PvzNotFound) {
+		utils.WriteJsonError(w, "pvz not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to replace manifest", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToManifestFormOut(manifest), http.StatusOK)
+}
+
+func (rc *ReceptionHandler) GetDiscrepancyReport(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got get discrepancy report request, trying to parse path params")
+
+	receptionId, err := uuid.Parse(mux.Vars(r)["receptionId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid receptionId")
+		utils.WriteJsonError(w, "invalid receptionId", http.StatusBadRequest)
+		return
+	}
+
+	report, err := rc.receptionUseCase.GetDiscrepancyReport(r.Context(), receptionId)
+	if errors.Is(err, usecase.DiscrepancyReportNotFound) {
+		utils.WriteJsonError(w, "reception has no manifest or is not closed yet", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to get discrepancy report", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToDiscrepancyReportFormOut(report), http.StatusOK)
+}
+
+func (rc *ReceptionHandler) ReopenReception(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got reopen reception request, trying to parse params")
+
+	receptionId, err := uuid.Parse(mux.Vars(r)["receptionId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid receptionId")
+		utils.WriteJsonError(w, "invalid receptionId", http.StatusBadRequest)
+		return
+	}
+
+	var reopenForm forms.ReopenReceptionForm
+	if err = json.NewDecoder(r.Body).Decode(&reopenForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "Error decoding json", http.StatusBadRequest)
+		return
+	}
+
+	reception, err := rc.receptionUseCase.ReopenReception(r.Context(), receptionId, reopenForm)
+	switch {
+	case errors.Is(err, usecase.InvalidReason):
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, usecase.ReceptionNotFound):
+		utils.WriteJsonError(w, "reception not found", http.StatusNotFound)
+	case errors.Is(err, usecase.IllegalStatusTransition):
+		utils.WriteJsonError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, usecase.ReceptionNotReopenable):
+		utils.WriteJsonError(w, "pvz already has an open reception or is decommissioned", http.StatusConflict)
+	case err != nil:
+		utils.WriteJsonError(w, "unable to reopen reception", http.StatusInternalServerError)
+	default:
+		utils.WriteJson(w, forms.ToReceptionFormOut(reception), http.StatusOK)
+	}
+}
+
+func (rc *ReceptionHandler) GetReceptionHistory(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got get reception history request, trying to parse path params")
+
+	receptionId, err := uuid.Parse(mux.Vars(r)["receptionId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid receptionId")
+		utils.WriteJsonError(w, "invalid receptionId", http.StatusBadRequest)
+		return
+	}
+
+	history, err := rc.receptionUseCase.GetReceptionHistory(r.Context(), receptionId)
+	if errors.Is(err, usecase.ReceptionNotFound) {
+		utils.WriteJsonError(w, "reception not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		utils.WriteJsonError(w, "unable to get reception history", http.StatusInternalServerError)
+		return
+	}
+
+	utils.WriteJson(w, forms.ToReceptionHistoryFormOut(history), http.StatusOK)
+}
+
+func (rc *ReceptionHandler) CancelReception(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got cancel reception request, trying to parse params")
+
+	receptionId, err := uuid.Parse(mux.Vars(r)["receptionId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid receptionId")
+		utils.WriteJsonError(w, "invalid receptionId", http.StatusBadRequest)
+		return
+	}
+
+	var cancelForm forms.CancelReceptionForm
+	if err = json.NewDecoder(r.Body).Decode(&cancelForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "Error decoding json", http.StatusBadRequest)
+		return
+	}
+
+	reception, err := rc.receptionUseCase.CancelReception(r.Context(), receptionId, cancelForm)
+	switch {
+	case errors.Is(err, usecase.InvalidReason):
+		utils.WriteJsonError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, usecase.PvzAccessDenied):
+		utils.WriteJsonError(w, "you are not assigned to this pvz", http.StatusForbidden)
+	case errors.Is(err, usecase.ReceptionNotFound):
+		utils.WriteJsonError(w, "reception not found", http.StatusNotFound)
+	case errors.Is(err, usecase.IllegalStatusTransition):
+		utils.WriteJsonError(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		utils.WriteJsonError(w, "unable to cancel reception", http.StatusInternalServerError)
+	default:
+		utils.WriteJson(w, forms.ToReceptionFormOut(reception), http.StatusOK)
+	}
+}
+
+func (rc *ReceptionHandler) VerifyReception(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got verify reception request, trying to parse path params")
+
+	receptionId, err := uuid.Parse(mux.Vars(r)["receptionId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid receptionId")
+		utils.WriteJsonError(w, "invalid receptionId", http.StatusBadRequest)
+		return
+	}
+
+	reception, err := rc.receptionUseCase.VerifyReception(r.Context(), receptionId)
+	switch {
+	case errors.Is(err, usecase.ReceptionNotFound):
+		utils.WriteJsonError(w, "reception not found", http.StatusNotFound)
+	case errors.Is(err, usecase.IllegalStatusTransition):
+		utils.WriteJsonError(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		utils.WriteJsonError(w, "unable to verify reception", http.StatusInternalServerError)
+	default:
+		utils.WriteJson(w, forms.ToReceptionFormOut(reception), http.StatusOK)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"pvz/internal/delivery/handlers"
 	"pvz/internal/delivery/mocks"
 	"pvz/internal/models"
+	"pvz/internal/usecase"
 )
 
 func TestReceptionHandler_CreateReception(t *testing.T) {
@@ -57,6 +58,38 @@ func TestReceptionHandler_CreateReception(t *testing.T) {
 			wantStatus:  http.StatusBadRequest,
 			wantBodyOut: forms.ReceptionFormOut{},
 		},
+		{
+			name:        "not assigned to pvz",
+			input:       forms.ReceptionForm{PvzId: pvzId},
+			mockReturn:  models.Reception{},
+			mockError:   usecase.PvzAccessDenied,
+			wantStatus:  http.StatusForbidden,
+			wantBodyOut: forms.ReceptionFormOut{},
+		},
+		{
+			name:        "pvz is closed by schedule",
+			input:       forms.ReceptionForm{PvzId: pvzId},
+			mockReturn:  models.Reception{},
+			mockError:   usecase.PvzClosed,
+			wantStatus:  http.StatusConflict,
+			wantBodyOut: forms.ReceptionFormOut{},
+		},
+		{
+			name:        "override without reason",
+			input:       forms.ReceptionForm{PvzId: pvzId, ScheduleOverride: true},
+			mockReturn:  models.Reception{},
+			mockError:   usecase.InvalidReason,
+			wantStatus:  http.StatusBadRequest,
+			wantBodyOut: forms.ReceptionFormOut{},
+		},
+		{
+			name:        "opened outside working hours",
+			input:       forms.ReceptionForm{PvzId: pvzId, ScheduleOverride: true, OverrideReason: "поздняя поставка"},
+			mockReturn:  models.Reception{Id: receptionId, PvzId: pvzId, Status: models.InProgress, OutsideSchedule: true},
+			mockError:   nil,
+			wantStatus:  http.StatusCreated,
+			wantBodyOut: forms.ReceptionFormOut{Id: receptionId, PvzId: pvzId, Status: string(models.InProgress), OutsideSchedule: true},
+		},
 	}
 
 	for _, tt := range tests {
@@ -110,7 +143,7 @@ func TestReceptionHandler_AddProduct(t *testing.T) {
 	productId := uuid.New()
 	productType := "обувь"
 	receptionId := uuid.New()
-	now := time.Now().Truncate(time.Millisecond)
+	now := time.Now().UTC().Truncate(time.Millisecond)
 
 	tests := []struct {
 		name        string
@@ -142,10 +175,57 @@ func TestReceptionHandler_AddProduct(t *testing.T) {
 		{
 			name:        "invalid product type",
 			body:        toJSONBody(forms.ProductForm{PvzId: pvzId, Type: "рандомный тип"}),
-			expectCall:  false,
+			expectCall:  true,
+			input:       forms.ProductForm{PvzId: pvzId, Type: "рандомный тип"},
+			mockError:   usecase.CategoryNotAvailable,
 			wantStatus:  http.StatusBadRequest,
 			wantBodyOut: forms.ProductFormOut{},
 		},
+		{
+			name:        "accepted over capacity",
+			body:        toJSONBody(forms.ProductForm{PvzId: pvzId, Type: productType}),
+			expectCall:  true,
+			input:       forms.ProductForm{PvzId: pvzId, Type: productType},
+			mockReturn:  models.Product{Id: productId, ReceptionId: receptionId, ProductType: productType, DateTime: now, OverCapacity: true},
+			wantStatus:  http.StatusCreated,
+			wantBodyOut: forms.ProductFormOut{Id: productId, ReceptionId: receptionId, ProductType: productType, DateTime: now, OverCapacity: true},
+		},
+		{
+			name:        "product with barcode and order",
+			body:        toJSONBody(forms.ProductForm{PvzId: pvzId, Type: productType, Barcode: "4600000000017", ExternalOrderId: "WB-1"}),
+			expectCall:  true,
+			input:       forms.ProductForm{PvzId: pvzId, Type: productType, Barcode: "4600000000017", ExternalOrderId: "WB-1"},
+			mockReturn:  models.Product{Id: productId, ReceptionId: receptionId, ProductType: productType, DateTime: now, Barcode: "4600000000017", ExternalOrderId: "WB-1"},
+			wantStatus:  http.StatusCreated,
+			wantBodyOut: forms.ProductFormOut{Id: productId, ReceptionId: receptionId, ProductType: productType, DateTime: now, Barcode: "4600000000017", ExternalOrderId: "WB-1"},
+		},
+		{
+			name:        "barcode is already received",
+			body:        toJSONBody(forms.ProductForm{PvzId: pvzId, Type: productType, Barcode: "4600000000017"}),
+			expectCall:  true,
+			input:       forms.ProductForm{PvzId: pvzId, Type: productType, Barcode: "4600000000017"},
+			mockError:   usecase.DuplicateBarcode,
+			wantStatus:  http.StatusConflict,
+			wantBodyOut: forms.ProductFormOut{},
+		},
+		{
+			name:        "invalid barcode",
+			body:        toJSONBody(forms.ProductForm{PvzId: pvzId, Type: productType, Barcode: "460 000"}),
+			expectCall:  true,
+			input:       forms.ProductForm{PvzId: pvzId, Type: productType, Barcode: "460 000"},
+			mockError:   fmt.Errorf("%w: bad barcode", usecase.InvalidProduct),
+			wantStatus:  http.StatusBadRequest,
+			wantBodyOut: forms.ProductFormOut{},
+		},
+		{
+			name:        "pvz is full",
+			body:        toJSONBody(forms.ProductForm{PvzId: pvzId, Type: productType}),
+			expectCall:  true,
+			input:       forms.ProductForm{PvzId: pvzId, Type: productType},
+			mockError:   usecase.PvzOverCapacity,
+			wantStatus:  http.StatusConflict,
+			wantBodyOut: forms.ProductFormOut{},
+		},
 		{
 			name:        "usecase error",
 			body:        toJSONBody(forms.ProductForm{PvzId: pvzId, Type: productType}),
@@ -307,6 +387,26 @@ func TestReceptionHandler_CloseReception(t *testing.T) {
 			wantStatus:  http.StatusBadRequest,
 			wantBodyOut: forms.ReceptionFormOut{},
 		},
+		{
+			name:        "not assigned to pvz",
+			url:         fmt.Sprintf("/pvz/%s/close_last_reception", pvzId.String()),
+			setVars:     map[string]string{"pvzId": pvzId.String()},
+			mockExpect:  true,
+			mockReturn:  models.Reception{},
+			mockError:   usecase.PvzAccessDenied,
+			wantStatus:  http.StatusForbidden,
+			wantBodyOut: forms.ReceptionFormOut{},
+		},
+		{
+			name:        "reception was cancelled while closing",
+			url:         fmt.Sprintf("/pvz/%s/close_last_reception", pvzId.String()),
+			setVars:     map[string]string{"pvzId": pvzId.String()},
+			mockExpect:  true,
+			mockReturn:  models.Reception{},
+			mockError:   &usecase.StatusTransitionError{From: models.InProgress, To: models.Closed},
+			wantStatus:  http.StatusConflict,
+			wantBodyOut: forms.ReceptionFormOut{},
+		},
 		{
 			name:        "missing pvzId",
 			url:         "/pvz//close_last_reception",
@@ -362,3 +462,612 @@ func TestReceptionHandler_CloseReception(t *testing.T) {
 		})
 	}
 }
+
+func TestReceptionHandler_ListReceptions(t *testing.T) {
+	pvzId := uuid.New()
+	receptionId := uuid.New()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantFilter *models.ReceptionFilter
+		mockError  error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "ok",
+			query: "?status=close&startDate=2026-01-01T00:00:00Z&endDate=2026-02-01T00:00:00Z&page=2&limit=5",
+			wantFilter: &models.ReceptionFilter{
+				PvzId: pvzId, Status: models.Closed, StartDate: start, EndDate: end, Page: 2, Limit: 5,
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   receptionId.String(),
+		},
+		{
+			name:       "invalid status",
+			query:      "?status=lost",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid startDate",
+			query:      "?startDate=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "startDate after endDate",
+			query:      "?startDate=2026-02-01T00:00:00Z&endDate=2026-01-01T00:00:00Z",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "usecase error",
+			query: "?startDate=2026-01-01T00:00:00Z&endDate=2026-02-01T00:00:00Z",
+			wantFilter: &models.ReceptionFilter{
+				PvzId: pvzId, StartDate: start, EndDate: end, Page: 1, Limit: 10,
+			},
+			mockError:  errors.New("some error"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+
+			if tt.wantFilter != nil {
+				mockUseCase.EXPECT().
+					ListReceptions(gomock.Any(), *tt.wantFilter).
+					Return([]models.Reception{{Id: receptionId, PvzId: pvzId, Status: models.Closed}}, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/pvz/%s/receptions%s", pvzId, tt.query), nil)
+			req = mux.SetURLVars(req, map[string]string{"pvzId": pvzId.String()})
+			rec := httptest.NewRecorder()
+
+			h.ListReceptions(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestReceptionHandler_GetReception(t *testing.T) {
+	pvzId := uuid.New()
+	receptionId := uuid.New()
+	productId := uuid.New()
+	reception := models.ReceptionProducts{
+		Reception: models.Reception{Id: receptionId, PvzId: pvzId, Status: models.InProgress},
+		Products:  []models.Product{{Id: productId, ProductType: "обувь", ReceptionId: receptionId}},
+	}
+
+	tests := []struct {
+		name       string
+		current    bool
+		mockError  error
+		wantStatus int
+	}{
+		{name: "by id", wantStatus: http.StatusOK},
+		{name: "by id not found", mockError: usecase.ReceptionNotFound, wantStatus: http.StatusNotFound},
+		{name: "by id usecase error", mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+		{name: "current", current: true, wantStatus: http.StatusOK},
+		{name: "no current reception", current: true, mockError: usecase.ReceptionNotOpened, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+			rec := httptest.NewRecorder()
+
+			if tt.current {
+				mockUseCase.EXPECT().GetCurrentReception(gomock.Any(), pvzId).Return(reception, tt.mockError)
+
+				req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/pvz/%s/receptions/current", pvzId), nil)
+				h.GetCurrentReception(rec, mux.SetURLVars(req, map[string]string{"pvzId": pvzId.String()}))
+			} else {
+				mockUseCase.EXPECT().GetReception(gomock.Any(), receptionId).Return(reception, tt.mockError)
+
+				req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/receptions/%s", receptionId), nil)
+				h.GetReception(rec, mux.SetURLVars(req, map[string]string{"receptionId": receptionId.String()}))
+			}
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.ReceptionProductsFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToReceptionProductsFormOut(reception), out)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/receptions/invalid-uuid", nil)
+	handlers.NewReceptionHandler(nil).GetReception(rec, mux.SetURLVars(req, map[string]string{"receptionId": "invalid-uuid"}))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestReceptionHandler_RemoveProductById(t *testing.T) {
+	receptionId := uuid.New()
+	productId := uuid.New()
+
+	tests := []struct {
+		name       string
+		setVars    map[string]string
+		expectCall bool
+		mockError  error
+		wantStatus int
+	}{
+		{
+			name:       "ok",
+			setVars:    map[string]string{"receptionId": receptionId.String(), "productId": productId.String()},
+			expectCall: true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid productId",
+			setVars:    map[string]string{"receptionId": receptionId.String(), "productId": "invalid-uuid"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "product not found",
+			setVars:    map[string]string{"receptionId": receptionId.String(), "productId": productId.String()},
+			expectCall: true,
+			mockError:  usecase.ProductNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "reception is closed",
+			setVars:    map[string]string{"receptionId": receptionId.String(), "productId": productId.String()},
+			expectCall: true,
+			mockError:  usecase.ReceptionNotOpened,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+
+			if tt.expectCall {
+				mockUseCase.EXPECT().RemoveProductById(gomock.Any(), receptionId, productId).Return(tt.mockError)
+			}
+
+			url := fmt.Sprintf("/receptions/%s/products/%s", tt.setVars["receptionId"], tt.setVars["productId"])
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, url, nil), tt.setVars)
+			rec := httptest.NewRecorder()
+
+			h.RemoveProductById(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestReceptionHandler_AddProducts(t *testing.T) {
+	pvzId := uuid.New()
+	receptionId := uuid.New()
+	form := forms.BatchProductForm{
+		PvzId: pvzId,
+		Items: []forms.BatchProductItemForm{{Type: "обувь"}, {Type: "рандомный тип"}},
+	}
+	products := []models.Product{
+		{Id: uuid.New(), ProductType: "обувь", ReceptionId: receptionId},
+		{Id: uuid.New(), ProductType: "обувь", ReceptionId: receptionId},
+	}
+
+	tests := []struct {
+		name       string
+		body       io.Reader
+		expectCall bool
+		mockReturn []models.Product
+		mockError  error
+		wantStatus int
+		wantBody   forms.BatchProductsFormOut
+	}{
+		{
+			name:       "ok",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockReturn: products,
+			wantStatus: http.StatusCreated,
+			wantBody:   forms.ToBatchProductsFormOut(products),
+		},
+		{
+			name:       "invalid item rejects the batch",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  &usecase.BatchItemsError{Items: map[int]error{1: usecase.CategoryNotAvailable}},
+			wantStatus: http.StatusBadRequest,
+			wantBody: forms.BatchProductsFormOut{
+				Message: "batch is rejected",
+				Results: []forms.BatchProductResultOut{
+					{Index: 0, Status: forms.BatchItemSkipped},
+					{Index: 1, Status: forms.BatchItemRejected, Error: usecase.CategoryNotAvailable.Error()},
+				},
+			},
+		},
+		{
+			name:       "invalid json",
+			body:       strings.NewReader("{invalid json"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "pvz is full",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  usecase.PvzOverCapacity,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "not assigned to pvz",
+			body:       toJSONBody(form),
+			expectCall: true,
+			mockError:  usecase.PvzAccessDenied,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+
+			if tt.expectCall {
+				mockUseCase.EXPECT().AddProducts(gomock.Any(), form).Return(tt.mockReturn, tt.mockError)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/products/batch", tt.body)
+			rec := httptest.NewRecorder()
+
+			h.AddProducts(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody.Results != nil {
+				var out forms.BatchProductsFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, tt.wantBody, out)
+			}
+		})
+	}
+}
+
+func TestReceptionHandler_SearchProducts(t *testing.T) {
+	barcode := "4600000000017"
+	locations := []models.ProductLocation{{
+		Product:         models.Product{Id: uuid.New(), ProductType: "обувь", Barcode: barcode, ExternalOrderId: "WB-1"},
+		PvzId:           uuid.New(),
+		City:            "Москва",
+		ReceptionStatus: models.Closed,
+	}}
+
+	tests := []struct {
+		name       string
+		query      string
+		expectCall bool
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", query: "?barcode=" + barcode, expectCall: true, wantStatus: http.StatusOK},
+		{name: "missing barcode", wantStatus: http.StatusBadRequest},
+		{name: "invalid barcode", query: "?barcode=%20", expectCall: true, mockError: usecase.InvalidProduct, wantStatus: http.StatusBadRequest},
+		{name: "usecase error", query: "?barcode=" + barcode, expectCall: true, mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+
+			if tt.expectCall {
+				mockUseCase.EXPECT().SearchProducts(gomock.Any(), gomock.Any()).Return(locations, tt.mockError)
+			}
+
+			rec := httptest.NewRecorder()
+			h.SearchProducts(rec, httptest.NewRequest(http.MethodGet, "/products/search"+tt.query, nil))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out []forms.ProductLocationFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToProductLocationsFormOut(locations), out)
+			}
+		})
+	}
+}
+
+func TestReceptionHandler_ReplaceManifest(t *testing.T) {
+	pvzId := uuid.New()
+	form := forms.ManifestForm{Items: []forms.ManifestItemForm{{Barcode: "A-1", Type: "обувь"}}}
+	manifest := models.Manifest{
+		Id:        uuid.New(),
+		PvzId:     pvzId,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Items:     []models.ManifestItem{{Barcode: "A-1", ProductType: "обувь"}},
+	}
+
+	tests := []struct {
+		name       string
+		body       io.Reader
+		expectCall bool
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", body: toJSONBody(form), expectCall: true, wantStatus: http.StatusOK},
+		{name: "invalid json", body: strings.NewReader("invalid"), wantStatus: http.StatusBadRequest},
+		{name: "invalid manifest", body: toJSONBody(form), expectCall: true, mockError: fmt.Errorf("%w: item 0", usecase.InvalidManifest), wantStatus: http.StatusBadRequest},
+		{name: "pvz not found", body: toJSONBody(form), expectCall: true, mockError: usecase.PvzNotFound, wantStatus: http.StatusNotFound},
+		{name: "usecase error", body: toJSONBody(form), expectCall: true, mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+
+			if tt.expectCall {
+				mockUseCase.EXPECT().ReplaceManifest(gomock.Any(), pvzId, form).Return(manifest, tt.mockError)
+			}
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/pvz/%s/manifest", pvzId), tt.body)
+			h.ReplaceManifest(rec, mux.SetURLVars(req, map[string]string{"pvzId": pvzId.String()}))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.ManifestFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToManifestFormOut(manifest), out)
+			}
+		})
+	}
+}
+
+func TestReceptionHandler_GetDiscrepancyReport(t *testing.T) {
+	receptionId := uuid.New()
+	productId := uuid.New()
+	report := models.DiscrepancyReport{
+		ReceptionId: receptionId,
+		ManifestId:  uuid.New(),
+		Items: []models.Discrepancy{
+			{Kind: models.Shortage, Barcode: "A-1", ExpectedType: "обувь"},
+			{Kind: models.Surplus, ProductId: productId, ActualType: "одежда"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", wantStatus: http.StatusOK},
+		{name: "no report", mockError: usecase.DiscrepancyReportNotFound, wantStatus: http.StatusNotFound},
+		{name: "usecase error", mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+			mockUseCase.EXPECT().GetDiscrepancyReport(gomock.Any(), receptionId).Return(report, tt.mockError)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/receptions/%s/discrepancies", receptionId), nil)
+			h.GetDiscrepancyReport(rec, mux.SetURLVars(req, map[string]string{"receptionId": receptionId.String()}))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.DiscrepancyReportFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Len(t, out.Shortages, 1)
+				require.Len(t, out.Surpluses, 1)
+				require.Empty(t, out.WrongCategory)
+				require.Equal(t, productId, *out.Surpluses[0].ProductId)
+			}
+		})
+	}
+}
+
+func TestReceptionHandler_ReopenReception(t *testing.T) {
+	receptionId := uuid.New()
+	form := forms.ReopenReceptionForm{Reason: "закрыта раньше времени"}
+	reception := models.Reception{Id: receptionId, PvzId: uuid.New(), Status: models.InProgress}
+
+	tests := []struct {
+		name       string
+		body       io.Reader
+		expectCall bool
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", body: toJSONBody(form), expectCall: true, wantStatus: http.StatusOK},
+		{name: "invalid json", body: strings.NewReader("invalid"), wantStatus: http.StatusBadRequest},
+		{name: "invalid reason", body: toJSONBody(form), expectCall: true, mockError: usecase.InvalidReason, wantStatus: http.StatusBadRequest},
+		{name: "not found", body: toJSONBody(form), expectCall: true, mockError: usecase.ReceptionNotFound, wantStatus: http.StatusNotFound},
+		{name: "not closed", body: toJSONBody(form), expectCall: true, mockError: &usecase.StatusTransitionError{From: models.InProgress, To: models.InProgress}, wantStatus: http.StatusConflict},
+		{name: "pvz has open reception", body: toJSONBody(form), expectCall: true, mockError: usecase.ReceptionNotReopenable, wantStatus: http.StatusConflict},
+		{name: "usecase error", body: toJSONBody(form), expectCall: true, mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+
+			if tt.expectCall {
+				mockUseCase.EXPECT().ReopenReception(gomock.Any(), receptionId, form).Return(reception, tt.mockError)
+			}
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/receptions/%s/reopen", receptionId), tt.body)
+			h.ReopenReception(rec, mux.SetURLVars(req, map[string]string{"receptionId": receptionId.String()}))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.ReceptionFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToReceptionFormOut(reception), out)
+			}
+		})
+	}
+}
+
+func TestReceptionHandler_CancelReception(t *testing.T) {
+	receptionId := uuid.New()
+	form := forms.CancelReceptionForm{Reason: "открыта по ошибке"}
+	reception := models.Reception{Id: receptionId, PvzId: uuid.New(), Status: models.Cancelled}
+
+	tests := []struct {
+		name       string
+		body       io.Reader
+		expectCall bool
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", body: toJSONBody(form), expectCall: true, wantStatus: http.StatusOK},
+		{name: "invalid json", body: strings.NewReader("invalid"), wantStatus: http.StatusBadRequest},
+		{name: "invalid reason", body: toJSONBody(form), expectCall: true, mockError: usecase.InvalidReason, wantStatus: http.StatusBadRequest},
+		{name: "not assigned", body: toJSONBody(form), expectCall: true, mockError: usecase.PvzAccessDenied, wantStatus: http.StatusForbidden},
+		{name: "not found", body: toJSONBody(form), expectCall: true, mockError: usecase.ReceptionNotFound, wantStatus: http.StatusNotFound},
+		{name: "already closed", body: toJSONBody(form), expectCall: true, mockError: &usecase.StatusTransitionError{From: models.Closed, To: models.Cancelled}, wantStatus: http.StatusConflict},
+		{name: "usecase error", body: toJSONBody(form), expectCall: true, mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+
+			if tt.expectCall {
+				mockUseCase.EXPECT().CancelReception(gomock.Any(), receptionId, form).Return(reception, tt.mockError)
+			}
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/receptions/%s/cancel", receptionId), tt.body)
+			h.CancelReception(rec, mux.SetURLVars(req, map[string]string{"receptionId": receptionId.String()}))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.ReceptionFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToReceptionFormOut(reception), out)
+			}
+		})
+	}
+}
+
+func TestReceptionHandler_VerifyReception(t *testing.T) {
+	receptionId := uuid.New()
+	reception := models.Reception{Id: receptionId, PvzId: uuid.New(), Status: models.Verified}
+
+	tests := []struct {
+		name       string
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", wantStatus: http.StatusOK},
+		{name: "not found", mockError: usecase.ReceptionNotFound, wantStatus: http.StatusNotFound},
+		{name: "not closed", mockError: &usecase.StatusTransitionError{From: models.InProgress, To: models.Verified}, wantStatus: http.StatusConflict},
+		{name: "usecase error", mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+			mockUseCase.EXPECT().VerifyReception(gomock.Any(), receptionId).Return(reception, tt.mockError)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/receptions/%s/verify", receptionId), nil)
+			h.VerifyReception(rec, mux.SetURLVars(req, map[string]string{"receptionId": receptionId.String()}))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.ReceptionFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, string(models.Verified), out.Status)
+			}
+		})
+	}
+}
+
+func TestReceptionHandler_GetReceptionHistory(t *testing.T) {
+	receptionId := uuid.New()
+	history := models.ReceptionHistory{
+		Reception: models.Reception{Id: receptionId, PvzId: uuid.New(), Status: models.InProgress},
+		Events: []models.AuditEntry{{
+			Id:        uuid.New(),
+			ActorId:   uuid.NewString(),
+			Action:    models.AuditReceptionReopen,
+			TargetId:  receptionId.String(),
+			Details:   map[string]string{"reason": "закрыта раньше времени"},
+			CreatedAt: time.Now().UTC().Truncate(time.Second),
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		mockError  error
+		wantStatus int
+	}{
+		{name: "ok", wantStatus: http.StatusOK},
+		{name: "not found", mockError: usecase.ReceptionNotFound, wantStatus: http.StatusNotFound},
+		{name: "usecase error", mockError: errors.New("some error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := mocks.NewMockReceptionUseCase(ctrl)
+			h := handlers.NewReceptionHandler(mockUseCase)
+			mockUseCase.EXPECT().GetReceptionHistory(gomock.Any(), receptionId).Return(history, tt.mockError)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/receptions/%s/history", receptionId), nil)
+			h.GetReceptionHistory(rec, mux.SetURLVars(req, map[string]string{"receptionId": receptionId.String()}))
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if rec.Code == http.StatusOK {
+				var out forms.ReceptionHistoryFormOut
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&out))
+				require.Equal(t, forms.ToReceptionHistoryFormOut(history), out)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type UserUseCase interface {
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error)
+	SetUserStatus(ctx context.Context, userId string, status models.UserStatus) (models.User, error)
+	ChangeRole(ctx context.Context, userId, role string) (models.User, error)
+	ResetPassword(ctx context.Context, userId string) (string, error)
+}
+
+type UserHandler struct {
+	userUseCase UserUseCase
+	policy      *models.Policy
+}
+
+// NewUserHandler - policy задаёт роли, которые модератор может назначить и по которым фильтрует список
+func NewUserHandler(userUseCase UserUseCase, policy *models.Policy) *UserHandler {
+	return &UserHandler{
+		userUseCase: userUseCase,
+		policy:      policy,
+	}
+}
+
+func (uh *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got list users request, trying to parse query params")
+
+	q := r.URL.Query()
+	filter := models.UserFilter{
+		Role:   q.Get("role"),
+		Status: models.UserStatus(q.Get("status")),
+	}
+
+	if filter.Role != "" && !utils.ValidateRole(uh.policy, filter.Role) {
+		logger.Error(r.Context(), fmt.Sprintf("Role %s is not valid", filter.Role))
+		utils.WriteJsonError(w, "Incorrect role was given", http.StatusBadRequest)
+		return
+	}
+
+	if filter.Status != "" && filter.Status != models.UserActive && filter.Status != models.UserDeactivated {
+		logger.Error(r.Context(), fmt.Sprintf("Status %s is not valid", filter.Status))
+		utils.WriteJsonError(w, "Incorrect status was given", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if filter.Page, err = strconv.Atoi(q.Get("page")); err != nil || filter.Page < 1 {
+		filter.Page = 1
+	}
+
+	if filter.Limit, err = strconv.Atoi(q.Get("limit")); err != nil || filter.Limit < 1 {
+		filter.Limit = 10
+	}
+
+	users, err := uh.userUseCase.ListUsers(r.Context(), filter)
+	if err != nil {
+		utils.WriteJsonError(w, "unable to list users", http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]forms.UserFormOut, 0, len(users))
+	for _, user := range users {
+		res = append(res, forms.ToUserFormOut(user))
+	}
+
+	utils.WriteJson(w, res, http.StatusOK)
+}
+
+func (uh *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	uh.setUserStatus(w, r, models.UserDeactivated)
+}
+
+func (uh *UserHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
+	uh.setUserStatus(w, r, models.UserActive)
+}
+
+func (uh *UserHandler) setUserStatus(w http.ResponseWriter, r *http.Request, status models.UserStatus) {
+	logger.Info(r.Context(), fmt.Sprintf("Got set user status %s request, trying to parse path params", status))
+
+	userId, ok := parseUserId(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := uh.userUseCase.SetUserStatus(r.Context(), userId, status)
+	if err != nil {
+		writeUserAdminError(w, err, "failed to update user status")
+		return
+	}
+
+	utils.WriteJson(w, forms.ToUserFormOut(user), http.StatusOK)
+}
+
+func (uh *UserHandler) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got change role request, trying to parse path params")
+
+	userId, ok := parseUserId(w, r)
+	if !ok {
+		return
+	}
+
+	var roleForm forms.ChangeRoleForm
+	if err := json.NewDecoder(r.Body).Decode(&roleForm); err != nil {
+		logger.Error(r.Context(), fmt.Sprintf("Error decoding json: %s", err.Error()))
+		utils.WriteJsonError(w, "failed to parse json", http.StatusBadRequest)
+		return
+	}
+
+	if !utils.ValidateRole(uh.policy, roleForm.Role) {
+		logger.Error(r.Context(), fmt.Sprintf("Role %s is not valid", roleForm.Role))
+		utils.WriteJsonError(w, "Incorrect role was given", http.StatusBadRequest)
+		return
+	}
+
+	user, err := uh.userUseCase.ChangeRole(r.Context(), userId, roleForm.Role)
+	if err != nil {
+		writeUserAdminError(w, err, "failed to change role")
+		return
+	}
+
+	utils.WriteJson(w, forms.ToUserFormOut(user), http.StatusOK)
+}
+
+func (uh *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	logger.Info(r.Context(), "Got reset password request, trying to parse path params")
+
+	userId, ok := parseUserId(w, r)
+	if !ok {
+		return
+	}
+
+	password, err := uh.userUseCase.ResetPassword(r.Context(), userId)
+	if err != nil {
+		writeUserAdminError(w, err, "failed to reset password")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	utils.WriteJson(w, forms.ResetPasswordFormOut{TemporaryPassword: password}, http.StatusOK)
+}
+
+func parseUserId(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userId, err := uuid.Parse(mux.Vars(r)["userId"])
+	if err != nil {
+		logger.Error(r.Context(), "invalid userId")
+		utils.WriteJsonError(w, "invalid userId", http.StatusBadRequest)
+		return "", false
+	}
+
+	return userId.String(), true
+}
+
+func writeUserAdminError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.UserNotFound):
+		utils.WriteJsonError(w, "user not found", http.StatusNotFound)
+	case errors.Is(err, usecase.CannotModifySelf):
+		utils.WriteJsonError(w, "you cannot modify your own account", http.StatusConflict)
+	default:
+		utils.WriteJsonError(w, message, http.StatusInternalServerError)
+	}
+}
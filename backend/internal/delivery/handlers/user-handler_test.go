@@ -0,0 +1,246 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/delivery/handlers"
+	"pvz/internal/delivery/mocks"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+)
+
+func TestUserHandler_ListUsers(t *testing.T) {
+	userId := uuid.NewString()
+
+	tests := []struct {
+		name         string
+		query        string
+		expectFilter *models.UserFilter
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:         "default pagination",
+			query:        "",
+			expectFilter: &models.UserFilter{Page: 1, Limit: 10},
+			expectStatus: http.StatusOK,
+			expectBody:   `[{"id":"` + userId + `","email":"employee@mail.ru","role":"employee","status":"active"}]`,
+		},
+		{
+			name:         "filters",
+			query:        "?role=employee&status=deactivated&page=2&limit=5",
+			expectFilter: &models.UserFilter{Role: "employee", Status: models.UserDeactivated, Page: 2, Limit: 5},
+			expectStatus: http.StatusOK,
+			expectBody:   `[{"id":"` + userId + `","email":"employee@mail.ru","role":"employee","status":"active"}]`,
+		},
+		{
+			name:         "unknown role",
+			query:        "?role=admin",
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"Incorrect role was given"}`,
+		},
+		{
+			name:         "unknown status",
+			query:        "?status=banned",
+			expectStatus: http.StatusBadRequest,
+			expectBody:   `{"message":"Incorrect status was given"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUC := mocks.NewMockUserUseCase(ctrl)
+			handler := handlers.NewUserHandler(mockUC, models.DefaultPolicy())
+
+			if tt.expectFilter != nil {
+				mockUC.EXPECT().ListUsers(gomock.Any(), *tt.expectFilter).Return([]models.User{
+					{Id: userId, Email: "employee@mail.ru", Role: "employee", Status: models.UserActive},
+				}, nil)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ListUsers(rec, httptest.NewRequest(http.MethodGet, "/users"+tt.query, nil))
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+			assert.JSONEq(t, tt.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestUserHandler_DeactivateUser(t *testing.T) {
+	userId := uuid.NewString()
+
+	tests := []struct {
+		name         string
+		userId       string
+		useCaseErr   error
+		expectCall   bool
+		expectStatus int
+	}{
+		{
+			name:         "ok",
+			userId:       userId,
+			expectCall:   true,
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "invalid userId",
+			userId:       "invalid",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "user not found",
+			userId:       userId,
+			useCaseErr:   usecase.UserNotFound,
+			expectCall:   true,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "own account",
+			userId:       userId,
+			useCaseErr:   usecase.CannotModifySelf,
+			expectCall:   true,
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name:         "usecase error",
+			userId:       userId,
+			useCaseErr:   errors.New("db error"),
+			expectCall:   true,
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUC := mocks.NewMockUserUseCase(ctrl)
+			handler := handlers.NewUserHandler(mockUC, models.DefaultPolicy())
+
+			if tt.expectCall {
+				mockUC.EXPECT().SetUserStatus(gomock.Any(), tt.userId, models.UserDeactivated).
+					Return(models.User{Id: tt.userId, Status: models.UserDeactivated}, tt.useCaseErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/users/"+tt.userId+"/deactivate", nil)
+			req = mux.SetURLVars(req, map[string]string{"userId": tt.userId})
+			rec := httptest.NewRecorder()
+
+			handler.DeactivateUser(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestUserHandler_ChangeRole(t *testing.T) {
+	userId := uuid.NewString()
+
+	tests := []struct {
+		name         string
+		body         string
+		expectCall   bool
+		expectStatus int
+	}{
+		{
+			name:         "ok",
+			body:         `{"role":"moderator"}`,
+			expectCall:   true,
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "unknown role",
+			body:         `{"role":"admin"}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid json",
+			body:         `{invalid`,
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUC := mocks.NewMockUserUseCase(ctrl)
+			handler := handlers.NewUserHandler(mockUC, models.DefaultPolicy())
+
+			if tt.expectCall {
+				mockUC.EXPECT().ChangeRole(gomock.Any(), userId, "moderator").
+					Return(models.User{Id: userId, Role: "moderator", Status: models.UserActive}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/users/"+userId+"/role", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"userId": userId})
+			rec := httptest.NewRecorder()
+
+			handler.ChangeRole(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
+
+func TestUserHandler_ChangeRole_Policy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy, err := models.NewPolicy(map[string][]string{"auditor": {"pvz:read"}})
+	require.NoError(t, err)
+
+	mockUC := mocks.NewMockUserUseCase(ctrl)
+	handler := handlers.NewUserHandler(mockUC, policy)
+	userId := uuid.NewString()
+
+	mockUC.EXPECT().ChangeRole(gomock.Any(), userId, "auditor").
+		Return(models.User{Id: userId, Role: "auditor", Status: models.UserActive}, nil)
+
+	for role, wantStatus := range map[string]int{"auditor": http.StatusOK, "moderator": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodPut, "/users/"+userId+"/role", strings.NewReader(`{"role":"`+role+`"}`))
+		req = mux.SetURLVars(req, map[string]string{"userId": userId})
+		rec := httptest.NewRecorder()
+
+		handler.ChangeRole(rec, req)
+
+		assert.Equal(t, wantStatus, rec.Code, "role %s is checked against the injected policy", role)
+	}
+}
+
+func TestUserHandler_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockUserUseCase(ctrl)
+	handler := handlers.NewUserHandler(mockUC, models.DefaultPolicy())
+
+	userId := uuid.NewString()
+	mockUC.EXPECT().ResetPassword(gomock.Any(), userId).Return("temporary", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/"+userId+"/reset_password", nil)
+	req = mux.SetURLVars(req, map[string]string{"userId": userId})
+	rec := httptest.NewRecorder()
+
+	handler.ResetPassword(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"temporaryPassword":"temporary"}`, rec.Body.String())
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, principal models.Principal) (bool, error)
+}
+
+// AuthMiddleware проверяет access-токен и кладёт principal в контекст. Права проверяет PermissionMiddleware
+func AuthMiddleware(revocationChecker TokenRevocationChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headerValue := r.Header.Get("Authorization")
+			tokenParts := strings.Split(headerValue, " ")
+
+			if len(tokenParts) != 2 {
+				logger.Error(r.Context(), "Invalid Authorization header params count")
+				utils.WriteJsonError(w, "invalid token", http.StatusBadRequest)
+				return
+			}
+
+			if tokenParts[0] != "Bearer" {
+				logger.Error(r.Context(), fmt.Sprintf("Invalid first param: %s", tokenParts[0]))
+				utils.WriteJsonError(w, "invalid scheme", http.StatusBadRequest)
+				return
+			}
+
+			principal, err := utils.ParseToken(tokenParts[1])
+			if err != nil {
+				logger.Error(r.Context(), fmt.Sprintf("Incorrect role or wrong token format: %s", err.Error()))
+				utils.WriteJsonError(w, "Incorrect role or wrong token format", http.StatusForbidden)
+				return
+			}
+
+			isRevoked, err := revocationChecker.IsTokenRevoked(r.Context(), principal)
+			if err != nil {
+				logger.Error(r.Context(), fmt.Sprintf("Unable to check token revocation: %s", err.Error()))
+				utils.WriteJsonError(w, "unable to verify token", http.StatusInternalServerError)
+				return
+			}
+
+			if isRevoked {
+				logger.Error(r.Context(), fmt.Sprintf("Token %s has been revoked", principal.TokenId))
+				utils.WriteJsonError(w, "token has been revoked", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(utils.SetPrincipal(r.Context(), principal)))
+		})
+	}
+}
@@ -48,66 +48,135 @@ func GetRequestId(ctx context.Context) string {
 	return ""
 }
 
-func TestRoleMiddleware(t *testing.T) {
-	originalGetRole := utils.GetRole
-	defer func() { utils.GetRole = originalGetRole }() // восстановим после теста
+type stubRevocationChecker struct {
+	revoked map[string]bool
+	err     error
+}
+
+func (s stubRevocationChecker) IsTokenRevoked(_ context.Context, principal models.Principal) (bool, error) {
+	return s.revoked[principal.TokenId], s.err
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	originalParseToken := utils.ParseToken
+	defer func() { utils.ParseToken = originalParseToken }() // восстановим после теста
 
 	type testCase struct {
 		name           string
 		authHeader     string
-		mockGetRole    func(token string) (string, error)
-		allowedRoles   []models.Role
+		mockParseToken func(token string) (models.Principal, error)
+		checker        stubRevocationChecker
+		permissions    []models.Permission
 		expectStatus   int
 		expectResponse string
 	}
 
 	tests := []testCase{
 		{
-			name:         "valid token and role allowed",
-			authHeader:   "Bearer token",
-			mockGetRole:  func(token string) (string, error) { return "client", nil },
-			allowedRoles: []models.Role{models.Client},
+			name:       "valid token without required permissions",
+			authHeader: "Bearer token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{UserId: "user", TokenId: "jti", Role: "client"}, nil
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:       "role has permission",
+			authHeader: "Bearer token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{UserId: "user", TokenId: "jti", Role: "employee"}, nil
+			},
+			permissions:  []models.Permission{models.ReceptionCreate, models.ProductCreate},
 			expectStatus: http.StatusOK,
 		},
+		{
+			name:       "role lacks one of permissions",
+			authHeader: "Bearer token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{UserId: "user", TokenId: "jti", Role: "employee"}, nil
+			},
+			permissions:    []models.Permission{models.ReceptionCreate, models.PvzCreate},
+			expectStatus:   http.StatusForbidden,
+			expectResponse: `{"message":"You don't have permission to use this endpoint"}`,
+		},
 		{
 			name:           "invalid token format",
 			authHeader:     "Bearer",
-			mockGetRole:    nil, // не будет вызова
-			allowedRoles:   []models.Role{models.Client},
+			mockParseToken: nil, // не будет вызова
+			permissions:    []models.Permission{models.PvzRead},
 			expectStatus:   http.StatusBadRequest,
 			expectResponse: `{"message":"invalid token"}`,
 		},
 		{
-			name:           "role not allowed",
-			authHeader:     "Bearer token",
-			mockGetRole:    func(token string) (string, error) { return "admin", nil },
-			allowedRoles:   []models.Role{models.Client},
+			name:       "unknown role",
+			authHeader: "Bearer token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{UserId: "user", TokenId: "jti", Role: "admin"}, nil
+			},
+			permissions:    []models.Permission{models.PvzRead},
 			expectStatus:   http.StatusForbidden,
 			expectResponse: `{"message":"You don't have permission to use this endpoint"}`,
 		},
 		{
-			name:           "token parse error",
-			authHeader:     "Bearer token",
-			mockGetRole:    func(token string) (string, error) { return "", errors.New("bad token") },
-			allowedRoles:   []models.Role{models.Client},
+			name:       "token parse error",
+			authHeader: "Bearer token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{}, errors.New("bad token")
+			},
+			permissions:    []models.Permission{models.PvzRead},
 			expectStatus:   http.StatusForbidden,
 			expectResponse: `{"message":"Incorrect role or wrong token format"}`,
 		},
+		{
+			name:       "invalid scheme",
+			authHeader: "Basic token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{UserId: "user", TokenId: "jti", Role: "client"}, nil
+			},
+			permissions:    []models.Permission{models.PvzRead},
+			expectStatus:   http.StatusBadRequest,
+			expectResponse: `{"message":"invalid scheme"}`,
+		},
+		{
+			name:       "revoked token",
+			authHeader: "Bearer token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{UserId: "user", TokenId: "revoked-jti", Role: "client"}, nil
+			},
+			checker:        stubRevocationChecker{revoked: map[string]bool{"revoked-jti": true}},
+			permissions:    []models.Permission{models.PvzRead},
+			expectStatus:   http.StatusUnauthorized,
+			expectResponse: `{"message":"token has been revoked"}`,
+		},
+		{
+			name:       "revocation check error",
+			authHeader: "Bearer token",
+			mockParseToken: func(token string) (models.Principal, error) {
+				return models.Principal{UserId: "user", TokenId: "jti", Role: "client"}, nil
+			},
+			checker:        stubRevocationChecker{err: errors.New("db error")},
+			permissions:    []models.Permission{models.PvzRead},
+			expectStatus:   http.StatusInternalServerError,
+			expectResponse: `{"message":"unable to verify token"}`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.mockGetRole != nil {
-				utils.GetRole = tt.mockGetRole
+			if tt.mockParseToken != nil {
+				utils.ParseToken = tt.mockParseToken
 			}
 
 			nextCalled := false
 			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				nextCalled = true
+				principal, ok := utils.GetPrincipal(r.Context())
+				assert.True(t, ok, "principal should be set in context")
+				assert.Equal(t, "user", principal.UserId)
 				w.WriteHeader(http.StatusOK)
 			})
 
-			middleware := middleware.RoleMiddleware(tt.allowedRoles...)(next)
+			middleware := middleware.AuthMiddleware(tt.checker)(middleware.PermissionMiddleware(models.DefaultPolicy(), tt.permissions...)(next))
 
 			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
 			req.Header.Set("Authorization", tt.authHeader)
@@ -124,3 +193,57 @@ func TestRoleMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestPermissionMiddleware_WithoutPrincipal(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not be called")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	rec := httptest.NewRecorder()
+
+	middleware.PermissionMiddleware(models.DefaultPolicy())(next).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestRealUserMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		principal    *models.Principal
+		expectStatus int
+	}{
+		{
+			name:         "real user",
+			principal:    &models.Principal{UserId: "user", Role: "employee"},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "dummy token",
+			principal:    &models.Principal{UserId: "user", Role: "employee", Dummy: true},
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "without principal",
+			expectStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+			if tt.principal != nil {
+				req = req.WithContext(utils.SetPrincipal(req.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+
+			middleware.RealUserMiddleware(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type PermissionChecker interface {
+	HasPermission(role string, permission models.Permission) bool
+}
+
+// PermissionMiddleware пропускает запрос, только если у роли из токена есть все перечисленные разрешения.
+// Должен стоять после AuthMiddleware
+func PermissionMiddleware(checker PermissionChecker, permissions ...models.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := utils.GetPrincipal(r.Context())
+			if !ok {
+				logger.Error(r.Context(), "Principal is missing in context")
+				utils.WriteJsonError(w, "You don't have permission to use this endpoint", http.StatusForbidden)
+				return
+			}
+
+			for _, permission := range permissions {
+				if !checker.HasPermission(principal.Role, permission) {
+					logger.Error(r.Context(), fmt.Sprintf("Role %s doesn't have permission %s", principal.Role, permission))
+					utils.WriteJsonError(w, "You don't have permission to use this endpoint", http.StatusForbidden)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+// RealUserMiddleware не пускает dummy-токены к операциям, завязанным на реального пользователя.
+// Должен стоять после AuthMiddleware
+func RealUserMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := utils.GetPrincipal(r.Context())
+		if !ok || principal.Dummy {
+			logger.Error(r.Context(), "Dummy token is not allowed for user-bound operation")
+			utils.WriteJsonError(w, "dummy token can't be used for this endpoint", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -66,6 +66,20 @@ func (mr *MockAuthUseCaseMockRecorder) DummyLogin(ctx, role interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DummyLogin", reflect.TypeOf((*MockAuthUseCase)(nil).DummyLogin), ctx, role)
 }
 
+// GetJWKS mocks base method.
+func (m *MockAuthUseCase) GetJWKS(ctx context.Context) models.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS", ctx)
+	ret0, _ := ret[0].(models.JSONWebKeySet)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockAuthUseCaseMockRecorder) GetJWKS(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockAuthUseCase)(nil).GetJWKS), ctx)
+}
+
 // IsUserExist mocks base method.
 func (m *MockAuthUseCase) IsUserExist(ctx context.Context, email string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -82,10 +96,10 @@ func (mr *MockAuthUseCaseMockRecorder) IsUserExist(ctx, email interface{}) *gomo
 }
 
 // LogInUser mocks base method.
-func (m *MockAuthUseCase) LogInUser(ctx context.Context, logInForm forms.LogInFormIn) (string, error) {
+func (m *MockAuthUseCase) LogInUser(ctx context.Context, logInForm forms.LogInFormIn) (models.TokenPair, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "LogInUser", ctx, logInForm)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(models.TokenPair)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -95,3 +109,46 @@ func (mr *MockAuthUseCaseMockRecorder) LogInUser(ctx, logInForm interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogInUser", reflect.TypeOf((*MockAuthUseCase)(nil).LogInUser), ctx, logInForm)
 }
+
+// Logout mocks base method.
+func (m *MockAuthUseCase) Logout(ctx context.Context, principal models.Principal, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, principal, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthUseCaseMockRecorder) Logout(ctx, principal, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthUseCase)(nil).Logout), ctx, principal, refreshToken)
+}
+
+// RefreshToken mocks base method.
+func (m *MockAuthUseCase) RefreshToken(ctx context.Context, refreshToken string) (models.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(models.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockAuthUseCaseMockRecorder) RefreshToken(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthUseCase)(nil).RefreshToken), ctx, refreshToken)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthUseCase) UnlockUser(ctx context.Context, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthUseCaseMockRecorder) UnlockUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthUseCase)(nil).UnlockUser), ctx, userId)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery\handlers\category-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	forms "pvz/internal/delivery/forms"
+	models "pvz/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockCategoryUseCase is a mock of CategoryUseCase interface.
+type MockCategoryUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryUseCaseMockRecorder
+}
+
+// MockCategoryUseCaseMockRecorder is the mock recorder for MockCategoryUseCase.
+type MockCategoryUseCaseMockRecorder struct {
+	mock *MockCategoryUseCase
+}
+
+// NewMockCategoryUseCase creates a new mock instance.
+func NewMockCategoryUseCase(ctrl *gomock.Controller) *MockCategoryUseCase {
+	mock := &MockCategoryUseCase{ctrl: ctrl}
+	mock.recorder = &MockCategoryUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryUseCase) EXPECT() *MockCategoryUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryUseCase) CreateCategory(ctx context.Context, form forms.CreateCategoryForm) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, form)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryUseCaseMockRecorder) CreateCategory(ctx, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryUseCase)(nil).CreateCategory), ctx, form)
+}
+
+// ListCategories mocks base method.
+func (m *MockCategoryUseCase) ListCategories(ctx context.Context) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", ctx)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockCategoryUseCaseMockRecorder) ListCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryUseCase)(nil).ListCategories), ctx)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryUseCase) UpdateCategory(ctx context.Context, categoryId uuid.UUID, form forms.UpdateCategoryForm) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, categoryId, form)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryUseCaseMockRecorder) UpdateCategory(ctx, categoryId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryUseCase)(nil).UpdateCategory), ctx, categoryId, form)
+}
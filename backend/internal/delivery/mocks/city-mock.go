@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery\handlers\city-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	forms "pvz/internal/delivery/forms"
+	models "pvz/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockCityUseCase is a mock of CityUseCase interface.
+type MockCityUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCityUseCaseMockRecorder
+}
+
+// MockCityUseCaseMockRecorder is the mock recorder for MockCityUseCase.
+type MockCityUseCaseMockRecorder struct {
+	mock *MockCityUseCase
+}
+
+// NewMockCityUseCase creates a new mock instance.
+func NewMockCityUseCase(ctrl *gomock.Controller) *MockCityUseCase {
+	mock := &MockCityUseCase{ctrl: ctrl}
+	mock.recorder = &MockCityUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCityUseCase) EXPECT() *MockCityUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCity mocks base method.
+func (m *MockCityUseCase) CreateCity(ctx context.Context, form forms.CreateCityForm) (models.City, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCity", ctx, form)
+	ret0, _ := ret[0].(models.City)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCity indicates an expected call of CreateCity.
+func (mr *MockCityUseCaseMockRecorder) CreateCity(ctx, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCity", reflect.TypeOf((*MockCityUseCase)(nil).CreateCity), ctx, form)
+}
+
+// ListCities mocks base method.
+func (m *MockCityUseCase) ListCities(ctx context.Context) ([]models.City, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCities", ctx)
+	ret0, _ := ret[0].([]models.City)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCities indicates an expected call of ListCities.
+func (mr *MockCityUseCaseMockRecorder) ListCities(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCities", reflect.TypeOf((*MockCityUseCase)(nil).ListCities), ctx)
+}
+
+// UpdateCity mocks base method.
+func (m *MockCityUseCase) UpdateCity(ctx context.Context, cityId uuid.UUID, form forms.UpdateCityForm) (models.City, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCity", ctx, cityId, form)
+	ret0, _ := ret[0].(models.City)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCity indicates an expected call of UpdateCity.
+func (mr *MockCityUseCaseMockRecorder) UpdateCity(ctx, cityId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCity", reflect.TypeOf((*MockCityUseCase)(nil).UpdateCity), ctx, cityId, form)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery\handlers\order-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	forms "pvz/internal/delivery/forms"
+	models "pvz/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockOrderUseCase is a mock of OrderUseCase interface.
+type MockOrderUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderUseCaseMockRecorder
+}
+
+// MockOrderUseCaseMockRecorder is the mock recorder for MockOrderUseCase.
+type MockOrderUseCaseMockRecorder struct {
+	mock *MockOrderUseCase
+}
+
+// NewMockOrderUseCase creates a new mock instance.
+func NewMockOrderUseCase(ctrl *gomock.Controller) *MockOrderUseCase {
+	mock := &MockOrderUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderUseCase) EXPECT() *MockOrderUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderUseCase) CreateOrder(ctx context.Context, orderForm forms.CreateOrderForm) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, orderForm)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderUseCaseMockRecorder) CreateOrder(ctx, orderForm interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderUseCase)(nil).CreateOrder), ctx, orderForm)
+}
+
+// CreatePickupCode mocks base method.
+func (m *MockOrderUseCase) CreatePickupCode(ctx context.Context, orderId uuid.UUID) (models.PickupCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePickupCode", ctx, orderId)
+	ret0, _ := ret[0].(models.PickupCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePickupCode indicates an expected call of CreatePickupCode.
+func (mr *MockOrderUseCaseMockRecorder) CreatePickupCode(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePickupCode", reflect.TypeOf((*MockOrderUseCase)(nil).CreatePickupCode), ctx, orderId)
+}
+
+// GetMyOrders mocks base method.
+func (m *MockOrderUseCase) GetMyOrders(ctx context.Context) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyOrders", ctx)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyOrders indicates an expected call of GetMyOrders.
+func (mr *MockOrderUseCaseMockRecorder) GetMyOrders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetMyOrders), ctx)
+}
+
+// IssueOrder mocks base method.
+func (m *MockOrderUseCase) IssueOrder(ctx context.Context, issueForm forms.IssueOrderForm) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueOrder", ctx, issueForm)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueOrder indicates an expected call of IssueOrder.
+func (mr *MockOrderUseCaseMockRecorder) IssueOrder(ctx, issueForm interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueOrder", reflect.TypeOf((*MockOrderUseCase)(nil).IssueOrder), ctx, issueForm)
+}
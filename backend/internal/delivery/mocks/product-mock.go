@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/delivery\handlers\product-handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockProductUseCase is a mock of ProductUseCase interface.
+type MockProductUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductUseCaseMockRecorder
+}
+
+// MockProductUseCaseMockRecorder is the mock recorder for MockProductUseCase.
+type MockProductUseCaseMockRecorder struct {
+	mock *MockProductUseCase
+}
+
+// NewMockProductUseCase creates a new mock instance.
+func NewMockProductUseCase(ctrl *gomock.Controller) *MockProductUseCase {
+	mock := &MockProductUseCase{ctrl: ctrl}
+	mock.recorder = &MockProductUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductUseCase) EXPECT() *MockProductUseCaseMockRecorder {
+	return m.recorder
+}
+
+// IssueProduct mocks base method.
+func (m *MockProductUseCase) IssueProduct(ctx context.Context, productId uuid.UUID) (models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueProduct", ctx, productId)
+	ret0, _ := ret[0].(models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueProduct indicates an expected call of IssueProduct.
+func (mr *MockProductUseCaseMockRecorder) IssueProduct(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueProduct", reflect.TypeOf((*MockProductUseCase)(nil).IssueProduct), ctx, productId)
+}
+
+// ReturnProduct mocks base method.
+func (m *MockProductUseCase) ReturnProduct(ctx context.Context, productId uuid.UUID) (models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnProduct", ctx, productId)
+	ret0, _ := ret[0].(models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnProduct indicates an expected call of ReturnProduct.
+func (mr *MockProductUseCaseMockRecorder) ReturnProduct(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnProduct", reflect.TypeOf((*MockProductUseCase)(nil).ReturnProduct), ctx, productId)
+}
+
+// SendBackProducts mocks base method.
+func (m *MockProductUseCase) SendBackProducts(ctx context.Context, pvzId uuid.UUID) ([]models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBackProducts", ctx, pvzId)
+	ret0, _ := ret[0].([]models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBackProducts indicates an expected call of SendBackProducts.
+func (mr *MockProductUseCaseMockRecorder) SendBackProducts(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBackProducts", reflect.TypeOf((*MockProductUseCase)(nil).SendBackProducts), ctx, pvzId)
+}
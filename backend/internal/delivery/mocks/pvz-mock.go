@@ -11,6 +11,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockPvzUseCase is a mock of PvzUseCase interface.
@@ -36,6 +37,21 @@ func (m *MockPvzUseCase) EXPECT() *MockPvzUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AssignEmployee mocks base method.
+func (m *MockPvzUseCase) AssignEmployee(ctx context.Context, pvzId, userId uuid.UUID) (models.PvzEmployee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignEmployee", ctx, pvzId, userId)
+	ret0, _ := ret[0].(models.PvzEmployee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignEmployee indicates an expected call of AssignEmployee.
+func (mr *MockPvzUseCaseMockRecorder) AssignEmployee(ctx, pvzId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignEmployee", reflect.TypeOf((*MockPvzUseCase)(nil).AssignEmployee), ctx, pvzId, userId)
+}
+
 // CreatePvz mocks base method.
 func (m *MockPvzUseCase) CreatePvz(ctx context.Context, pvzForm forms.PvzForm) (models.Pvz, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +67,65 @@ func (mr *MockPvzUseCaseMockRecorder) CreatePvz(ctx, pvzForm interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePvz", reflect.TypeOf((*MockPvzUseCase)(nil).CreatePvz), ctx, pvzForm)
 }
 
+// DecommissionPvz mocks base method.
+func (m *MockPvzUseCase) DecommissionPvz(ctx context.Context, pvzId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecommissionPvz", ctx, pvzId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecommissionPvz indicates an expected call of DecommissionPvz.
+func (mr *MockPvzUseCaseMockRecorder) DecommissionPvz(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecommissionPvz", reflect.TypeOf((*MockPvzUseCase)(nil).DecommissionPvz), ctx, pvzId)
+}
+
+// GetNearbyPvz mocks base method.
+func (m *MockPvzUseCase) GetNearbyPvz(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyPvz", ctx, form)
+	ret0, _ := ret[0].([]models.NearbyPvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyPvz indicates an expected call of GetNearbyPvz.
+func (mr *MockPvzUseCaseMockRecorder) GetNearbyPvz(ctx, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyPvz", reflect.TypeOf((*MockPvzUseCase)(nil).GetNearbyPvz), ctx, form)
+}
+
+// GetOverloadedPvz mocks base method.
+func (m *MockPvzUseCase) GetOverloadedPvz(ctx context.Context, threshold float64) ([]models.PvzLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverloadedPvz", ctx, threshold)
+	ret0, _ := ret[0].([]models.PvzLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverloadedPvz indicates an expected call of GetOverloadedPvz.
+func (mr *MockPvzUseCaseMockRecorder) GetOverloadedPvz(ctx, threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverloadedPvz", reflect.TypeOf((*MockPvzUseCase)(nil).GetOverloadedPvz), ctx, threshold)
+}
+
+// GetPvz mocks base method.
+func (m *MockPvzUseCase) GetPvz(ctx context.Context, pvzId uuid.UUID) (models.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvz", ctx, pvzId)
+	ret0, _ := ret[0].(models.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvz indicates an expected call of GetPvz.
+func (mr *MockPvzUseCaseMockRecorder) GetPvz(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvz", reflect.TypeOf((*MockPvzUseCase)(nil).GetPvz), ctx, pvzId)
+}
+
 // GetPvzInfo mocks base method.
 func (m *MockPvzUseCase) GetPvzInfo(ctx context.Context, form forms.GetPvzInfoForm) ([]models.PvzInfo, error) {
 	m.ctrl.T.Helper()
@@ -65,3 +140,62 @@ func (mr *MockPvzUseCaseMockRecorder) GetPvzInfo(ctx, form interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzInfo", reflect.TypeOf((*MockPvzUseCase)(nil).GetPvzInfo), ctx, form)
 }
+
+// GetSchedule mocks base method.
+func (m *MockPvzUseCase) GetSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, pvzId)
+	ret0, _ := ret[0].(models.PvzSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockPvzUseCaseMockRecorder) GetSchedule(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockPvzUseCase)(nil).GetSchedule), ctx, pvzId)
+}
+
+// UnassignEmployee mocks base method.
+func (m *MockPvzUseCase) UnassignEmployee(ctx context.Context, pvzId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignEmployee", ctx, pvzId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignEmployee indicates an expected call of UnassignEmployee.
+func (mr *MockPvzUseCaseMockRecorder) UnassignEmployee(ctx, pvzId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignEmployee", reflect.TypeOf((*MockPvzUseCase)(nil).UnassignEmployee), ctx, pvzId, userId)
+}
+
+// UpdatePvz mocks base method.
+func (m *MockPvzUseCase) UpdatePvz(ctx context.Context, pvzId uuid.UUID, form forms.UpdatePvzForm) (models.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePvz", ctx, pvzId, form)
+	ret0, _ := ret[0].(models.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePvz indicates an expected call of UpdatePvz.
+func (mr *MockPvzUseCaseMockRecorder) UpdatePvz(ctx, pvzId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePvz", reflect.TypeOf((*MockPvzUseCase)(nil).UpdatePvz), ctx, pvzId, form)
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockPvzUseCase) UpdateSchedule(ctx context.Context, pvzId uuid.UUID, form forms.PvzScheduleForm) (models.PvzSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, pvzId, form)
+	ret0, _ := ret[0].(models.PvzSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockPvzUseCaseMockRecorder) UpdateSchedule(ctx, pvzId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockPvzUseCase)(nil).UpdateSchedule), ctx, pvzId, form)
+}
@@ -52,6 +52,36 @@ func (mr *MockReceptionUseCaseMockRecorder) AddProduct(ctx, productForm interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockReceptionUseCase)(nil).AddProduct), ctx, productForm)
 }
 
+// AddProducts mocks base method.
+func (m *MockReceptionUseCase) AddProducts(ctx context.Context, form forms.BatchProductForm) ([]models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProducts", ctx, form)
+	ret0, _ := ret[0].([]models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProducts indicates an expected call of AddProducts.
+func (mr *MockReceptionUseCaseMockRecorder) AddProducts(ctx, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProducts", reflect.TypeOf((*MockReceptionUseCase)(nil).AddProducts), ctx, form)
+}
+
+// CancelReception mocks base method.
+func (m *MockReceptionUseCase) CancelReception(ctx context.Context, receptionId uuid.UUID, form forms.CancelReceptionForm) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReception", ctx, receptionId, form)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelReception indicates an expected call of CancelReception.
+func (mr *MockReceptionUseCaseMockRecorder) CancelReception(ctx, receptionId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReception", reflect.TypeOf((*MockReceptionUseCase)(nil).CancelReception), ctx, receptionId, form)
+}
+
 // CloseReception mocks base method.
 func (m *MockReceptionUseCase) CloseReception(ctx context.Context, pvzId uuid.UUID) (models.Reception, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +112,81 @@ func (mr *MockReceptionUseCaseMockRecorder) CreateReception(ctx, receptionForm i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockReceptionUseCase)(nil).CreateReception), ctx, receptionForm)
 }
 
+// GetCurrentReception mocks base method.
+func (m *MockReceptionUseCase) GetCurrentReception(ctx context.Context, pvzId uuid.UUID) (models.ReceptionProducts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentReception", ctx, pvzId)
+	ret0, _ := ret[0].(models.ReceptionProducts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentReception indicates an expected call of GetCurrentReception.
+func (mr *MockReceptionUseCaseMockRecorder) GetCurrentReception(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentReception", reflect.TypeOf((*MockReceptionUseCase)(nil).GetCurrentReception), ctx, pvzId)
+}
+
+// GetDiscrepancyReport mocks base method.
+func (m *MockReceptionUseCase) GetDiscrepancyReport(ctx context.Context, receptionId uuid.UUID) (models.DiscrepancyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscrepancyReport", ctx, receptionId)
+	ret0, _ := ret[0].(models.DiscrepancyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscrepancyReport indicates an expected call of GetDiscrepancyReport.
+func (mr *MockReceptionUseCaseMockRecorder) GetDiscrepancyReport(ctx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscrepancyReport", reflect.TypeOf((*MockReceptionUseCase)(nil).GetDiscrepancyReport), ctx, receptionId)
+}
+
+// GetReception mocks base method.
+func (m *MockReceptionUseCase) GetReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReception", ctx, receptionId)
+	ret0, _ := ret[0].(models.ReceptionProducts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReception indicates an expected call of GetReception.
+func (mr *MockReceptionUseCaseMockRecorder) GetReception(ctx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReception", reflect.TypeOf((*MockReceptionUseCase)(nil).GetReception), ctx, receptionId)
+}
+
+// GetReceptionHistory mocks base method.
+func (m *MockReceptionUseCase) GetReceptionHistory(ctx context.Context, receptionId uuid.UUID) (models.ReceptionHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionHistory", ctx, receptionId)
+	ret0, _ := ret[0].(models.ReceptionHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionHistory indicates an expected call of GetReceptionHistory.
+func (mr *MockReceptionUseCaseMockRecorder) GetReceptionHistory(ctx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionHistory", reflect.TypeOf((*MockReceptionUseCase)(nil).GetReceptionHistory), ctx, receptionId)
+}
+
+// ListReceptions mocks base method.
+func (m *MockReceptionUseCase) ListReceptions(ctx context.Context, filter models.ReceptionFilter) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReceptions", ctx, filter)
+	ret0, _ := ret[0].([]models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReceptions indicates an expected call of ListReceptions.
+func (mr *MockReceptionUseCaseMockRecorder) ListReceptions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReceptions", reflect.TypeOf((*MockReceptionUseCase)(nil).ListReceptions), ctx, filter)
+}
+
 // RemoveProduct mocks base method.
 func (m *MockReceptionUseCase) RemoveProduct(ctx context.Context, pvzId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -95,3 +200,77 @@ func (mr *MockReceptionUseCaseMockRecorder) RemoveProduct(ctx, pvzId interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProduct", reflect.TypeOf((*MockReceptionUseCase)(nil).RemoveProduct), ctx, pvzId)
 }
+
+// RemoveProductById mocks base method.
+func (m *MockReceptionUseCase) RemoveProductById(ctx context.Context, receptionId, productId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveProductById", ctx, receptionId, productId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveProductById indicates an expected call of RemoveProductById.
+func (mr *MockReceptionUseCaseMockRecorder) RemoveProductById(ctx, receptionId, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProductById", reflect.TypeOf((*MockReceptionUseCase)(nil).RemoveProductById), ctx, receptionId, productId)
+}
+
+// ReopenReception mocks base method.
+func (m *MockReceptionUseCase) ReopenReception(ctx context.Context, receptionId uuid.UUID, form forms.ReopenReceptionForm) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenReception", ctx, receptionId, form)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenReception indicates an expected call of ReopenReception.
+func (mr *MockReceptionUseCaseMockRecorder) ReopenReception(ctx, receptionId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenReception", reflect.TypeOf((*MockReceptionUseCase)(nil).ReopenReception), ctx, receptionId, form)
+}
+
+// ReplaceManifest mocks base method.
+func (m *MockReceptionUseCase) ReplaceManifest(ctx context.Context, pvzId uuid.UUID, form forms.ManifestForm) (models.Manifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceManifest", ctx, pvzId, form)
+	ret0, _ := ret[0].(models.Manifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceManifest indicates an expected call of ReplaceManifest.
+func (mr *MockReceptionUseCaseMockRecorder) ReplaceManifest(ctx, pvzId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceManifest", reflect.TypeOf((*MockReceptionUseCase)(nil).ReplaceManifest), ctx, pvzId, form)
+}
+
+// SearchProducts mocks base method.
+func (m *MockReceptionUseCase) SearchProducts(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProducts", ctx, barcode)
+	ret0, _ := ret[0].([]models.ProductLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockReceptionUseCaseMockRecorder) SearchProducts(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockReceptionUseCase)(nil).SearchProducts), ctx, barcode)
+}
+
+// VerifyReception mocks base method.
+func (m *MockReceptionUseCase) VerifyReception(ctx context.Context, receptionId uuid.UUID) (models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyReception", ctx, receptionId)
+	ret0, _ := ret[0].(models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyReception indicates an expected call of VerifyReception.
+func (mr *MockReceptionUseCaseMockRecorder) VerifyReception(ctx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyReception", reflect.TypeOf((*MockReceptionUseCase)(nil).VerifyReception), ctx, receptionId)
+}
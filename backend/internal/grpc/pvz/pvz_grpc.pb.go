@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PVZService_GetPVZList_FullMethodName = "/grpc.PVZService/GetPVZList"
+	PVZService_GetPVZList_FullMethodName       = "/grpc.PVZService/GetPVZList"
+	PVZService_GetNearbyPVZList_FullMethodName = "/grpc.PVZService/GetNearbyPVZList"
+	PVZService_GetPVZSchedule_FullMethodName   = "/grpc.PVZService/GetPVZSchedule"
 )
 
 // PVZServiceClient is the client API for PVZService service.
@@ -27,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PVZServiceClient interface {
 	GetPVZList(ctx context.Context, in *GetPVZListRequest, opts ...grpc.CallOption) (*GetPVZListResponse, error)
+	GetNearbyPVZList(ctx context.Context, in *GetNearbyPVZListRequest, opts ...grpc.CallOption) (*GetNearbyPVZListResponse, error)
+	GetPVZSchedule(ctx context.Context, in *GetPVZScheduleRequest, opts ...grpc.CallOption) (*GetPVZScheduleResponse, error)
 }
 
 type pVZServiceClient struct {
@@ -47,11 +51,33 @@ func (c *pVZServiceClient) GetPVZList(ctx context.Context, in *GetPVZListRequest
 	return out, nil
 }
 
+func (c *pVZServiceClient) GetNearbyPVZList(ctx context.Context, in *GetNearbyPVZListRequest, opts ...grpc.CallOption) (*GetNearbyPVZListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNearbyPVZListResponse)
+	err := c.cc.Invoke(ctx, PVZService_GetNearbyPVZList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pVZServiceClient) GetPVZSchedule(ctx context.Context, in *GetPVZScheduleRequest, opts ...grpc.CallOption) (*GetPVZScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPVZScheduleResponse)
+	err := c.cc.Invoke(ctx, PVZService_GetPVZSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PVZServiceServer is the server API for PVZService service.
 // All implementations must embed UnimplementedPVZServiceServer
 // for forward compatibility.
 type PVZServiceServer interface {
 	GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error)
+	GetNearbyPVZList(context.Context, *GetNearbyPVZListRequest) (*GetNearbyPVZListResponse, error)
+	GetPVZSchedule(context.Context, *GetPVZScheduleRequest) (*GetPVZScheduleResponse, error)
 	mustEmbedUnimplementedPVZServiceServer()
 }
 
@@ -65,6 +91,12 @@ type UnimplementedPVZServiceServer struct{}
 func (UnimplementedPVZServiceServer) GetPVZList(context.Context, *GetPVZListRequest) (*GetPVZListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPVZList not implemented")
 }
+func (UnimplementedPVZServiceServer) GetNearbyPVZList(context.Context, *GetNearbyPVZListRequest) (*GetNearbyPVZListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearbyPVZList not implemented")
+}
+func (UnimplementedPVZServiceServer) GetPVZSchedule(context.Context, *GetPVZScheduleRequest) (*GetPVZScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPVZSchedule not implemented")
+}
 func (UnimplementedPVZServiceServer) mustEmbedUnimplementedPVZServiceServer() {}
 func (UnimplementedPVZServiceServer) testEmbeddedByValue()                    {}
 
@@ -104,6 +136,42 @@ func _PVZService_GetPVZList_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PVZService_GetNearbyPVZList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNearbyPVZListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetNearbyPVZList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetNearbyPVZList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetNearbyPVZList(ctx, req.(*GetNearbyPVZListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PVZService_GetPVZSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPVZScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PVZServiceServer).GetPVZSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PVZService_GetPVZSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PVZServiceServer).GetPVZSchedule(ctx, req.(*GetPVZScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PVZService_ServiceDesc is the grpc.ServiceDesc for PVZService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +183,14 @@ var PVZService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPVZList",
 			Handler:    _PVZService_GetPVZList_Handler,
 		},
+		{
+			MethodName: "GetNearbyPVZList",
+			Handler:    _PVZService_GetNearbyPVZList_Handler,
+		},
+		{
+			MethodName: "GetPVZSchedule",
+			Handler:    _PVZService_GetPVZSchedule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pvz.proto",
@@ -2,10 +2,16 @@ package server
 
 import (
 	"context"
+	"errors"
 
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"pvz/internal/delivery/forms"
 	Pvz "pvz/internal/grpc/pvz"
+	"pvz/internal/models"
 	"pvz/internal/usecase"
 )
 
@@ -28,14 +34,93 @@ func (pm *PvzManager) GetPVZList(ctx context.Context, _ *Pvz.GetPVZListRequest)
 
 	var resp []*Pvz.PVZ
 	for _, pvz := range pvzList {
-		resp = append(resp, &Pvz.PVZ{
-			Id:               pvz.Id.String(),
-			City:             pvz.City,
-			RegistrationDate: timestamppb.New(pvz.RegistrationDate),
-		})
+		resp = append(resp, toProtoPvz(pvz))
 	}
 
 	return &Pvz.GetPVZListResponse{
 		Pvzs: resp,
 	}, nil
 }
+
+func (pm *PvzManager) GetNearbyPVZList(ctx context.Context, req *Pvz.GetNearbyPVZListRequest) (*Pvz.GetNearbyPVZListResponse, error) {
+	nearbyList, err := pm.PvzService.GetNearbyPvz(ctx, forms.NearbyPvzForm{
+		Latitude:  req.GetLatitude(),
+		Longitude: req.GetLongitude(),
+		Radius:    req.GetRadius(),
+	})
+	if errors.Is(err, usecase.InvalidNearbySearch) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*Pvz.NearbyPVZ
+	for _, nearby := range nearbyList {
+		resp = append(resp, &Pvz.NearbyPVZ{
+			Pvz:      toProtoPvz(nearby.Pvz),
+			Distance: nearby.Distance,
+		})
+	}
+
+	return &Pvz.GetNearbyPVZListResponse{
+		Pvzs: resp,
+	}, nil
+}
+
+func (pm *PvzManager) GetPVZSchedule(ctx context.Context, req *Pvz.GetPVZScheduleRequest) (*Pvz.GetPVZScheduleResponse, error) {
+	pvzId, err := uuid.Parse(req.GetPvzId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pvz_id")
+	}
+
+	schedule, err := pm.PvzService.GetSchedule(ctx, pvzId)
+	if errors.Is(err, usecase.PvzNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Pvz.GetPVZScheduleResponse{
+		PvzId:      pvzId.String(),
+		Timezone:   schedule.Timezone,
+		AlwaysOpen: !schedule.IsConfigured(),
+	}
+	for _, hours := range schedule.Week {
+		resp.Week = append(resp.Week, &Pvz.WorkingHours{
+			Weekday:  int32(hours.Weekday),
+			OpensAt:  hours.OpensAt,
+			ClosesAt: hours.ClosesAt,
+		})
+	}
+	for _, exception := range schedule.Exceptions {
+		resp.Exceptions = append(resp.Exceptions, &Pvz.ScheduleException{
+			Date:        exception.Date.Format(models.DateLayout),
+			OpensAt:     exception.OpensAt,
+			ClosesAt:    exception.ClosesAt,
+			Description: exception.Description,
+		})
+	}
+
+	return resp, nil
+}
+
+func toProtoPvz(pvz models.Pvz) *Pvz.PVZ {
+	resp := &Pvz.PVZ{
+		Id:               pvz.Id.String(),
+		City:             pvz.City,
+		RegistrationDate: timestamppb.New(pvz.RegistrationDate),
+		Address:          pvz.Address,
+		OpeningHours:     pvz.OpeningHours,
+		Phone:            pvz.Phone,
+	}
+	if pvz.Location != nil {
+		resp.Location = &Pvz.Location{
+			Latitude:  pvz.Location.Latitude,
+			Longitude: pvz.Location.Longitude,
+		}
+	}
+
+	return resp
+}
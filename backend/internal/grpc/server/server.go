@@ -7,14 +7,22 @@ import (
 
 	"google.golang.org/grpc"
 
+	"pvz/config"
 	pvz "pvz/internal/grpc/pvz"
+	"pvz/internal/models"
 	"pvz/internal/repository"
 	"pvz/internal/usecase"
 	"pvz/pkg/logger"
 )
 
-func RunGrpcServer() error {
+func RunGrpcServer(cfg *config.Config) error {
 	ctx := context.Background()
+
+	policy, err := models.PolicyFromConfig(cfg.Roles)
+	if err != nil {
+		return fmt.Errorf("invalid role policy: %w", err)
+	}
+
 	lis, err := net.Listen("tcp", ":3000")
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("failed to listen: %v", err))
@@ -26,7 +34,7 @@ func RunGrpcServer() error {
 	newPvzRepo := repository.NewPostgresPvzRepository()
 	defer newPvzRepo.Close()
 
-	newPvzService := usecase.NewPvzService(newPvzRepo)
+	newPvzService := usecase.NewPvzService(newPvzRepo, policy)
 
 	pvz.RegisterPVZServiceServer(server, NewPvzManager(newPvzService))
 
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pvz/internal/models"
+	"pvz/pkg/logger"
+)
+
+// AutoCloseLockKey - ключ advisory-блокировки задачи автозакрытия приёмок
+const AutoCloseLockKey int64 = 240001
+
+type ReceptionUseCase interface {
+	CloseStaleReceptions(ctx context.Context, idleTimeout time.Duration) ([]models.Reception, error)
+}
+
+// NewAutoCloseJob закрывает приёмки, забытые открытыми дольше idleTimeout, чтобы они не мешали открыть новую
+func NewAutoCloseJob(receptionUC ReceptionUseCase, idleTimeout, interval time.Duration) Job {
+	return Job{
+		Name:     "auto-close-receptions",
+		LockKey:  AutoCloseLockKey,
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			closed, err := receptionUC.CloseStaleReceptions(ctx, idleTimeout)
+			if len(closed) > 0 {
+				logger.Info(ctx, fmt.Sprintf("Closed %d stale receptions", len(closed)))
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/jobs\auto-close-job.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReceptionUseCase is a mock of ReceptionUseCase interface.
+type MockReceptionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceptionUseCaseMockRecorder
+}
+
+// MockReceptionUseCaseMockRecorder is the mock recorder for MockReceptionUseCase.
+type MockReceptionUseCaseMockRecorder struct {
+	mock *MockReceptionUseCase
+}
+
+// NewMockReceptionUseCase creates a new mock instance.
+func NewMockReceptionUseCase(ctrl *gomock.Controller) *MockReceptionUseCase {
+	mock := &MockReceptionUseCase{ctrl: ctrl}
+	mock.recorder = &MockReceptionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceptionUseCase) EXPECT() *MockReceptionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CloseStaleReceptions mocks base method.
+func (m *MockReceptionUseCase) CloseStaleReceptions(ctx context.Context, idleTimeout time.Duration) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseStaleReceptions", ctx, idleTimeout)
+	ret0, _ := ret[0].([]models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseStaleReceptions indicates an expected call of CloseStaleReceptions.
+func (mr *MockReceptionUseCaseMockRecorder) CloseStaleReceptions(ctx, idleTimeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseStaleReceptions", reflect.TypeOf((*MockReceptionUseCase)(nil).CloseStaleReceptions), ctx, idleTimeout)
+}
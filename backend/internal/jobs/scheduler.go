@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+// Locker не даёт одной и той же задаче выполняться одновременно на нескольких репликах сервиса
+type Locker interface {
+	TryLock(ctx context.Context, key int64) (unlock func(), acquired bool, err error)
+}
+
+// Job - фоновая задача, которую планировщик запускает раз в Interval
+type Job struct {
+	Name string
+	// LockKey - ключ advisory-блокировки postgres, у каждой задачи свой
+	LockKey  int64
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	locker Locker
+	jobs   []Job
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(locker Locker, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		locker: locker,
+		jobs:   jobs,
+	}
+}
+
+// Start запускает каждую задачу в своей горутине. Задачи останавливаются после отмены ctx
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}()
+	}
+}
+
+// Wait ждёт завершения задач после отмены контекста
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	logger.Info(ctx, fmt.Sprintf("Job %s is scheduled every %s", job.Name, job.Interval))
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info(ctx, fmt.Sprintf("Job %s is stopped", job.Name))
+			return
+		case <-ticker.C:
+			_ = s.RunOnce(ctx, job)
+		}
+	}
+}
+
+// RunOnce выполняет задачу, если её блокировку не держит другая реплика. Каждый запуск получает свой requestID для логов
+func (s *Scheduler) RunOnce(ctx context.Context, job Job) error {
+	ctx = utils.SetRequestId(ctx)
+
+	unlock, acquired, err := s.locker.TryLock(ctx, job.LockKey)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Job %s is skipped: %v", job.Name, err))
+		return err
+	}
+
+	if !acquired {
+		logger.Info(ctx, fmt.Sprintf("Job %s is skipped: it is running on another replica", job.Name))
+		return nil
+	}
+	defer unlock()
+
+	start := time.Now()
+	if err = job.Run(ctx); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Job %s failed after %s: %v", job.Name, time.Since(start), err))
+		return err
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Job %s finished in %s", job.Name, time.Since(start)))
+	return nil
+}
@@ -0,0 +1,99 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/jobs"
+	"pvz/internal/jobs/mocks"
+	"pvz/internal/models"
+)
+
+func TestScheduler_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLocker := mocks.NewMockLocker(ctrl)
+	runs := 0
+	job := jobs.Job{
+		Name:     "test",
+		LockKey:  7,
+		Interval: time.Minute,
+		Run: func(ctx context.Context) error {
+			runs++
+			return nil
+		},
+	}
+	scheduler := jobs.NewScheduler(mockLocker, job)
+
+	unlocked := false
+	mockLocker.EXPECT().TryLock(gomock.Any(), int64(7)).Return(func() { unlocked = true }, true, nil)
+	assert.NoError(t, scheduler.RunOnce(context.Background(), job))
+	assert.Equal(t, 1, runs)
+	assert.True(t, unlocked)
+
+	mockLocker.EXPECT().TryLock(gomock.Any(), int64(7)).Return(nil, false, nil)
+	assert.NoError(t, scheduler.RunOnce(context.Background(), job))
+	assert.Equal(t, 1, runs, "job is running on another replica")
+
+	mockLocker.EXPECT().TryLock(gomock.Any(), int64(7)).Return(nil, false, errors.New("db error"))
+	assert.Error(t, scheduler.RunOnce(context.Background(), job))
+	assert.Equal(t, 1, runs)
+}
+
+func TestScheduler_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLocker := mocks.NewMockLocker(ctrl)
+	mockLocker.EXPECT().TryLock(gomock.Any(), gomock.Any()).Return(func() {}, true, nil).MinTimes(1)
+
+	ran := make(chan struct{}, 1)
+	job := jobs.Job{
+		Name:     "test",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := jobs.NewScheduler(mockLocker, job)
+	scheduler.Start(ctx)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("job was not run")
+	}
+
+	cancel()
+	scheduler.Wait()
+}
+
+func TestAutoCloseJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUC := mocks.NewMockReceptionUseCase(ctrl)
+	job := jobs.NewAutoCloseJob(mockUC, 12*time.Hour, 5*time.Minute)
+	assert.Equal(t, jobs.AutoCloseLockKey, job.LockKey)
+	assert.Equal(t, 5*time.Minute, job.Interval)
+
+	mockUC.EXPECT().CloseStaleReceptions(gomock.Any(), 12*time.Hour).
+		Return([]models.Reception{{Id: uuid.New(), AutoClosed: true}}, nil)
+	assert.NoError(t, job.Run(context.Background()))
+
+	mockUC.EXPECT().CloseStaleReceptions(gomock.Any(), 12*time.Hour).Return(nil, errors.New("db error"))
+	assert.Error(t, job.Run(context.Background()))
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditUserDeactivate    AuditAction = "user.deactivate"
+	AuditUserActivate      AuditAction = "user.activate"
+	AuditUserRoleChange    AuditAction = "user.role_change"
+	AuditUserPasswordReset AuditAction = "user.password_reset"
+	AuditReceptionReopen   AuditAction = "reception.reopen"
+	AuditReceptionCancel   AuditAction = "reception.cancel"
+	AuditReceptionVerify   AuditAction = "reception.verify"
+	// AuditReceptionScheduleOverride - приёмка открыта вне часов работы ПВЗ, её проверяет модератор
+	AuditReceptionScheduleOverride AuditAction = "reception.schedule_override"
+	// AuditReceptionAutoClose - простаивающую приёмку закрыл фоновый job
+	AuditReceptionAutoClose AuditAction = "reception.auto_close"
+)
+
+// SystemActorId - автор записей журнала, сделанных сервисом без участия пользователя
+const SystemActorId = "00000000-0000-0000-0000-000000000000"
+
+// AuditEntry - запись журнала административных действий: кто, что и над кем сделал
+type AuditEntry struct {
+	Id        uuid.UUID
+	ActorId   string
+	Action    AuditAction
+	TargetId  string
+	Details   map[string]string
+	CreatedAt time.Time
+}
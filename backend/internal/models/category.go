@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category - категория товаров из справочника. Code хранится в товаре как его тип и после создания не меняется
+type Category struct {
+	Id        uuid.UUID
+	Code      string
+	Names     map[string]string
+	Active    bool
+	ParentId  uuid.UUID
+	CreatedAt time.Time
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// City - город из справочника. В отключенном городе нельзя открывать новые ПВЗ
+type City struct {
+	Id        uuid.UUID
+	Name      string
+	Enabled   bool
+	CreatedAt time.Time
+}
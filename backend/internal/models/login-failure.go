@@ -0,0 +1,9 @@
+package models
+
+// LoginSubject - по чему считаются неудачные попытки входа: по email аккаунта или по IP клиента
+type LoginSubject string
+
+const (
+	LoginByEmail LoginSubject = "email"
+	LoginByIP    LoginSubject = "ip"
+)
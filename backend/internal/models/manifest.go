@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ManifestItem - товар, который должен прийти в приёмку
+type ManifestItem struct {
+	Barcode     string
+	ProductType string
+}
+
+// Manifest - ожидаемая поставка (ASN) для следующей приёмки ПВЗ. Пока приёмка не закрыта, ReceptionId пустой
+type Manifest struct {
+	Id          uuid.UUID
+	PvzId       uuid.UUID
+	ReceptionId uuid.UUID
+	CreatedAt   time.Time
+	Items       []ManifestItem
+}
+
+type DiscrepancyKind string
+
+const (
+	// Shortage - товар был в манифесте, но не пришёл
+	Shortage DiscrepancyKind = "shortage"
+	// Surplus - пришёл товар, которого нет в манифесте, или товар без штрихкода
+	Surplus DiscrepancyKind = "surplus"
+	// WrongCategory - товар пришёл, но принят с другим типом
+	WrongCategory DiscrepancyKind = "wrong_category"
+)
+
+// Discrepancy - расхождение приёмки с манифестом. ProductId пустой для недостачи
+type Discrepancy struct {
+	Kind         DiscrepancyKind
+	Barcode      string
+	ProductId    uuid.UUID
+	ExpectedType string
+	ActualType   string
+}
+
+// DiscrepancyReport - итог сверки приёмки с манифестом, пустой ManifestId означает, что манифеста не было
+type DiscrepancyReport struct {
+	ReceptionId uuid.UUID
+	ManifestId  uuid.UUID
+	Items       []Discrepancy
+}
+
+func (r DiscrepancyReport) Count(kind DiscrepancyKind) int {
+	count := 0
+	for _, item := range r.Items {
+		if item.Kind == kind {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Compare сверяет принятые товары с манифестом по штрихкодам. Недостачи идут в порядке манифеста,
+// излишки и пересортица - в порядке приёма товаров
+func (m Manifest) Compare(receptionId uuid.UUID, products []Product) DiscrepancyReport {
+	report := DiscrepancyReport{
+		ReceptionId: receptionId,
+		ManifestId:  m.Id,
+		Items:       []Discrepancy{},
+	}
+
+	expected := make(map[string]string, len(m.Items))
+	for _, item := range m.Items {
+		expected[item.Barcode] = item.ProductType
+	}
+
+	received := make(map[string]struct{}, len(products))
+	for _, product := range products {
+		if product.Barcode != "" {
+			received[product.Barcode] = struct{}{}
+		}
+	}
+
+	for _, item := range m.Items {
+		if _, ok := received[item.Barcode]; !ok {
+			report.Items = append(report.Items, Discrepancy{
+				Kind:         Shortage,
+				Barcode:      item.Barcode,
+				ExpectedType: item.ProductType,
+			})
+		}
+	}
+
+	for _, product := range products {
+		expectedType, ok := expected[product.Barcode]
+		switch {
+		case product.Barcode == "" || !ok:
+			report.Items = append(report.Items, Discrepancy{
+				Kind:       Surplus,
+				Barcode:    product.Barcode,
+				ProductId:  product.Id,
+				ActualType: product.ProductType,
+			})
+		case expectedType != product.ProductType:
+			report.Items = append(report.Items, Discrepancy{
+				Kind:         WrongCategory,
+				Barcode:      product.Barcode,
+				ProductId:    product.Id,
+				ExpectedType: expectedType,
+				ActualType:   product.ProductType,
+			})
+		}
+	}
+
+	return report
+}
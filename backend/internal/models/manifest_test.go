@@ -0,0 +1,49 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+)
+
+func TestManifest_Compare(t *testing.T) {
+	manifest := models.Manifest{
+		Id:    uuid.New(),
+		PvzId: uuid.New(),
+		Items: []models.ManifestItem{
+			{Barcode: "A-1", ProductType: "обувь"},
+			{Barcode: "A-2", ProductType: "одежда"},
+			{Barcode: "A-3", ProductType: "электроника"},
+		},
+	}
+	receptionId := uuid.New()
+
+	matched := models.Product{Id: uuid.New(), Barcode: "A-1", ProductType: "обувь"}
+	wrongType := models.Product{Id: uuid.New(), Barcode: "A-2", ProductType: "обувь"}
+	unexpected := models.Product{Id: uuid.New(), Barcode: "B-1", ProductType: "одежда"}
+	noBarcode := models.Product{Id: uuid.New(), ProductType: "электроника"}
+
+	report := manifest.Compare(receptionId, []models.Product{matched, wrongType, unexpected, noBarcode})
+
+	assert.Equal(t, receptionId, report.ReceptionId)
+	assert.Equal(t, manifest.Id, report.ManifestId)
+	assert.Equal(t, []models.Discrepancy{
+		{Kind: models.Shortage, Barcode: "A-3", ExpectedType: "электроника"},
+		{Kind: models.WrongCategory, Barcode: "A-2", ProductId: wrongType.Id, ExpectedType: "одежда", ActualType: "обувь"},
+		{Kind: models.Surplus, Barcode: "B-1", ProductId: unexpected.Id, ActualType: "одежда"},
+		{Kind: models.Surplus, ProductId: noBarcode.Id, ActualType: "электроника"},
+	}, report.Items)
+	assert.Equal(t, 1, report.Count(models.Shortage))
+	assert.Equal(t, 2, report.Count(models.Surplus))
+	assert.Equal(t, 1, report.Count(models.WrongCategory))
+
+	report = manifest.Compare(receptionId, []models.Product{
+		matched,
+		{Id: uuid.New(), Barcode: "A-2", ProductType: "одежда"},
+		{Id: uuid.New(), Barcode: "A-3", ProductType: "электроника"},
+	})
+	assert.Empty(t, report.Items)
+}
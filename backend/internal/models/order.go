@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderStatus string
+
+const (
+	OrderWaiting   OrderStatus = "waiting"
+	OrderIssued    OrderStatus = "issued"
+	OrderCancelled OrderStatus = "cancelled"
+)
+
+type Order struct {
+	Id        uuid.UUID
+	ClientId  string
+	PvzId     uuid.UUID
+	ProductId uuid.UUID
+	Status    OrderStatus
+	CreatedAt time.Time
+	IssuedAt  time.Time
+	IssuedBy  string
+}
+
+// PickupCode - одноразовый код выдачи заказа, в БД хранится только его хэш
+type PickupCode struct {
+	OrderId   uuid.UUID
+	Code      string
+	ExpiresAt time.Time
+}
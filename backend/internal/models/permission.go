@@ -0,0 +1,158 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Permission string
+
+const (
+	PvzCreate         Permission = "pvz:create"
+	PvzRead           Permission = "pvz:read"
+	PvzAssignEmployee Permission = "pvz:assign_employee"
+	PvzUpdate         Permission = "pvz:update"
+	PvzDecommission   Permission = "pvz:decommission"
+	PvzUtilization    Permission = "pvz:utilization"
+	CityManage        Permission = "city:manage"
+	CategoryManage    Permission = "category:manage"
+	ManifestManage    Permission = "manifest:manage"
+	ReceptionCreate   Permission = "reception:create"
+	ReceptionClose    Permission = "reception:close"
+	ReceptionReopen   Permission = "reception:reopen"
+	ReceptionCancel   Permission = "reception:cancel"
+	ReceptionVerify   Permission = "reception:verify"
+	ProductCreate     Permission = "product:create"
+	ProductDelete     Permission = "product:delete"
+	ProductIssue      Permission = "product:issue"
+	ProductReturn     Permission = "product:return"
+	ProductSendBack   Permission = "product:send_back"
+	OrderCreate       Permission = "order:create"
+	OrderIssue        Permission = "order:issue"
+	OrderReadOwn      Permission = "order:read_own"
+	UserUnlock        Permission = "user:unlock"
+	UserRead          Permission = "user:read"
+	UserManage        Permission = "user:manage"
+)
+
+var knownPermissions = map[Permission]struct{}{
+	PvzCreate:         {},
+	PvzRead:           {},
+	PvzAssignEmployee: {},
+	PvzUpdate:         {},
+	PvzDecommission:   {},
+	PvzUtilization:    {},
+	CityManage:        {},
+	CategoryManage:    {},
+	ManifestManage:    {},
+	ReceptionCreate:   {},
+	ReceptionClose:    {},
+	ReceptionReopen:   {},
+	ReceptionCancel:   {},
+	ReceptionVerify:   {},
+	ProductCreate:     {},
+	ProductDelete:     {},
+	ProductIssue:      {},
+	ProductReturn:     {},
+	ProductSendBack:   {},
+	OrderCreate:       {},
+	OrderIssue:        {},
+	OrderReadOwn:      {},
+	UserUnlock:        {},
+	UserRead:          {},
+	UserManage:        {},
+}
+
+// Policy сопоставляет ролям набор разрешений. Роль существует, только если она описана в политике
+type Policy struct {
+	roles map[string]map[Permission]struct{}
+}
+
+// NewPolicy строит политику из конфига вида роль -> список разрешений и отклоняет неизвестные разрешения
+func NewPolicy(rolePermissions map[string][]string) (*Policy, error) {
+	policy := &Policy{roles: make(map[string]map[Permission]struct{}, len(rolePermissions))}
+
+	for role, permissions := range rolePermissions {
+		policy.roles[role] = make(map[Permission]struct{}, len(permissions))
+		for _, permission := range permissions {
+			if _, ok := knownPermissions[Permission(permission)]; !ok {
+				return nil, fmt.Errorf("role %s: unknown permission %q", role, permission)
+			}
+			policy.roles[role][Permission(permission)] = struct{}{}
+		}
+	}
+
+	return policy, nil
+}
+
+// PolicyFromConfig строит политику из таблицы [roles] конфига, а если её нет - возвращает DefaultPolicy
+func PolicyFromConfig(rolePermissions map[string][]string) (*Policy, error) {
+	if len(rolePermissions) == 0 {
+		return DefaultPolicy(), nil
+	}
+
+	return NewPolicy(rolePermissions)
+}
+
+// DefaultPolicy используется, если в конфиге нет таблицы [roles]
+func DefaultPolicy() *Policy {
+	policy, _ := NewPolicy(map[string][]string{
+		string(Moderator): {
+			string(PvzCreate),
+			string(PvzRead),
+			string(PvzAssignEmployee),
+			string(PvzUpdate),
+			string(PvzDecommission),
+			string(PvzUtilization),
+			string(CityManage),
+			string(CategoryManage),
+			string(ManifestManage),
+			string(ReceptionReopen),
+			string(ReceptionVerify),
+			string(UserUnlock),
+			string(UserRead),
+			string(UserManage),
+		},
+		string(Employee): {
+			string(PvzRead),
+			string(ReceptionCreate),
+			string(ReceptionClose),
+			string(ReceptionCancel),
+			string(ProductCreate),
+			string(ProductDelete),
+			string(ProductIssue),
+			string(ProductReturn),
+			string(ProductSendBack),
+			string(OrderCreate),
+			string(OrderIssue),
+		},
+		string(Client): {
+			string(OrderReadOwn),
+		},
+	})
+
+	return policy
+}
+
+func (p *Policy) HasRole(role string) bool {
+	_, ok := p.roles[role]
+	return ok
+}
+
+func (p *Policy) HasPermission(role string, permission Permission) bool {
+	_, ok := p.roles[role][permission]
+	return ok
+}
+
+// RolesWith возвращает отсортированный список ролей, которым выдано разрешение
+func (p *Policy) RolesWith(permission Permission) []string {
+	var roles []string
+	for role := range p.roles {
+		if p.HasPermission(role, permission) {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+
+	return roles
+}
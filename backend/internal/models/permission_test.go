@@ -0,0 +1,58 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+)
+
+func TestNewPolicy(t *testing.T) {
+	policy, err := models.NewPolicy(map[string][]string{
+		"employee":        {"reception:create", "product:create"},
+		"senior_employee": {"reception:create", "reception:close"},
+		"auditor":         {"pvz:read"},
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, policy.HasRole("auditor"))
+	assert.False(t, policy.HasRole("moderator"))
+	assert.True(t, policy.HasPermission("senior_employee", models.ReceptionClose))
+	assert.False(t, policy.HasPermission("employee", models.ReceptionClose))
+	assert.False(t, policy.HasPermission("unknown", models.PvzRead))
+	assert.Equal(t, []string{"employee", "senior_employee"}, policy.RolesWith(models.ReceptionCreate))
+
+	_, err = models.NewPolicy(map[string][]string{"auditor": {"pvz:destroy"}})
+	assert.Error(t, err)
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	policy, err := models.PolicyFromConfig(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultPolicy(), policy, "no [roles] table falls back to the default policy")
+
+	policy, err = models.PolicyFromConfig(map[string][]string{"auditor": {"pvz:read"}})
+	assert.NoError(t, err)
+	assert.True(t, policy.HasRole("auditor"))
+	assert.False(t, policy.HasRole(string(models.Employee)), "the [roles] table replaces the default policy")
+
+	_, err = models.PolicyFromConfig(map[string][]string{"auditor": {"pvz:destroy"}})
+	assert.Error(t, err)
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := models.DefaultPolicy()
+
+	assert.True(t, policy.HasPermission(string(models.Moderator), models.PvzCreate))
+	assert.False(t, policy.HasPermission(string(models.Moderator), models.ReceptionCreate))
+	assert.True(t, policy.HasPermission(string(models.Moderator), models.ManifestManage))
+	assert.Equal(t, []string{string(models.Moderator)}, policy.RolesWith(models.ReceptionReopen))
+	assert.Equal(t, []string{string(models.Moderator)}, policy.RolesWith(models.ReceptionVerify))
+	assert.Equal(t, []string{string(models.Employee)}, policy.RolesWith(models.ReceptionCancel))
+	assert.True(t, policy.HasPermission(string(models.Employee), models.ProductDelete))
+	assert.True(t, policy.HasPermission(string(models.Employee), models.ProductSendBack))
+	assert.True(t, policy.HasPermission(string(models.Client), models.OrderReadOwn))
+	assert.False(t, policy.HasPermission(string(models.Client), models.OrderIssue))
+	assert.Equal(t, []string{string(models.Employee)}, policy.RolesWith(models.ReceptionCreate))
+}
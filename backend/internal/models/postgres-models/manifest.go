@@ -0,0 +1,27 @@
+package postgres_models
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+type PostgresDiscrepancy struct {
+	DiscrepancyKind         sql.NullString
+	DiscrepancyBarcode      sql.NullString
+	DiscrepancyProductId    uuid.UUID
+	DiscrepancyExpectedType sql.NullString
+	DiscrepancyActualType   sql.NullString
+}
+
+func ToDiscrepancy(d PostgresDiscrepancy) models.Discrepancy {
+	return models.Discrepancy{
+		Kind:         models.DiscrepancyKind(d.DiscrepancyKind.String),
+		Barcode:      d.DiscrepancyBarcode.String,
+		ProductId:    d.DiscrepancyProductId,
+		ExpectedType: d.DiscrepancyExpectedType.String,
+		ActualType:   d.DiscrepancyActualType.String,
+	}
+}
@@ -0,0 +1,34 @@
+package postgres_models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+type PostgresOrder struct {
+	OrderId        uuid.UUID
+	OrderClientId  string
+	OrderPvzId     uuid.UUID
+	OrderProductId uuid.UUID
+	OrderStatus    string
+	OrderCreatedAt time.Time
+	OrderIssuedAt  sql.NullTime
+	OrderIssuedBy  sql.NullString
+}
+
+func ToOrder(o PostgresOrder) models.Order {
+	return models.Order{
+		Id:        o.OrderId,
+		ClientId:  o.OrderClientId,
+		PvzId:     o.OrderPvzId,
+		ProductId: o.OrderProductId,
+		Status:    models.OrderStatus(o.OrderStatus),
+		CreatedAt: o.OrderCreatedAt,
+		IssuedAt:  o.OrderIssuedAt.Time,
+		IssuedBy:  o.OrderIssuedBy.String,
+	}
+}
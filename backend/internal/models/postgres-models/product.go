@@ -9,17 +9,31 @@ import (
 )
 
 type PostgresProduct struct {
-	ProductId          uuid.UUID
-	ProductReceivedAt  sql.NullTime
-	ProductType        sql.NullString
-	ProductReceptionId uuid.UUID
+	ProductId              uuid.UUID
+	ProductReceivedAt      sql.NullTime
+	ProductType            sql.NullString
+	ProductReceptionId     uuid.UUID
+	ProductOverCapacity    sql.NullBool
+	ProductBarcode         sql.NullString
+	ProductExternalOrderId sql.NullString
+	ProductStatus          sql.NullString
+	ProductIssuedAt        sql.NullTime
+	ProductReturnedAt      sql.NullTime
+	ProductSentBackAt      sql.NullTime
 }
 
 func ToProduct(p PostgresProduct) models.Product {
 	return models.Product{
-		Id:          p.ProductId,
-		DateTime:    p.ProductReceivedAt.Time,
-		ProductType: p.ProductType.String,
-		ReceptionId: p.ProductReceptionId,
+		Id:              p.ProductId,
+		DateTime:        p.ProductReceivedAt.Time,
+		ProductType:     p.ProductType.String,
+		ReceptionId:     p.ProductReceptionId,
+		Barcode:         p.ProductBarcode.String,
+		ExternalOrderId: p.ProductExternalOrderId.String,
+		OverCapacity:    p.ProductOverCapacity.Bool,
+		Status:          models.ProductStatus(p.ProductStatus.String),
+		IssuedAt:        p.ProductIssuedAt.Time,
+		ReturnedAt:      p.ProductReturnedAt.Time,
+		SentBackAt:      p.ProductSentBackAt.Time,
 	}
 }
@@ -12,12 +12,29 @@ type PostgresPvz struct {
 	PvzId               uuid.UUID
 	PvzRegistrationDate sql.NullTime
 	PvzCity             sql.NullString
+	PvzAddress          sql.NullString
+	PvzLatitude         sql.NullFloat64
+	PvzLongitude        sql.NullFloat64
+	PvzOpeningHours     sql.NullString
+	PvzPhone            sql.NullString
+	PvzCapacity         sql.NullInt64
+	PvzDecommissionedAt sql.NullTime
 }
 
 func ToPvz(p PostgresPvz) models.Pvz {
-	return models.Pvz{
+	pvz := models.Pvz{
 		Id:               p.PvzId,
 		RegistrationDate: p.PvzRegistrationDate.Time,
 		City:             p.PvzCity.String,
+		Address:          p.PvzAddress.String,
+		OpeningHours:     p.PvzOpeningHours.String,
+		Phone:            p.PvzPhone.String,
+		Capacity:         int(p.PvzCapacity.Int64),
+		DecommissionedAt: p.PvzDecommissionedAt.Time,
 	}
+	if p.PvzLatitude.Valid && p.PvzLongitude.Valid {
+		pvz.Location = &models.GeoPoint{Latitude: p.PvzLatitude.Float64, Longitude: p.PvzLongitude.Float64}
+	}
+
+	return pvz
 }
@@ -9,17 +9,21 @@ import (
 )
 
 type PostgresReception struct {
-	ReceptionId     uuid.UUID
-	ReceptionTime   sql.NullTime
-	ReceptionStatus sql.NullString
-	PvzId           uuid.UUID
+	ReceptionId              uuid.UUID
+	ReceptionTime            sql.NullTime
+	ReceptionStatus          sql.NullString
+	PvzId                    uuid.UUID
+	ReceptionOutsideSchedule sql.NullBool
+	ReceptionAutoClosed      sql.NullBool
 }
 
 func ToReception(p PostgresReception) models.Reception {
 	return models.Reception{
-		Id:       p.ReceptionId,
-		DateTime: p.ReceptionTime.Time,
-		PvzId:    p.PvzId,
-		Status:   models.Status(p.ReceptionStatus.String),
+		Id:              p.ReceptionId,
+		DateTime:        p.ReceptionTime.Time,
+		PvzId:           p.PvzId,
+		Status:          models.Status(p.ReceptionStatus.String),
+		OutsideSchedule: p.ReceptionOutsideSchedule.Bool,
+		AutoClosed:      p.ReceptionAutoClosed.Bool,
 	}
 }
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Principal описывает аутентифицированного пользователя, извлеченного из access-токена
+type Principal struct {
+	UserId    string
+	Email     string
+	Role      string
+	TokenId   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	// Dummy - токен выдан через /dummyLogin и не привязан к реальному пользователю
+	Dummy bool
+}
+
+func (p Principal) String() string {
+	return p.Role + ":" + p.UserId
+}
@@ -6,9 +6,40 @@ import (
 	"github.com/google/uuid"
 )
 
+// ProductStatus - этап жизни товара после приёмки
+type ProductStatus string
+
+const (
+	// ProductStored - товар лежит на складе ПВЗ и ждёт клиента
+	ProductStored ProductStatus = "stored"
+	ProductIssued ProductStatus = "issued"
+	// ProductReturned - клиент вернул товар, он снова на складе и ждёт отправки обратно
+	ProductReturned ProductStatus = "returned"
+	// ProductSentBack - невостребованный или возвращённый товар отправлен обратно на склад
+	ProductSentBack ProductStatus = "sent_back"
+)
+
 type Product struct {
 	Id          uuid.UUID
 	DateTime    time.Time
 	ProductType string
 	ReceptionId uuid.UUID
+	// Barcode - штрихкод или артикул товара, в пределах одной приёмки не повторяется
+	Barcode string
+	// ExternalOrderId - номер заказа во внешней системе, несколько коробок одного заказа имеют общий номер
+	ExternalOrderId string
+	// OverCapacity - товар принят сверх вместимости ПВЗ
+	OverCapacity bool
+	Status       ProductStatus
+	IssuedAt     time.Time
+	ReturnedAt   time.Time
+	SentBackAt   time.Time
+}
+
+// ProductLocation - где и в какой приёмке был принят товар
+type ProductLocation struct {
+	Product         Product
+	PvzId           uuid.UUID
+	City            string
+	ReceptionStatus Status
 }
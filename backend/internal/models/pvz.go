@@ -18,6 +18,51 @@ type Pvz struct {
 	Id               uuid.UUID
 	RegistrationDate time.Time
 	City             string
+	Address          string
+	// Location - координаты ПВЗ, nil если они не указаны
+	Location     *GeoPoint
+	OpeningHours string
+	Phone        string
+	// Capacity - вместимость в товарах, 0 означает отсутствие ограничения
+	Capacity int
+	// DecommissionedAt - момент вывода ПВЗ из эксплуатации, нулевое значение у действующих ПВЗ
+	DecommissionedAt time.Time
+}
+
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// NearbyPvz - ПВЗ с расстоянием до точки поиска в метрах
+type NearbyPvz struct {
+	Pvz      Pvz
+	Distance float64
+}
+
+// PvzLoad - загрузка ПВЗ: сколько товаров сейчас лежит на складе
+type PvzLoad struct {
+	Pvz    Pvz
+	OnHand int
+}
+
+func (l PvzLoad) Utilization() float64 {
+	if l.Pvz.Capacity == 0 {
+		return 0
+	}
+
+	return float64(l.OnHand) / float64(l.Pvz.Capacity)
+}
+
+// ExceedsCapacity проверяет, переполнится ли ПВЗ после приёма ещё count товаров
+func (l PvzLoad) ExceedsCapacity(count int) bool {
+	return l.Pvz.Capacity > 0 && l.OnHand+count > l.Pvz.Capacity
+}
+
+type PvzEmployee struct {
+	UserId     string
+	PvzId      uuid.UUID
+	AssignedAt time.Time
 }
 
 type PvzInfo struct {
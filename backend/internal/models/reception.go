@@ -11,16 +11,45 @@ type Status string
 const (
 	InProgress Status = "in_progress"
 	Closed     Status = "close"
+	// Cancelled - приёмка открыта по ошибке, её товары не числятся на складе
+	Cancelled Status = "cancelled"
+	// Verified - модератор проверил закрытую приёмку
+	Verified Status = "verified"
 )
 
+// HoldsStock - товары закрытой или проверенной приёмки приняты окончательно и лежат на складе
+func (s Status) HoldsStock() bool {
+	return s == Closed || s == Verified
+}
+
 type Reception struct {
 	Id       uuid.UUID
 	DateTime time.Time
 	PvzId    uuid.UUID
 	Status   Status
+	// OutsideSchedule - приёмка открыта вне часов работы ПВЗ и ждёт проверки модератором
+	OutsideSchedule bool
+	// AutoClosed - приёмка закрыта фоновой задачей после долгого простоя, а не сотрудником
+	AutoClosed bool
+}
+
+// ReceptionFilter - фильтр истории приёмок ПВЗ, пустой Status означает любой статус
+type ReceptionFilter struct {
+	PvzId     uuid.UUID
+	Status    Status
+	StartDate time.Time
+	EndDate   time.Time
+	Page      int
+	Limit     int
 }
 
 type ReceptionProducts struct {
 	Reception Reception
 	Products  []Product
 }
+
+// ReceptionHistory - приёмка и записи журнала о смене её статуса
+type ReceptionHistory struct {
+	Reception Reception
+	Events    []AuditEntry
+}
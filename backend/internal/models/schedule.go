@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ClockLayout - формат времени открытия и закрытия, "24:00" означает конец суток
+	ClockLayout = "15:04"
+	DateLayout  = "2006-01-02"
+)
+
+// WorkingHours - часы работы в день недели по ISO (1 - понедельник, 7 - воскресенье)
+type WorkingHours struct {
+	Weekday  int
+	OpensAt  string
+	ClosesAt string
+}
+
+// ScheduleException - праздник или сокращённый день. Пустые часы работы означают, что ПВЗ закрыт весь день
+type ScheduleException struct {
+	Date        time.Time
+	OpensAt     string
+	ClosesAt    string
+	Description string
+}
+
+func (e ScheduleException) IsClosed() bool {
+	return e.OpensAt == ""
+}
+
+// PvzSchedule - недельное расписание ПВЗ и исключения из него, время указано в часовом поясе Timezone.
+// Нулевой PvzId означает, что расписание не задано и ПВЗ работает круглосуточно
+type PvzSchedule struct {
+	PvzId      uuid.UUID
+	Timezone   string
+	Week       []WorkingHours
+	Exceptions []ScheduleException
+}
+
+func (s PvzSchedule) IsConfigured() bool {
+	return s.PvzId != uuid.Nil
+}
+
+// ParseClock переводит время HH:MM в минуты от начала суток, "24:00" - конец суток
+func ParseClock(clock string) (int, error) {
+	if clock == "24:00" {
+		return 24 * 60, nil
+	}
+
+	parsed, err := time.Parse(ClockLayout, clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %q: %v", clock, err)
+	}
+
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// IsOpenAt проверяет, работает ли ПВЗ в момент t. Исключение на дату важнее недельного расписания.
+// Если время закрытия раньше времени открытия, это ночная смена: она заканчивается на следующий день
+func (s PvzSchedule) IsOpenAt(t time.Time) (bool, error) {
+	if !s.IsConfigured() {
+		return true, nil
+	}
+
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false, fmt.Errorf("unknown timezone %s: %v", s.Timezone, err)
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+
+	opensAt, closesAt, isWorking, err := s.hoursOn(local)
+	if err != nil {
+		return false, err
+	}
+
+	if isWorking && opensAt <= minute && (minute < closesAt || closesAt < opensAt) {
+		return true, nil
+	}
+
+	// ночная смена предыдущего дня ещё не закончилась
+	opensAt, closesAt, isWorking, err = s.hoursOn(local.AddDate(0, 0, -1))
+	if err != nil {
+		return false, err
+	}
+
+	return isWorking && closesAt < opensAt && minute < closesAt, nil
+}
+
+// hoursOn возвращает часы работы в день day в минутах от начала суток, false означает выходной
+func (s PvzSchedule) hoursOn(day time.Time) (int, int, bool, error) {
+	opensAt, closesAt, isWorking := "", "", false
+
+	isException := false
+	for _, exception := range s.Exceptions {
+		if exception.Date.Format(DateLayout) == day.Format(DateLayout) {
+			opensAt, closesAt, isWorking = exception.OpensAt, exception.ClosesAt, !exception.IsClosed()
+			isException = true
+			break
+		}
+	}
+
+	if !isException {
+		weekday := int(day.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+
+		for _, hours := range s.Week {
+			if hours.Weekday == weekday {
+				opensAt, closesAt, isWorking = hours.OpensAt, hours.ClosesAt, true
+				break
+			}
+		}
+	}
+
+	if !isWorking {
+		return 0, 0, false, nil
+	}
+
+	opens, err := ParseClock(opensAt)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	closes, err := ParseClock(closesAt)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return opens, closes, true, nil
+}
@@ -0,0 +1,79 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+)
+
+func TestPvzSchedule_IsOpenAt(t *testing.T) {
+	schedule := models.PvzSchedule{
+		PvzId:    uuid.New(),
+		Timezone: "Europe/Moscow",
+		Week: []models.WorkingHours{
+			{Weekday: 1, OpensAt: "09:00", ClosesAt: "21:00"},
+			{Weekday: 5, OpensAt: "22:00", ClosesAt: "06:00"},
+			{Weekday: 7, OpensAt: "10:00", ClosesAt: "24:00"},
+		},
+		Exceptions: []models.ScheduleException{
+			{Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Description: "Новогодние каникулы"},
+			{Date: time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), OpensAt: "12:00", ClosesAt: "15:00"},
+			{Date: time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC), Description: "Выходная пятница"},
+			{Date: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), Description: "Выходная суббота"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		// время в UTC, Москва на 3 часа впереди
+		{"monday in working hours", time.Date(2026, 1, 19, 6, 0, 0, 0, time.UTC), true},
+		{"monday before opening", time.Date(2026, 1, 19, 5, 59, 0, 0, time.UTC), false},
+		{"monday at closing time", time.Date(2026, 1, 19, 18, 0, 0, 0, time.UTC), false},
+		{"sunday late evening", time.Date(2026, 1, 18, 20, 59, 0, 0, time.UTC), true},
+		{"tuesday is a day off", time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC), false},
+		{"holiday on monday", time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC), false},
+		{"shortened monday", time.Date(2026, 1, 12, 10, 0, 0, 0, time.UTC), true},
+		{"shortened monday after closing", time.Date(2026, 1, 12, 13, 0, 0, 0, time.UTC), false},
+		{"friday night shift", time.Date(2026, 1, 23, 20, 0, 0, 0, time.UTC), true},
+		{"friday before night shift", time.Date(2026, 1, 23, 18, 59, 0, 0, time.UTC), false},
+		{"night shift continues on saturday", time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC), true},
+		{"night shift is over on saturday", time.Date(2026, 1, 24, 3, 0, 0, 0, time.UTC), false},
+		{"no night shift after friday holiday", time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC), false},
+		{"night shift continues into saturday holiday", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isOpen, err := schedule.IsOpenAt(tt.at)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, isOpen)
+		})
+	}
+
+	isOpen, err := models.PvzSchedule{}.IsOpenAt(time.Now())
+	assert.NoError(t, err)
+	assert.True(t, isOpen, "pvz without schedule works around the clock")
+
+	_, err = models.PvzSchedule{PvzId: uuid.New(), Timezone: "Mars/Olympus"}.IsOpenAt(time.Now())
+	assert.Error(t, err)
+}
+
+func TestParseClock(t *testing.T) {
+	minutes, err := models.ParseClock("09:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 9*60+30, minutes)
+
+	minutes, err = models.ParseClock("24:00")
+	assert.NoError(t, err)
+	assert.Equal(t, 24*60, minutes)
+
+	_, err = models.ParseClock("25:00")
+	assert.Error(t, err)
+}
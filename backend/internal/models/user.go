@@ -1,11 +1,27 @@
 package models
 
+type UserStatus string
+
+const (
+	UserActive      UserStatus = "active"
+	UserDeactivated UserStatus = "deactivated"
+)
+
 type User struct {
 	Email    string
 	Password string
 	Salt     string
 	Role     string
 	Id       string
+	Status   UserStatus
+}
+
+// UserFilter - фильтр списка пользователей, пустые Role и Status не ограничивают выборку
+type UserFilter struct {
+	Role   string
+	Status UserStatus
+	Page   int
+	Limit  int
 }
 
 type LoginData struct {
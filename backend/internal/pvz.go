@@ -11,9 +11,11 @@ import (
 	"pvz/config"
 	"pvz/internal/delivery/handlers"
 	"pvz/internal/delivery/middleware"
+	"pvz/internal/jobs"
 	"pvz/internal/models"
 	"pvz/internal/repository"
 	"pvz/internal/usecase"
+	"pvz/internal/utils"
 	"pvz/pkg/logger"
 )
 
@@ -24,46 +26,146 @@ func Run(cfg *config.Config) error {
 
 	ctx := context.Background()
 
+	policy, err := models.PolicyFromConfig(cfg.Roles)
+	if err != nil {
+		return fmt.Errorf("invalid role policy: %w", err)
+	}
+
+	if keysDir := utils.GetEnv("JWT_KEYS_DIR", ""); keysDir != "" {
+		tokenKeys, err := utils.LoadTokenKeys(keysDir, utils.GetEnv("JWT_ACTIVE_KEY_ID", ""))
+		if err != nil {
+			return fmt.Errorf("unable to load token keys: %w", err)
+		}
+		utils.SetTokenKeys(tokenKeys)
+		logger.Info(ctx, fmt.Sprintf("Loaded token keys from %s", keysDir))
+	}
+
 	newUserRepo := repository.NewPostgresUserRepository()
 	newPvzRepo := repository.NewPostgresPvzRepository()
 	newReceptionRepo := repository.NewPostgresReceptionRepository()
-
-	newAuthService := usecase.NewAuthService(newUserRepo)
-	newPvzService := usecase.NewPvzService(newPvzRepo)
-	newReceptionService := usecase.NewReceptionService(newReceptionRepo)
-
-	newAuthHandler := handlers.NewAuthHandler(newAuthService)
+	newTokenRepo := repository.NewPostgresTokenRepository()
+	newOrderRepo := repository.NewPostgresOrderRepository()
+	newProductRepo := repository.NewPostgresProductRepository()
+	newLoginFailureRepo := repository.NewPostgresLoginFailureRepository()
+	newCityRepo := repository.NewPostgresCityRepository()
+	newCategoryRepo := repository.NewPostgresCategoryRepository()
+	newJobLockRepo := repository.NewPostgresJobLockRepository()
+
+	newAuthService := usecase.NewAuthService(newUserRepo, newTokenRepo, newLoginFailureRepo, utils.NewPasswordHasher())
+	newPvzAccessChecker := usecase.NewPvzAccessChecker(newPvzRepo, cfg.Mode == config.ModeTest)
+	newPvzService := usecase.NewPvzService(newPvzRepo, policy)
+	newReceptionService := usecase.NewReceptionService(newReceptionRepo, newPvzAccessChecker, cfg.CapacityPolicy)
+	newOrderService := usecase.NewOrderService(newOrderRepo, newPvzAccessChecker)
+	newProductService := usecase.NewProductService(newProductRepo, newPvzAccessChecker, cfg.StoragePeriod)
+	newUserService := usecase.NewUserService(newUserRepo, utils.NewPasswordHasher())
+	newCityService := usecase.NewCityService(newCityRepo)
+	newCategoryService := usecase.NewCategoryService(newCategoryRepo)
+
+	newAuthHandler := handlers.NewAuthHandler(newAuthService, policy)
 	newPvzHandler := handlers.NewPvzHandler(newPvzService)
 	newReceptionHandler := handlers.NewReceptionHandler(newReceptionService)
+	newOrderHandler := handlers.NewOrderHandler(newOrderService)
+	newProductHandler := handlers.NewProductHandler(newProductService)
+	newUserHandler := handlers.NewUserHandler(newUserService, policy)
+	newCityHandler := handlers.NewCityHandler(newCityService)
+	newCategoryHandler := handlers.NewCategoryHandler(newCategoryService)
 
 	defer newUserRepo.Close()
 	defer newPvzRepo.Close()
 	defer newReceptionRepo.Close()
+	defer newTokenRepo.Close()
+	defer newOrderRepo.Close()
+	defer newProductRepo.Close()
+	defer newLoginFailureRepo.Close()
+	defer newCityRepo.Close()
+	defer newCategoryRepo.Close()
+	defer newJobLockRepo.Close()
+
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	scheduler := jobs.NewScheduler(newJobLockRepo,
+		jobs.NewAutoCloseJob(newReceptionService, cfg.ReceptionIdleTimeout, cfg.AutoCloseInterval),
+	)
+	scheduler.Start(jobsCtx)
+	defer scheduler.Wait()
+	defer stopJobs()
 
 	r := mux.NewRouter()
 
 	r.Use(middleware.RequestIDMiddleware)
-	r.HandleFunc("/dummyLogin", newAuthHandler.DummyLogin).Methods("POST")
+	if cfg.Mode != config.ModeProd {
+		r.HandleFunc("/dummyLogin", newAuthHandler.DummyLogin).Methods("POST")
+	}
 	r.HandleFunc("/register", newAuthHandler.Register).Methods("POST")
 	r.HandleFunc("/login", newAuthHandler.Login).Methods("POST")
+	r.HandleFunc("/token/refresh", newAuthHandler.RefreshToken).Methods("POST")
+	r.HandleFunc("/.well-known/jwks.json", newAuthHandler.GetJWKS).Methods("GET")
+
+	// endpoints for any authorized user, access to the rest is granted by role permissions
+	authorized := r.PathPrefix("/").Subrouter()
+	authorized.Use(middleware.AuthMiddleware(newAuthService))
+	if cfg.Mode == config.ModeProd {
+		// dummy-токены, выпущенные другим окружением с тем же ключом, в prod не принимаются
+		authorized.Use(middleware.RealUserMiddleware)
+	}
+	authorized.HandleFunc("/logout", newAuthHandler.Logout).Methods("POST")
+
+	permit := func(permission models.Permission, handler http.HandlerFunc) http.Handler {
+		return middleware.PermissionMiddleware(policy, permission)(handler)
+	}
+	// изменения данных и операции, завязанные на реального пользователя; в test режиме dummy-токены допускаются для сквозных тестов
+	userBound := func(permission models.Permission, handler http.HandlerFunc) http.Handler {
+		if cfg.Mode == config.ModeTest {
+			return permit(permission, handler)
+		}
+		return middleware.RealUserMiddleware(permit(permission, handler))
+	}
 
-	// endpoints for moderators only
-	protectedModer := r.PathPrefix("/").Subrouter()
-	protectedModer.Use(middleware.RoleMiddleware(models.Moderator))
-	protectedModer.HandleFunc("/pvz", newPvzHandler.CreatePvz).Methods("POST")
-
-	// endpoints for moderators and employees
-	protectedModerEmp := r.PathPrefix("/").Subrouter()
-	protectedModerEmp.Use(middleware.RoleMiddleware(models.Moderator, models.Employee))
-	protectedModerEmp.HandleFunc("/pvz", newPvzHandler.GetPvzInfo).Methods("GET")
-
-	//endpoints for employees only
-	protectedEmp := r.PathPrefix("/").Subrouter()
-	protectedEmp.Use(middleware.RoleMiddleware(models.Employee))
-	protectedEmp.HandleFunc("/receptions", newReceptionHandler.CreateReception).Methods("POST")
-	protectedEmp.HandleFunc("/products", newReceptionHandler.AddProduct).Methods("POST")
-	protectedEmp.HandleFunc("/pvz/{pvzId:[0-9a-fA-F-]{36}}/delete_last_product", newReceptionHandler.RemoveProduct).Methods("POST")
-	protectedEmp.HandleFunc("/pvz/{pvzId:[0-9a-fA-F-]{36}}/close_last_reception", newReceptionHandler.CloseReception).Methods("POST")
+	authorized.Handle("/users", userBound(models.UserRead, newUserHandler.ListUsers)).Methods("GET")
+	authorized.Handle("/users/{userId:[0-9a-fA-F-]{36}}/deactivate", userBound(models.UserManage, newUserHandler.DeactivateUser)).Methods("POST")
+	authorized.Handle("/users/{userId:[0-9a-fA-F-]{36}}/activate", userBound(models.UserManage, newUserHandler.ActivateUser)).Methods("POST")
+	authorized.Handle("/users/{userId:[0-9a-fA-F-]{36}}/role", userBound(models.UserManage, newUserHandler.ChangeRole)).Methods("PUT")
+	authorized.Handle("/users/{userId:[0-9a-fA-F-]{36}}/reset_password", userBound(models.UserManage, newUserHandler.ResetPassword)).Methods("POST")
+	authorized.Handle("/users/{userId:[0-9a-fA-F-]{36}}/unlock", userBound(models.UserUnlock, newAuthHandler.UnlockUser)).Methods("POST")
+	authorized.Handle("/cities", permit(models.PvzRead, newCityHandler.ListCities)).Methods("GET")
+	authorized.Handle("/cities", userBound(models.CityManage, newCityHandler.CreateCity)).Methods("POST")
+	authorized.Handle("/cities/{cityId:[0-9a-fA-F-]{36}}", userBound(models.CityManage, newCityHandler.UpdateCity)).Methods("PATCH")
+	authorized.Handle("/categories", permit(models.PvzRead, newCategoryHandler.ListCategories)).Methods("GET")
+	authorized.Handle("/categories", userBound(models.CategoryManage, newCategoryHandler.CreateCategory)).Methods("POST")
+	authorized.Handle("/categories/{categoryId:[0-9a-fA-F-]{36}}", userBound(models.CategoryManage, newCategoryHandler.UpdateCategory)).Methods("PATCH")
+	authorized.Handle("/pvz", userBound(models.PvzCreate, newPvzHandler.CreatePvz)).Methods("POST")
+	authorized.Handle("/pvz", permit(models.PvzRead, newPvzHandler.GetPvzInfo)).Methods("GET")
+	authorized.Handle("/pvz/nearby", permit(models.PvzRead, newPvzHandler.GetNearbyPvz)).Methods("GET")
+	authorized.Handle("/pvz/utilization", permit(models.PvzUtilization, newPvzHandler.GetUtilization)).Methods("GET")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}", permit(models.PvzRead, newPvzHandler.GetPvz)).Methods("GET")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}", userBound(models.PvzUpdate, newPvzHandler.UpdatePvz)).Methods("PATCH")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}", userBound(models.PvzDecommission, newPvzHandler.DecommissionPvz)).Methods("DELETE")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/schedule", permit(models.PvzRead, newPvzHandler.GetSchedule)).Methods("GET")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/schedule", userBound(models.PvzUpdate, newPvzHandler.UpdateSchedule)).Methods("PUT")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/employees", userBound(models.PvzAssignEmployee, newPvzHandler.AssignEmployee)).Methods("POST")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/employees/{userId:[0-9a-fA-F-]{36}}", userBound(models.PvzAssignEmployee, newPvzHandler.UnassignEmployee)).Methods("DELETE")
+	authorized.Handle("/receptions", userBound(models.ReceptionCreate, newReceptionHandler.CreateReception)).Methods("POST")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}", permit(models.PvzRead, newReceptionHandler.GetReception)).Methods("GET")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/reopen", userBound(models.ReceptionReopen, newReceptionHandler.ReopenReception)).Methods("POST")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/cancel", userBound(models.ReceptionCancel, newReceptionHandler.CancelReception)).Methods("POST")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/verify", userBound(models.ReceptionVerify, newReceptionHandler.VerifyReception)).Methods("POST")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/history", permit(models.PvzRead, newReceptionHandler.GetReceptionHistory)).Methods("GET")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/discrepancies", permit(models.PvzRead, newReceptionHandler.GetDiscrepancyReport)).Methods("GET")
+	authorized.Handle("/receptions/{receptionId:[0-9a-fA-F-]{36}}/products/{productId:[0-9a-fA-F-]{36}}", userBound(models.ProductDelete, newReceptionHandler.RemoveProductById)).Methods("DELETE")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/receptions", permit(models.PvzRead, newReceptionHandler.ListReceptions)).Methods("GET")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/manifest", userBound(models.ManifestManage, newReceptionHandler.ReplaceManifest)).Methods("PUT")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/receptions/current", permit(models.PvzRead, newReceptionHandler.GetCurrentReception)).Methods("GET")
+	authorized.Handle("/products", userBound(models.ProductCreate, newReceptionHandler.AddProduct)).Methods("POST")
+	authorized.Handle("/products/batch", userBound(models.ProductCreate, newReceptionHandler.AddProducts)).Methods("POST")
+	authorized.Handle("/products/search", permit(models.PvzRead, newReceptionHandler.SearchProducts)).Methods("GET")
+	authorized.Handle("/products/{productId:[0-9a-fA-F-]{36}}/issue", userBound(models.ProductIssue, newProductHandler.IssueProduct)).Methods("POST")
+	authorized.Handle("/products/{productId:[0-9a-fA-F-]{36}}/return", userBound(models.ProductReturn, newProductHandler.ReturnProduct)).Methods("POST")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/send_back", userBound(models.ProductSendBack, newProductHandler.SendBackProducts)).Methods("POST")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/delete_last_product", userBound(models.ProductDelete, newReceptionHandler.RemoveProduct)).Methods("POST")
+	authorized.Handle("/pvz/{pvzId:[0-9a-fA-F-]{36}}/close_last_reception", userBound(models.ReceptionClose, newReceptionHandler.CloseReception)).Methods("POST")
+	authorized.Handle("/orders", userBound(models.OrderCreate, newOrderHandler.CreateOrder)).Methods("POST")
+	authorized.Handle("/orders/my", userBound(models.OrderReadOwn, newOrderHandler.GetMyOrders)).Methods("GET")
+	authorized.Handle("/orders/{orderId:[0-9a-fA-F-]{36}}/pickup_code", userBound(models.OrderReadOwn, newOrderHandler.CreatePickupCode)).Methods("POST")
+	authorized.Handle("/orders/issue", userBound(models.OrderIssue, newOrderHandler.IssueOrder)).Methods("POST")
 
 	server := http.Server{
 		Addr:         cfg.Addr,
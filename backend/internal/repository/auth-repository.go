@@ -26,7 +26,15 @@ const (
 	`
 
 	GetUserQuery = `
-		select id, email, password, salt, role from "user" where email = $1
+		select id, email, password, salt, role, status from "user" where email = $1
+	`
+
+	GetUserByIdQuery = `
+		select id, email, password, salt, role, status from "user" where id = $1
+	`
+
+	UpdatePasswordQuery = `
+		update "user" set password = $2, salt = $3 where id = $1
 	`
 )
 
@@ -90,6 +98,7 @@ func (p *PostgresUserRepository) GetUserByEmail(ctx context.Context, logInData m
 		&user.Password,
 		&user.Salt,
 		&user.Role,
+		&user.Status,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -103,3 +112,39 @@ func (p *PostgresUserRepository) GetUserByEmail(ctx context.Context, logInData m
 	logger.Info(ctx, fmt.Sprintf("Successfully got info about user with email: %s", logInData.Email))
 	return user, nil
 }
+
+func (p *PostgresUserRepository) GetUserById(ctx context.Context, userId string) (models.User, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to get user by id: %s", userId))
+
+	var user models.User
+	err := p.Db.QueryRowContext(ctx, GetUserByIdQuery, userId).Scan(&user.Id,
+		&user.Email,
+		&user.Password,
+		&user.Salt,
+		&user.Role,
+		&user.Status,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("User with id: %s does not exist", userId))
+			return models.User{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to get user info: %v", err))
+		return models.User{}, errors.New("unable to get user info")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully got info about user with id: %s", userId))
+	return user, nil
+}
+
+func (p *PostgresUserRepository) UpdatePassword(ctx context.Context, userId, password, salt string) error {
+	logger.Info(ctx, fmt.Sprintf("Trying to update password of user: %s", userId))
+
+	_, err := p.Db.ExecContext(ctx, UpdatePasswordQuery, userId, password, salt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error updating password: %s", err.Error()))
+		return fmt.Errorf("unable to update password: %v", err)
+	}
+
+	return nil
+}
@@ -29,6 +29,7 @@ func TestCreateUser(t *testing.T) {
 		Password: "superMegaHashUnrealNoWayReally?HashedPassword",
 		Salt:     "saltySalt",
 		Role:     string(models.Client),
+		Status:   models.UserActive,
 	}
 
 	tests := []struct {
@@ -154,6 +155,7 @@ func TestGetUserByEmail(t *testing.T) {
 		Password: "superMegaHashUnrealNoWayReally?HashedPassword",
 		Salt:     "saltySalt",
 		Role:     string(models.Client),
+		Status:   models.UserActive,
 	}
 
 	tests := []struct {
@@ -165,9 +167,9 @@ func TestGetUserByEmail(t *testing.T) {
 		{
 			name: "user found",
 			setupMock: func() {
-				rows := sqlmock.NewRows([]string{"id", "email", "password", "salt", "role"}).
-					AddRow(user.Id, user.Email, user.Password, user.Salt, user.Role)
-				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, password, salt, role from "user" where email = $1`)).
+				rows := sqlmock.NewRows([]string{"id", "email", "password", "salt", "role", "status"}).
+					AddRow(user.Id, user.Email, user.Password, user.Salt, user.Role, user.Status)
+				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, password, salt, role, status from "user" where email = $1`)).
 					WithArgs(email).
 					WillReturnRows(rows)
 			},
@@ -177,7 +179,7 @@ func TestGetUserByEmail(t *testing.T) {
 		{
 			name: "user not found",
 			setupMock: func() {
-				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, password, salt, role from "user" where email = $1`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, password, salt, role, status from "user" where email = $1`)).
 					WithArgs(email).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -187,7 +189,7 @@ func TestGetUserByEmail(t *testing.T) {
 		{
 			name: "db error",
 			setupMock: func() {
-				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, password, salt, role from "user" where email = $1`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`select id, email, password, salt, role, status from "user" where email = $1`)).
 					WithArgs(email).
 					WillReturnError(errors.New("db error"))
 			},
@@ -210,3 +212,92 @@ func TestGetUserByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUserById(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresUserRepository{Db: db}
+	user := models.User{
+		Id:       uuid.New().String(),
+		Email:    "abobus@mail.ru",
+		Password: "superMegaHashUnrealNoWayReally?HashedPassword",
+		Salt:     "saltySalt",
+		Role:     string(models.Employee),
+		Status:   models.UserDeactivated,
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    models.User
+		expectedErr bool
+	}{
+		{
+			name: "user found",
+			setupMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "email", "password", "salt", "role", "status"}).
+					AddRow(user.Id, user.Email, user.Password, user.Salt, user.Role, user.Status)
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetUserByIdQuery)).
+					WithArgs(user.Id).
+					WillReturnRows(rows)
+			},
+			expected:    user,
+			expectedErr: false,
+		},
+		{
+			name: "user not found",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetUserByIdQuery)).
+					WithArgs(user.Id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expected:    models.User{},
+			expectedErr: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetUserByIdQuery)).
+					WithArgs(user.Id).
+					WillReturnError(errors.New("db error"))
+			},
+			expected:    models.User{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			result, err := repo.GetUserById(context.Background(), user.Id)
+			assert.Equal(t, tt.expected, result)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresUserRepository{Db: db}
+	userId := uuid.NewString()
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.UpdatePasswordQuery)).
+		WithArgs(userId, "$argon2id$hash", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UpdatePassword(context.Background(), userId, "$argon2id$hash", ""))
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.UpdatePasswordQuery)).
+		WithArgs(userId, "$argon2id$hash", "").
+		WillReturnError(errors.New("db error"))
+	assert.Error(t, repo.UpdatePassword(context.Background(), userId, "$argon2id$hash", ""))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"pvz/config/postgres"
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/pkg/logger"
+)
+
+const (
+	ListCategoriesQuery = `
+		select id, code, names, active, parent_id, created_at
+		from category
+		order by code
+	`
+
+	GetCategoryQuery = `
+		select id, code, names, active, parent_id, created_at
+		from category
+		where id = $1
+	`
+
+	CreateCategoryQuery = `
+		insert into category (id, code, names, active, parent_id, created_at)
+		values ($1, $2, $3, $4, $5, $6)
+		on conflict (code) do nothing
+	`
+
+	// $4 показывает, передан ли parent_id: null в $5 означает перенос категории в корень
+	UpdateCategoryQuery = `
+		update category set
+			names = coalesce($2::jsonb, names),
+			active = coalesce($3, active),
+			parent_id = case when $4 then $5 else parent_id end
+		where id = $1
+		returning id, code, names, active, parent_id, created_at
+	`
+)
+
+type PostgresCategoryRepository struct {
+	Db *sql.DB
+}
+
+func NewPostgresCategoryRepository() *PostgresCategoryRepository {
+	db, err := sql.Open("pgx", postgres.NewPostgresConfig().GetURL())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	return &PostgresCategoryRepository{Db: db}
+}
+
+func (p *PostgresCategoryRepository) Close() {
+	p.Db.Close()
+}
+
+type categoryScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCategory(row categoryScanner) (models.Category, error) {
+	var (
+		category models.Category
+		names    []byte
+		parentId uuid.NullUUID
+	)
+
+	if err := row.Scan(&category.Id, &category.Code, &names, &category.Active, &parentId, &category.CreatedAt); err != nil {
+		return models.Category{}, err
+	}
+
+	if err := json.Unmarshal(names, &category.Names); err != nil {
+		return models.Category{}, fmt.Errorf("invalid category names: %v", err)
+	}
+	category.ParentId = parentId.UUID
+
+	return category, nil
+}
+
+func (p *PostgresCategoryRepository) ListCategories(ctx context.Context) ([]models.Category, error) {
+	logger.Info(ctx, "Trying to list categories")
+
+	rows, err := p.Db.QueryContext(ctx, ListCategoriesQuery)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error listing categories: %s", err.Error()))
+		return nil, fmt.Errorf("unable to list categories: %v", err)
+	}
+	defer rows.Close()
+
+	categories := []models.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+func (p *PostgresCategoryRepository) GetCategory(ctx context.Context, categoryId uuid.UUID) (models.Category, error) {
+	category, err := scanCategory(p.Db.QueryRowContext(ctx, GetCategoryQuery, categoryId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Category{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("Error getting category %s: %s", categoryId, err.Error()))
+		return models.Category{}, fmt.Errorf("unable to get category: %v", err)
+	}
+
+	return category, nil
+}
+
+// CreateCategory возвращает false, если категория с таким кодом уже есть
+func (p *PostgresCategoryRepository) CreateCategory(ctx context.Context, category models.Category) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to create category %s", category.Code))
+
+	names, err := json.Marshal(category.Names)
+	if err != nil {
+		return false, fmt.Errorf("unable to encode category names: %v", err)
+	}
+
+	commandTag, err := p.Db.ExecContext(ctx, CreateCategoryQuery, category.Id, category.Code, names, category.Active,
+		uuid.NullUUID{UUID: category.ParentId, Valid: category.ParentId != uuid.Nil}, category.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return false, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error creating category: %s", err.Error()))
+		return false, fmt.Errorf("unable to create category: %v", err)
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+func (p *PostgresCategoryRepository) UpdateCategory(ctx context.Context, categoryId uuid.UUID, form forms.UpdateCategoryForm) (models.Category, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to update category %s", categoryId))
+
+	var names sql.NullString
+	if form.Names != nil {
+		encoded, err := json.Marshal(form.Names)
+		if err != nil {
+			return models.Category{}, fmt.Errorf("unable to encode category names: %v", err)
+		}
+		names = sql.NullString{String: string(encoded), Valid: true}
+	}
+	var active sql.NullBool
+	if form.Active != nil {
+		active = sql.NullBool{Bool: *form.Active, Valid: true}
+	}
+	var parentId uuid.NullUUID
+	if form.ParentId != nil {
+		parentId = uuid.NullUUID{UUID: *form.ParentId, Valid: *form.ParentId != uuid.Nil}
+	}
+
+	category, err := scanCategory(p.Db.QueryRowContext(ctx, UpdateCategoryQuery, categoryId, names, active, form.ParentId != nil, parentId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("Category %s does not exist", categoryId))
+			return models.Category{}, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return models.Category{}, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error updating category: %s", err.Error()))
+		return models.Category{}, fmt.Errorf("unable to update category: %v", err)
+	}
+
+	return category, nil
+}
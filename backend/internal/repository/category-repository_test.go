@@ -0,0 +1,143 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+var categoryColumns = []string{"id", "code", "names", "active", "parent_id", "created_at"}
+
+func TestListCategories(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCategoryRepository{Db: db}
+	createdAt := time.Now().Truncate(time.Millisecond)
+	parent := models.Category{Id: uuid.New(), Code: "электроника", Names: map[string]string{"ru": "Электроника"}, Active: true, CreatedAt: createdAt}
+	child := models.Category{Id: uuid.New(), Code: "телефоны", Names: map[string]string{"ru": "Телефоны"}, ParentId: parent.Id, CreatedAt: createdAt}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListCategoriesQuery)).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).
+			AddRow(parent.Id, parent.Code, []byte(`{"ru": "Электроника"}`), parent.Active, nil, createdAt).
+			AddRow(child.Id, child.Code, []byte(`{"ru": "Телефоны"}`), child.Active, parent.Id, createdAt))
+	categories, err := repo.ListCategories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Category{parent, child}, categories)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListCategoriesQuery)).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).
+			AddRow(parent.Id, parent.Code, []byte(`not json`), parent.Active, nil, createdAt))
+	_, err = repo.ListCategories(context.Background())
+	assert.Error(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListCategoriesQuery)).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.ListCategories(context.Background())
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCategory(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCategoryRepository{Db: db}
+	categoryId := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetCategoryQuery)).
+		WithArgs(categoryId).
+		WillReturnError(sql.ErrNoRows)
+	category, err := repo.GetCategory(context.Background(), categoryId)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Category{}, category)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetCategoryQuery)).
+		WithArgs(categoryId).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.GetCategory(context.Background(), categoryId)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateCategory(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCategoryRepository{Db: db}
+	category := models.Category{
+		Id:        uuid.New(),
+		Code:      "книги",
+		Names:     map[string]string{"ru": "Книги"},
+		Active:    true,
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateCategoryQuery)).
+		WithArgs(category.Id, category.Code, []byte(`{"ru":"Книги"}`), true, uuid.NullUUID{}, category.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	isCreated, err := repo.CreateCategory(context.Background(), category)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+
+	category.ParentId = uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateCategoryQuery)).
+		WithArgs(category.Id, category.Code, []byte(`{"ru":"Книги"}`), true, uuid.NullUUID{UUID: category.ParentId, Valid: true}, category.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	isCreated, err = repo.CreateCategory(context.Background(), category)
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateCategoryQuery)).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.CreateCategory(context.Background(), category)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateCategory(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCategoryRepository{Db: db}
+	categoryId := uuid.New()
+	createdAt := time.Now().Truncate(time.Millisecond)
+	active := false
+	root := uuid.Nil
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateCategoryQuery)).
+		WithArgs(categoryId, sql.NullString{}, sql.NullBool{Bool: false, Valid: true}, true, uuid.NullUUID{}).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(categoryId, "обувь", []byte(`{"ru": "Обувь"}`), false, nil, createdAt))
+	category, err := repo.UpdateCategory(context.Background(), categoryId, forms.UpdateCategoryForm{Active: &active, ParentId: &root})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Category{Id: categoryId, Code: "обувь", Names: map[string]string{"ru": "Обувь"}, CreatedAt: createdAt}, category)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateCategoryQuery)).
+		WithArgs(categoryId, sql.NullString{String: `{"ru":"Обувь"}`, Valid: true}, sql.NullBool{}, false, uuid.NullUUID{}).
+		WillReturnError(sql.ErrNoRows)
+	category, err = repo.UpdateCategory(context.Background(), categoryId, forms.UpdateCategoryForm{Names: map[string]string{"ru": "Обувь"}})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Category{}, category)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateCategoryQuery)).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.UpdateCategory(context.Background(), categoryId, forms.UpdateCategoryForm{})
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
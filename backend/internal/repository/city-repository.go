@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"pvz/config/postgres"
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/pkg/logger"
+)
+
+const (
+	ListCitiesQuery = `
+		select id, name, enabled, created_at
+		from city
+		order by name
+	`
+
+	GetCityByNameQuery = `
+		select id, name, enabled, created_at
+		from city
+		where name = $1
+	`
+
+	CreateCityQuery = `
+		insert into city (id, name, enabled, created_at)
+		values ($1, $2, $3, $4)
+		on conflict (name) do nothing
+	`
+
+	// переименование каскадно обновляет город у всех ПВЗ
+	UpdateCityQuery = `
+		update city set name = coalesce($2, name), enabled = coalesce($3, enabled)
+		where id = $1
+		returning id, name, enabled, created_at
+	`
+)
+
+type PostgresCityRepository struct {
+	Db *sql.DB
+}
+
+func NewPostgresCityRepository() *PostgresCityRepository {
+	db, err := sql.Open("pgx", postgres.NewPostgresConfig().GetURL())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	return &PostgresCityRepository{Db: db}
+}
+
+func (p *PostgresCityRepository) Close() {
+	p.Db.Close()
+}
+
+func (p *PostgresCityRepository) ListCities(ctx context.Context) ([]models.City, error) {
+	logger.Info(ctx, "Trying to list cities")
+
+	rows, err := p.Db.QueryContext(ctx, ListCitiesQuery)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error listing cities: %s", err.Error()))
+		return nil, fmt.Errorf("unable to list cities: %v", err)
+	}
+	defer rows.Close()
+
+	cities := []models.City{}
+	for rows.Next() {
+		var city models.City
+		if err = rows.Scan(&city.Id, &city.Name, &city.Enabled, &city.CreatedAt); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
+			return nil, err
+		}
+		cities = append(cities, city)
+	}
+
+	return cities, nil
+}
+
+func (p *PostgresCityRepository) GetCityByName(ctx context.Context, name string) (models.City, error) {
+	var city models.City
+	err := p.Db.QueryRowContext(ctx, GetCityByNameQuery, name).Scan(&city.Id, &city.Name, &city.Enabled, &city.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.City{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("Error getting city %s: %s", name, err.Error()))
+		return models.City{}, fmt.Errorf("unable to get city: %v", err)
+	}
+
+	return city, nil
+}
+
+// CreateCity возвращает false, если город с таким названием уже есть
+func (p *PostgresCityRepository) CreateCity(ctx context.Context, city models.City) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to create city %s", city.Name))
+
+	commandTag, err := p.Db.ExecContext(ctx, CreateCityQuery, city.Id, city.Name, city.Enabled, city.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return false, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error creating city: %s", err.Error()))
+		return false, fmt.Errorf("unable to create city: %v", err)
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+func (p *PostgresCityRepository) UpdateCity(ctx context.Context, cityId uuid.UUID, form forms.UpdateCityForm) (models.City, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to update city %s", cityId))
+
+	var name sql.NullString
+	if form.Name != nil {
+		name = sql.NullString{String: *form.Name, Valid: true}
+	}
+	var enabled sql.NullBool
+	if form.Enabled != nil {
+		enabled = sql.NullBool{Bool: *form.Enabled, Valid: true}
+	}
+
+	var city models.City
+	err := p.Db.QueryRowContext(ctx, UpdateCityQuery, cityId, name, enabled).
+		Scan(&city.Id, &city.Name, &city.Enabled, &city.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("City %s does not exist", cityId))
+			return models.City{}, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return models.City{}, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error updating city: %s", err.Error()))
+		return models.City{}, fmt.Errorf("unable to update city: %v", err)
+	}
+
+	return city, nil
+}
@@ -0,0 +1,127 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+var cityColumns = []string{"id", "name", "enabled", "created_at"}
+
+func TestListCities(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCityRepository{Db: db}
+	city := models.City{Id: uuid.New(), Name: "Казань", Enabled: true, CreatedAt: time.Now().Truncate(time.Millisecond)}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListCitiesQuery)).
+		WillReturnRows(sqlmock.NewRows(cityColumns).AddRow(city.Id, city.Name, city.Enabled, city.CreatedAt))
+	cities, err := repo.ListCities(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.City{city}, cities)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListCitiesQuery)).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.ListCities(context.Background())
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCityByName(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCityRepository{Db: db}
+	city := models.City{Id: uuid.New(), Name: "Казань", Enabled: false, CreatedAt: time.Now().Truncate(time.Millisecond)}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetCityByNameQuery)).
+		WithArgs(city.Name).
+		WillReturnRows(sqlmock.NewRows(cityColumns).AddRow(city.Id, city.Name, city.Enabled, city.CreatedAt))
+	got, err := repo.GetCityByName(context.Background(), city.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, city, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetCityByNameQuery)).
+		WithArgs(city.Name).
+		WillReturnError(sql.ErrNoRows)
+	got, err = repo.GetCityByName(context.Background(), city.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, models.City{}, got)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateCity(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCityRepository{Db: db}
+	city := models.City{Id: uuid.New(), Name: "Томск", Enabled: true, CreatedAt: time.Now().Truncate(time.Millisecond)}
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateCityQuery)).
+		WithArgs(city.Id, city.Name, city.Enabled, city.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	isCreated, err := repo.CreateCity(context.Background(), city)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateCityQuery)).
+		WithArgs(city.Id, city.Name, city.Enabled, city.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	isCreated, err = repo.CreateCity(context.Background(), city)
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateCityQuery)).
+		WithArgs(city.Id, city.Name, city.Enabled, city.CreatedAt).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.CreateCity(context.Background(), city)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateCity(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresCityRepository{Db: db}
+	city := models.City{Id: uuid.New(), Name: "Санкт-Петербург", Enabled: false, CreatedAt: time.Now().Truncate(time.Millisecond)}
+	enabled := false
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateCityQuery)).
+		WithArgs(city.Id, sql.NullString{}, sql.NullBool{Bool: false, Valid: true}).
+		WillReturnRows(sqlmock.NewRows(cityColumns).AddRow(city.Id, city.Name, city.Enabled, city.CreatedAt))
+	got, err := repo.UpdateCity(context.Background(), city.Id, forms.UpdateCityForm{Enabled: &enabled})
+	assert.NoError(t, err)
+	assert.Equal(t, city, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateCityQuery)).
+		WithArgs(city.Id, sql.NullString{String: city.Name, Valid: true}, sql.NullBool{}).
+		WillReturnError(sql.ErrNoRows)
+	got, err = repo.UpdateCity(context.Background(), city.Id, forms.UpdateCityForm{Name: &city.Name})
+	assert.NoError(t, err)
+	assert.Equal(t, models.City{}, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateCityQuery)).
+		WithArgs(city.Id, sql.NullString{}, sql.NullBool{}).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.UpdateCity(context.Background(), city.Id, forms.UpdateCityForm{})
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
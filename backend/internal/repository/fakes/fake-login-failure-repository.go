@@ -0,0 +1,62 @@
+package fakes
+
+import (
+	"context"
+	"time"
+
+	"pvz/internal/models"
+)
+
+type fakeLoginFailure struct {
+	failures      int
+	lastFailureAt time.Time
+	lockedUntil   time.Time
+}
+
+type FakeLoginFailureRepository struct {
+	failures map[string]fakeLoginFailure
+}
+
+func NewFakeLoginFailureRepository() *FakeLoginFailureRepository {
+	return &FakeLoginFailureRepository{
+		failures: make(map[string]fakeLoginFailure),
+	}
+}
+
+func (f *FakeLoginFailureRepository) GetLoginLock(ctx context.Context, kind models.LoginSubject, subject string, now time.Time) (time.Time, error) {
+	failure := f.failures[string(kind)+":"+subject]
+	if failure.lockedUntil.After(now) {
+		return failure.lockedUntil, nil
+	}
+
+	return time.Time{}, nil
+}
+
+func (f *FakeLoginFailureRepository) RegisterLoginFailure(ctx context.Context, kind models.LoginSubject, subject string, now time.Time, window time.Duration) (int, error) {
+	key := string(kind) + ":" + subject
+
+	failure := f.failures[key]
+	if failure.lastFailureAt.Before(now.Add(-window)) {
+		failure.failures = 0
+	}
+	failure.failures++
+	failure.lastFailureAt = now
+	f.failures[key] = failure
+
+	return failure.failures, nil
+}
+
+func (f *FakeLoginFailureRepository) LockLogin(ctx context.Context, kind models.LoginSubject, subject string, until time.Time) error {
+	key := string(kind) + ":" + subject
+
+	failure := f.failures[key]
+	failure.lockedUntil = until
+	f.failures[key] = failure
+
+	return nil
+}
+
+func (f *FakeLoginFailureRepository) ResetLoginFailures(ctx context.Context, kind models.LoginSubject, subject string) error {
+	delete(f.failures, string(kind)+":"+subject)
+	return nil
+}
@@ -3,6 +3,7 @@ package fakes
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,10 +13,15 @@ import (
 
 type FakePvzRepository struct {
 	fakeDB map[uuid.UUID]models.Pvz
+	// fakeEmployeesDB - закреплённые сотрудники по id ПВЗ
+	fakeEmployeesDB map[uuid.UUID]map[string]bool
 }
 
 func NewPostgresPvzRepository() *FakePvzRepository {
-	return &FakePvzRepository{fakeDB: make(map[uuid.UUID]models.Pvz)}
+	return &FakePvzRepository{
+		fakeDB:          make(map[uuid.UUID]models.Pvz),
+		fakeEmployeesDB: make(map[uuid.UUID]map[string]bool),
+	}
 }
 
 func (p *FakePvzRepository) CreatePvz(ctx context.Context, pvzData models.Pvz) error {
@@ -34,3 +40,94 @@ func (p *FakePvzRepository) GetPvzInfo(ctx context.Context, form forms.GetPvzInf
 func (p *FakePvzRepository) GetPvzList(ctx context.Context) ([]models.Pvz, error) {
 	return []models.Pvz{}, nil
 }
+
+func (p *FakePvzRepository) AssignEmployee(ctx context.Context, employee models.PvzEmployee, roles []string) (models.PvzEmployee, error) {
+	if _, ok := p.fakeDB[employee.PvzId]; !ok {
+		return models.PvzEmployee{}, nil
+	}
+
+	if p.fakeEmployeesDB[employee.PvzId] == nil {
+		p.fakeEmployeesDB[employee.PvzId] = make(map[string]bool)
+	}
+	p.fakeEmployeesDB[employee.PvzId][employee.UserId] = true
+
+	return employee, nil
+}
+
+func (p *FakePvzRepository) UnassignEmployee(ctx context.Context, userId string, pvzId uuid.UUID) (bool, error) {
+	if !p.fakeEmployeesDB[pvzId][userId] {
+		return false, nil
+	}
+
+	delete(p.fakeEmployeesDB[pvzId], userId)
+	return true, nil
+}
+
+func (p *FakePvzRepository) IsEmployeeAssigned(ctx context.Context, userId string, pvzId uuid.UUID) (bool, error) {
+	return p.fakeEmployeesDB[pvzId][userId], nil
+}
+
+func (p *FakePvzRepository) IsCityAvailable(ctx context.Context, city string) (bool, error) {
+	switch city {
+	case "Москва", "Санкт-Петербург", "Казань":
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (p *FakePvzRepository) GetPvz(ctx context.Context, pvzId uuid.UUID) (models.Pvz, error) {
+	return p.fakeDB[pvzId], nil
+}
+
+func (p *FakePvzRepository) UpdatePvz(ctx context.Context, pvzId uuid.UUID, form forms.UpdatePvzForm) (models.Pvz, error) {
+	pvz, ok := p.fakeDB[pvzId]
+	if !ok {
+		return models.Pvz{}, nil
+	}
+
+	if form.City != nil {
+		pvz.City = *form.City
+	}
+	if form.Address != nil {
+		pvz.Address = *form.Address
+	}
+	if form.Location != nil {
+		pvz.Location = form.Location.ToGeoPoint()
+	}
+	if form.OpeningHours != nil {
+		pvz.OpeningHours = *form.OpeningHours
+	}
+	if form.Phone != nil {
+		pvz.Phone = *form.Phone
+	}
+	p.fakeDB[pvzId] = pvz
+	return pvz, nil
+}
+
+func (p *FakePvzRepository) DecommissionPvz(ctx context.Context, pvzId uuid.UUID, decommissionedAt time.Time) (bool, error) {
+	pvz, ok := p.fakeDB[pvzId]
+	if !ok || !pvz.DecommissionedAt.IsZero() {
+		return false, nil
+	}
+
+	pvz.DecommissionedAt = decommissionedAt
+	p.fakeDB[pvzId] = pvz
+	return true, nil
+}
+
+func (p *FakePvzRepository) GetNearbyPvz(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error) {
+	return []models.NearbyPvz{}, nil
+}
+
+func (p *FakePvzRepository) GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	return models.PvzSchedule{}, nil
+}
+
+func (p *FakePvzRepository) ReplacePvzSchedule(ctx context.Context, schedule models.PvzSchedule) error {
+	return nil
+}
+
+func (p *FakePvzRepository) GetOverloadedPvz(ctx context.Context, threshold float64) ([]models.PvzLoad, error) {
+	return nil, nil
+}
@@ -3,6 +3,7 @@ package fakes
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,12 +13,22 @@ import (
 type FakeReceptionRepository struct {
 	fakeDB         map[uuid.UUID]models.Reception
 	fakeProductsDB map[uuid.UUID]models.Product
+	// fakeManifestsDB - манифесты, ждущие приёмку, по id ПВЗ
+	fakeManifestsDB map[uuid.UUID]models.Manifest
+	// fakeBoundManifestsDB - манифесты, привязанные к приёмке, по id приёмки
+	fakeBoundManifestsDB map[uuid.UUID]models.Manifest
+	fakeReportsDB        map[uuid.UUID]models.DiscrepancyReport
+	fakeHistoryDB        map[uuid.UUID][]models.AuditEntry
 }
 
 func NewFakeReceptionRepository() *FakeReceptionRepository {
 	return &FakeReceptionRepository{
-		fakeDB:         make(map[uuid.UUID]models.Reception),
-		fakeProductsDB: make(map[uuid.UUID]models.Product),
+		fakeDB:               make(map[uuid.UUID]models.Reception),
+		fakeProductsDB:       make(map[uuid.UUID]models.Product),
+		fakeManifestsDB:      make(map[uuid.UUID]models.Manifest),
+		fakeBoundManifestsDB: make(map[uuid.UUID]models.Manifest),
+		fakeReportsDB:        make(map[uuid.UUID]models.DiscrepancyReport),
+		fakeHistoryDB:        make(map[uuid.UUID][]models.AuditEntry),
 	}
 }
 
@@ -30,6 +41,15 @@ func (p *FakeReceptionRepository) CreateReception(ctx context.Context, reception
 	return nil
 }
 
+func (p *FakeReceptionRepository) CreateReceptionOutsideSchedule(ctx context.Context, reception models.Reception, entry models.AuditEntry) error {
+	if err := p.CreateReception(ctx, reception); err != nil {
+		return err
+	}
+
+	p.fakeHistoryDB[reception.Id] = append(p.fakeHistoryDB[reception.Id], entry)
+	return nil
+}
+
 func (p *FakeReceptionRepository) GetOpenReception(ctx context.Context, pvzId uuid.UUID) (models.Reception, error) {
 	for _, reception := range p.fakeDB {
 		if reception.PvzId == pvzId && reception.Status == models.InProgress {
@@ -45,13 +65,225 @@ func (p *FakeReceptionRepository) AddProduct(ctx context.Context, product models
 	return nil
 }
 
+func (p *FakeReceptionRepository) AddProducts(ctx context.Context, products []models.Product) error {
+	for _, product := range products {
+		p.fakeProductsDB[product.Id] = product
+	}
+	return nil
+}
+
 func (p *FakeReceptionRepository) RemoveProduct(ctx context.Context, receptionId uuid.UUID) error {
 	return nil
 }
 
-func (p *FakeReceptionRepository) CloseReception(ctx context.Context, receptionData models.Reception) error {
+func (p *FakeReceptionRepository) DeleteProduct(ctx context.Context, receptionId uuid.UUID, productId uuid.UUID) (bool, error) {
+	product, ok := p.fakeProductsDB[productId]
+	if !ok || product.ReceptionId != receptionId {
+		return false, nil
+	}
+
+	delete(p.fakeProductsDB, productId)
+	return true, nil
+}
+
+func (p *FakeReceptionRepository) CloseReception(ctx context.Context, receptionData models.Reception) (bool, error) {
+	if p.fakeDB[receptionData.Id].Status != models.InProgress {
+		return false, nil
+	}
+
 	receptionData.Status = models.Closed
 	p.fakeDB[receptionData.Id] = receptionData
 
-	return nil
+	return true, nil
+}
+
+func (p *FakeReceptionRepository) IsCategoryActive(ctx context.Context, code string) (bool, error) {
+	switch code {
+	case "электроника", "одежда", "обувь":
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (p *FakeReceptionRepository) GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	return models.PvzSchedule{}, nil
+}
+
+func (p *FakeReceptionRepository) GetPvzLoad(ctx context.Context, pvzId uuid.UUID) (models.PvzLoad, error) {
+	return models.PvzLoad{}, nil
+}
+
+func (p *FakeReceptionRepository) ListReceptions(ctx context.Context, filter models.ReceptionFilter) ([]models.Reception, error) {
+	receptions := make([]models.Reception, 0)
+	for _, reception := range p.fakeDB {
+		if reception.PvzId == filter.PvzId && (filter.Status == "" || reception.Status == filter.Status) {
+			receptions = append(receptions, reception)
+		}
+	}
+
+	return receptions, nil
+}
+
+func (p *FakeReceptionRepository) ListStaleReceptions(ctx context.Context, idleSince time.Time) ([]models.Reception, error) {
+	receptions := make([]models.Reception, 0)
+	for _, reception := range p.fakeDB {
+		if reception.Status != models.InProgress || !reception.DateTime.Before(idleSince) {
+			continue
+		}
+
+		isActive := false
+		for _, product := range p.fakeProductsDB {
+			if product.ReceptionId == reception.Id && !product.DateTime.Before(idleSince) {
+				isActive = true
+			}
+		}
+		for _, entry := range p.fakeHistoryDB[reception.Id] {
+			if entry.Action == models.AuditReceptionReopen && !entry.CreatedAt.Before(idleSince) {
+				isActive = true
+			}
+		}
+
+		if !isActive {
+			receptions = append(receptions, reception)
+		}
+	}
+
+	return receptions, nil
+}
+
+func (p *FakeReceptionRepository) GetReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error) {
+	reception, ok := p.fakeDB[receptionId]
+	if !ok {
+		return models.ReceptionProducts{}, nil
+	}
+
+	result := models.ReceptionProducts{Reception: reception, Products: []models.Product{}}
+	for _, product := range p.fakeProductsDB {
+		if product.ReceptionId == receptionId {
+			result.Products = append(result.Products, product)
+		}
+	}
+
+	return result, nil
+}
+
+func (p *FakeReceptionRepository) GetReceivedBarcodes(ctx context.Context, receptionId uuid.UUID, barcodes []string) ([]string, error) {
+	received := make([]string, 0)
+	for _, product := range p.fakeProductsDB {
+		if product.ReceptionId != receptionId || product.Barcode == "" {
+			continue
+		}
+		for _, barcode := range barcodes {
+			if product.Barcode == barcode {
+				received = append(received, barcode)
+			}
+		}
+	}
+
+	return received, nil
+}
+
+func (p *FakeReceptionRepository) SearchProducts(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	locations := make([]models.ProductLocation, 0)
+	for _, product := range p.fakeProductsDB {
+		if product.Barcode != barcode {
+			continue
+		}
+
+		reception := p.fakeDB[product.ReceptionId]
+		locations = append(locations, models.ProductLocation{
+			Product:         product,
+			PvzId:           reception.PvzId,
+			ReceptionStatus: reception.Status,
+		})
+	}
+
+	return locations, nil
+}
+
+func (p *FakeReceptionRepository) ReplaceManifest(ctx context.Context, manifest models.Manifest) (bool, error) {
+	p.fakeManifestsDB[manifest.PvzId] = manifest
+	return true, nil
+}
+
+func (p *FakeReceptionRepository) GetReceptionManifest(ctx context.Context, reception models.Reception) (models.Manifest, error) {
+	if manifest, ok := p.fakeBoundManifestsDB[reception.Id]; ok {
+		return manifest, nil
+	}
+
+	return p.fakeManifestsDB[reception.PvzId], nil
+}
+
+func (p *FakeReceptionRepository) CloseReceptionWithReport(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport) (bool, error) {
+	if p.fakeDB[receptionData.Id].Status != models.InProgress {
+		return false, nil
+	}
+
+	receptionData.Status = models.Closed
+	p.fakeDB[receptionData.Id] = receptionData
+	if _, ok := p.fakeBoundManifestsDB[receptionData.Id]; !ok {
+		p.fakeBoundManifestsDB[receptionData.Id] = p.fakeManifestsDB[receptionData.PvzId]
+		delete(p.fakeManifestsDB, receptionData.PvzId)
+	}
+	p.fakeReportsDB[receptionData.Id] = report
+
+	return true, nil
+}
+
+func (p *FakeReceptionRepository) AutoCloseReception(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport, entry models.AuditEntry) (bool, error) {
+	var isClosed bool
+	if report.ManifestId == uuid.Nil {
+		isClosed, _ = p.CloseReception(ctx, receptionData)
+	} else {
+		isClosed, _ = p.CloseReceptionWithReport(ctx, receptionData, report)
+	}
+	if !isClosed {
+		return false, nil
+	}
+
+	p.fakeHistoryDB[receptionData.Id] = append(p.fakeHistoryDB[receptionData.Id], entry)
+	return true, nil
+}
+
+func (p *FakeReceptionRepository) GetDiscrepancyReport(ctx context.Context, receptionId uuid.UUID) (models.DiscrepancyReport, error) {
+	return p.fakeReportsDB[receptionId], nil
+}
+
+func (p *FakeReceptionRepository) ReopenReception(ctx context.Context, receptionId uuid.UUID, entry models.AuditEntry) (bool, error) {
+	reception, ok := p.fakeDB[receptionId]
+	if !ok || reception.Status != models.Closed {
+		return false, nil
+	}
+
+	for _, other := range p.fakeDB {
+		if other.PvzId == reception.PvzId && other.Status == models.InProgress {
+			return false, nil
+		}
+	}
+
+	reception.Status = models.InProgress
+	reception.AutoClosed = false
+	p.fakeDB[receptionId] = reception
+	delete(p.fakeReportsDB, receptionId)
+	p.fakeHistoryDB[receptionId] = append(p.fakeHistoryDB[receptionId], entry)
+
+	return true, nil
+}
+
+func (p *FakeReceptionRepository) ChangeReceptionStatus(ctx context.Context, receptionId uuid.UUID, from, to models.Status, entry models.AuditEntry) (bool, error) {
+	reception, ok := p.fakeDB[receptionId]
+	if !ok || reception.Status != from {
+		return false, nil
+	}
+
+	reception.Status = to
+	p.fakeDB[receptionId] = reception
+	p.fakeHistoryDB[receptionId] = append(p.fakeHistoryDB[receptionId], entry)
+
+	return true, nil
+}
+
+func (p *FakeReceptionRepository) GetReceptionHistory(ctx context.Context, receptionId uuid.UUID) ([]models.AuditEntry, error) {
+	return append([]models.AuditEntry{}, p.fakeHistoryDB[receptionId]...), nil
 }
@@ -0,0 +1,64 @@
+package fakes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+)
+
+type FakeTokenRepository struct {
+	refreshTokens map[string]models.RefreshToken
+	revokedTokens map[string]time.Time
+}
+
+func NewFakeTokenRepository() *FakeTokenRepository {
+	return &FakeTokenRepository{
+		refreshTokens: make(map[string]models.RefreshToken),
+		revokedTokens: make(map[string]time.Time),
+	}
+}
+
+func (f *FakeTokenRepository) CreateRefreshToken(ctx context.Context, token models.RefreshToken) error {
+	f.refreshTokens[token.TokenHash] = token
+	return nil
+}
+
+func (f *FakeTokenRepository) GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	return f.refreshTokens[tokenHash], nil
+}
+
+func (f *FakeTokenRepository) RevokeRefreshToken(ctx context.Context, tokenId uuid.UUID) (bool, error) {
+	for hash, token := range f.refreshTokens {
+		if token.Id == tokenId && token.RevokedAt.IsZero() {
+			token.RevokedAt = time.Now()
+			f.refreshTokens[hash] = token
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *FakeTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userId string) error {
+	for hash, token := range f.refreshTokens {
+		if token.UserId == userId && token.RevokedAt.IsZero() {
+			token.RevokedAt = time.Now()
+			f.refreshTokens[hash] = token
+		}
+	}
+
+	return nil
+}
+
+func (f *FakeTokenRepository) RevokeAccessToken(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	f.revokedTokens[tokenId] = expiresAt
+	return nil
+}
+
+func (f *FakeTokenRepository) IsAccessTokenRevoked(ctx context.Context, tokenId, userId, role string, issuedAt time.Time) (bool, error) {
+	_, ok := f.revokedTokens[tokenId]
+	return ok, nil
+}
@@ -22,3 +22,11 @@ func (f *FakeUserRepository) IsUserExist(ctx context.Context, email string) (boo
 func (f *FakeUserRepository) GetUserByEmail(ctx context.Context, logInData models.LoginData) (models.User, error) {
 	return models.User{}, nil
 }
+
+func (f *FakeUserRepository) GetUserById(ctx context.Context, userId string) (models.User, error) {
+	return models.User{}, nil
+}
+
+func (f *FakeUserRepository) UpdatePassword(ctx context.Context, userId, password, salt string) error {
+	return nil
+}
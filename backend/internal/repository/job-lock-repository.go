@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"pvz/config/postgres"
+	"pvz/pkg/logger"
+)
+
+const (
+	TryAdvisoryLockQuery = `
+		select pg_try_advisory_lock($1)
+	`
+
+	AdvisoryUnlockQuery = `
+		select pg_advisory_unlock($1)
+	`
+)
+
+// PostgresJobLockRepository не даёт фоновой задаче выполняться одновременно на нескольких репликах сервиса
+type PostgresJobLockRepository struct {
+	Db *sql.DB
+}
+
+func NewPostgresJobLockRepository() *PostgresJobLockRepository {
+	db, err := sql.Open("pgx", postgres.NewPostgresConfig().GetURL())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	return &PostgresJobLockRepository{Db: db}
+}
+
+func (p *PostgresJobLockRepository) Close() {
+	p.Db.Close()
+}
+
+// TryLock берёт сессионную advisory-блокировку postgres без ожидания. Блокировка живёт, пока открыто соединение,
+// поэтому оно удерживается до вызова unlock. acquired = false означает, что задачу уже выполняет другая реплика
+func (p *PostgresJobLockRepository) TryLock(ctx context.Context, key int64) (unlock func(), acquired bool, err error) {
+	conn, err := p.Db.Conn(ctx)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get connection for job lock %d: %v", key, err))
+		return nil, false, errors.New("unable to take job lock")
+	}
+
+	if err = conn.QueryRowContext(ctx, TryAdvisoryLockQuery, key).Scan(&acquired); err != nil {
+		conn.Close()
+		logger.Error(ctx, fmt.Sprintf("unable to take job lock %d: %v", key, err))
+		return nil, false, errors.New("unable to take job lock")
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock = func() {
+		// контекст задачи мог быть уже отменён, а блокировку нужно снять в любом случае
+		if _, err := conn.ExecContext(context.Background(), AdvisoryUnlockQuery, key); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to release job lock %d: %v", key, err))
+		}
+		conn.Close()
+	}
+
+	return unlock, true, nil
+}
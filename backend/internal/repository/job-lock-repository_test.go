@@ -0,0 +1,43 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+func TestTryLock(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresJobLockRepository{Db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.TryAdvisoryLockQuery)).
+		WithArgs(int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+	mock.ExpectExec(regexp.QuoteMeta(repository.AdvisoryUnlockQuery)).
+		WithArgs(int64(42)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	unlock, acquired, err := repo.TryLock(context.Background(), 42)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	unlock()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.TryAdvisoryLockQuery)).
+		WithArgs(int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+	unlock, acquired, err = repo.TryLock(context.Background(), 42)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+	assert.Nil(t, unlock)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"pvz/config/postgres"
+	"pvz/internal/models"
+	"pvz/pkg/logger"
+)
+
+const (
+	GetLoginLockQuery = `
+		select locked_until from login_failure
+		where kind = $1 and subject = $2 and locked_until > $3
+	`
+
+	RegisterLoginFailureQuery = `
+		insert into login_failure (kind, subject, failures, last_failure_at)
+		values ($1, $2, 1, $3)
+		on conflict (kind, subject) do update set
+			failures = case when login_failure.last_failure_at < $4 then 1 else login_failure.failures + 1 end,
+			last_failure_at = $3
+		returning failures
+	`
+
+	LockLoginQuery = `
+		update login_failure set locked_until = $3
+		where kind = $1 and subject = $2
+	`
+
+	ResetLoginFailuresQuery = `
+		delete from login_failure where kind = $1 and subject = $2
+	`
+)
+
+type PostgresLoginFailureRepository struct {
+	Db *sql.DB
+}
+
+func NewPostgresLoginFailureRepository() *PostgresLoginFailureRepository {
+	db, err := sql.Open("pgx", postgres.NewPostgresConfig().GetURL())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	return &PostgresLoginFailureRepository{Db: db}
+}
+
+func (p *PostgresLoginFailureRepository) Close() {
+	p.Db.Close()
+}
+
+// GetLoginLock возвращает время окончания действующей блокировки или нулевое время, если блокировки нет
+func (p *PostgresLoginFailureRepository) GetLoginLock(ctx context.Context, kind models.LoginSubject, subject string, now time.Time) (time.Time, error) {
+	var lockedUntil time.Time
+	if err := p.Db.QueryRowContext(ctx, GetLoginLockQuery, kind, subject, now).Scan(&lockedUntil); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to get login lock: %v", err))
+		return time.Time{}, errors.New("unable to get login lock")
+	}
+
+	return lockedUntil, nil
+}
+
+// RegisterLoginFailure увеличивает счётчик неудачных попыток и возвращает его новое значение.
+// Если с прошлой неудачи прошло больше окна, счёт начинается заново
+func (p *PostgresLoginFailureRepository) RegisterLoginFailure(ctx context.Context, kind models.LoginSubject, subject string, now time.Time, window time.Duration) (int, error) {
+	var failures int
+	if err := p.Db.QueryRowContext(ctx, RegisterLoginFailureQuery, kind, subject, now, now.Add(-window)).Scan(&failures); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return 0, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to register login failure: %v", err))
+		return 0, errors.New("unable to register login failure")
+	}
+
+	return failures, nil
+}
+
+func (p *PostgresLoginFailureRepository) LockLogin(ctx context.Context, kind models.LoginSubject, subject string, until time.Time) error {
+	if _, err := p.Db.ExecContext(ctx, LockLoginQuery, kind, subject, until); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to lock login: %v", err))
+		return fmt.Errorf("unable to lock login: %v", err)
+	}
+
+	logger.Warn(ctx, fmt.Sprintf("Login by %s %s is locked until %s", kind, subject, until))
+	return nil
+}
+
+func (p *PostgresLoginFailureRepository) ResetLoginFailures(ctx context.Context, kind models.LoginSubject, subject string) error {
+	if _, err := p.Db.ExecContext(ctx, ResetLoginFailuresQuery, kind, subject); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to reset login failures: %v", err))
+		return fmt.Errorf("unable to reset login failures: %v", err)
+	}
+
+	return nil
+}
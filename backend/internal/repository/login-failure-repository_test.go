@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+func TestGetLoginLock(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresLoginFailureRepository{Db: db}
+
+	now := time.Now()
+	lockedUntil := now.Add(time.Minute)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetLoginLockQuery)).
+		WithArgs(models.LoginByEmail, "user@example.com", now).
+		WillReturnRows(sqlmock.NewRows([]string{"locked_until"}).AddRow(lockedUntil))
+
+	got, err := repo.GetLoginLock(context.Background(), models.LoginByEmail, "user@example.com", now)
+	assert.NoError(t, err)
+	assert.Equal(t, lockedUntil, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetLoginLockQuery)).
+		WithArgs(models.LoginByIP, "10.0.0.1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"locked_until"}))
+
+	got, err = repo.GetLoginLock(context.Background(), models.LoginByIP, "10.0.0.1", now)
+	assert.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterLoginFailure(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresLoginFailureRepository{Db: db}
+
+	now := time.Now()
+	window := time.Hour
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.RegisterLoginFailureQuery)).
+		WithArgs(models.LoginByEmail, "user@example.com", now, now.Add(-window)).
+		WillReturnRows(sqlmock.NewRows([]string{"failures"}).AddRow(3))
+
+	failures, err := repo.RegisterLoginFailure(context.Background(), models.LoginByEmail, "user@example.com", now, window)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, failures)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.RegisterLoginFailureQuery)).
+		WithArgs(models.LoginByEmail, "user@example.com", now, now.Add(-window)).
+		WillReturnError(errors.New("some error"))
+
+	_, err = repo.RegisterLoginFailure(context.Background(), models.LoginByEmail, "user@example.com", now, window)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockAndResetLogin(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresLoginFailureRepository{Db: db}
+
+	until := time.Now().Add(time.Minute)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.LockLoginQuery)).
+		WithArgs(models.LoginByEmail, "user@example.com", until).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.LockLogin(context.Background(), models.LoginByEmail, "user@example.com", until))
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.ResetLoginFailuresQuery)).
+		WithArgs(models.LoginByEmail, "user@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.ResetLoginFailures(context.Background(), models.LoginByEmail, "user@example.com"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,14 +2,26 @@ package mocks
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 )
 
+// arrayConverter пропускает срезы строк без изменений, как это делает драйвер pgx, кодируя их в массивы postgres
+type arrayConverter struct{}
+
+func (arrayConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	if values, ok := v.([]string); ok {
+		return values, nil
+	}
+
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
 func SetupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, func()) {
-	db, mock, err := sqlmock.New()
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
 	assert.NoError(t, err)
 
 	cleanup := func() {
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"pvz/config/postgres"
+	"pvz/internal/models"
+	postgres_models "pvz/internal/models/postgres-models"
+	"pvz/pkg/logger"
+)
+
+const (
+	GetOrderProductQuery = `
+		select p.id, p.status, r.pvz_id, r.status
+		from product p
+		join reception r on r.id = p.reception_id
+		where p.id = $1
+	`
+
+	CreateOrderQuery = `
+		insert into "order" (id, client_id, pvz_id, product_id, status, created_at)
+		values ($1, $2, $3, $4, $5, $6)
+	`
+
+	GetOrderQuery = `
+		select id, client_id, pvz_id, product_id, status, created_at, issued_at, issued_by
+		from "order"
+		where id = $1
+	`
+
+	GetClientOrdersByStatusQuery = `
+		select id, client_id, pvz_id, product_id, status, created_at, issued_at, issued_by
+		from "order"
+		where client_id = $1 and status = $2
+		order by created_at desc
+	`
+
+	SetPickupCodeQuery = `
+		update "order" set pickup_code_hash = $2, pickup_code_expires_at = $3
+		where id = $1 and status = $4
+	`
+
+	// вместе с заказом выдаётся и товар, если он ещё на складе закрытой или проверенной приёмки -
+	// по тому же правилу, что и выдача товара без заказа
+	IssueOrderQuery = `
+		with issued as (
+		  update "order" set status = $4, issued_at = $5, issued_by = $3,
+			pickup_code_hash = null, pickup_code_expires_at = null
+		  where pvz_id = $1 and pickup_code_hash = $2 and status = $6 and pickup_code_expires_at > $5
+			and exists (
+			  select 1 from product
+			  join reception r on r.id = product.reception_id
+			  where product.id = "order".product_id and product.status = $8 and r.status in ($9, $10)
+			)
+		  returning id, client_id, pvz_id, product_id, status, created_at, issued_at, issued_by
+		), issued_product as (
+		  update product set status = $7, issued_at = $5
+		  where id = (select product_id from issued)
+		)
+		select id, client_id, pvz_id, product_id, status, created_at, issued_at, issued_by
+		from issued
+	`
+)
+
+type PostgresOrderRepository struct {
+	Db *sql.DB
+}
+
+func NewPostgresOrderRepository() *PostgresOrderRepository {
+	db, err := sql.Open("pgx", postgres.NewPostgresConfig().GetURL())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	return &PostgresOrderRepository{Db: db}
+}
+
+func (p *PostgresOrderRepository) Close() {
+	p.Db.Close()
+}
+
+// GetOrderProduct возвращает товар со статусом его приёмки и ПВЗ, на который он был принят.
+// Нулевой Product.Id означает, что товара нет
+func (p *PostgresOrderRepository) GetOrderProduct(ctx context.Context, productId uuid.UUID) (models.ProductLocation, error) {
+	var location models.ProductLocation
+	err := p.Db.QueryRowContext(ctx, GetOrderProductQuery, productId).
+		Scan(&location.Product.Id, &location.Product.Status, &location.PvzId, &location.ReceptionStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("Product %s was not found", productId))
+			return models.ProductLocation{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to get product of order: %v", err))
+		return models.ProductLocation{}, errors.New("unable to get product of order")
+	}
+
+	return location, nil
+}
+
+func (p *PostgresOrderRepository) CreateOrder(ctx context.Context, order models.Order) error {
+	logger.Info(ctx, "Trying to create order")
+
+	_, err := p.Db.ExecContext(ctx, CreateOrderQuery, order.Id, order.ClientId, order.PvzId, order.ProductId, order.Status, order.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error creating order: %s", err.Error()))
+		return fmt.Errorf("unable to create order: %v", err)
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully created order with Id: %s", order.Id))
+	return nil
+}
+
+func (p *PostgresOrderRepository) GetOrder(ctx context.Context, orderId uuid.UUID) (models.Order, error) {
+	var order postgres_models.PostgresOrder
+	if err := p.Db.QueryRowContext(ctx, GetOrderQuery, orderId).Scan(&order.OrderId,
+		&order.OrderClientId,
+		&order.OrderPvzId,
+		&order.OrderProductId,
+		&order.OrderStatus,
+		&order.OrderCreatedAt,
+		&order.OrderIssuedAt,
+		&order.OrderIssuedBy,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("Order %s was not found", orderId))
+			return models.Order{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to get order: %v", err))
+		return models.Order{}, errors.New("unable to get order")
+	}
+
+	return postgres_models.ToOrder(order), nil
+}
+
+func (p *PostgresOrderRepository) GetClientOrders(ctx context.Context, clientId string, status models.OrderStatus) ([]models.Order, error) {
+	logger.Info(ctx, "Trying to get client orders")
+
+	rows, err := p.Db.QueryContext(ctx, GetClientOrdersByStatusQuery, clientId, status)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get client orders: %v", err))
+		return nil, errors.New("unable to get client orders")
+	}
+	defer rows.Close()
+
+	orders := make([]models.Order, 0)
+	for rows.Next() {
+		var order postgres_models.PostgresOrder
+		if err = rows.Scan(&order.OrderId,
+			&order.OrderClientId,
+			&order.OrderPvzId,
+			&order.OrderProductId,
+			&order.OrderStatus,
+			&order.OrderCreatedAt,
+			&order.OrderIssuedAt,
+			&order.OrderIssuedBy,
+		); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan order: %v", err))
+			return nil, errors.New("unable to get client orders")
+		}
+		orders = append(orders, postgres_models.ToOrder(order))
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate orders: %v", err))
+		return nil, errors.New("unable to get client orders")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully got %d orders of client %s", len(orders), clientId))
+	return orders, nil
+}
+
+// SetPickupCode заменяет код выдачи ожидающего заказа, прежний код перестаёт действовать
+func (p *PostgresOrderRepository) SetPickupCode(ctx context.Context, orderId uuid.UUID, codeHash string, expiresAt time.Time) (bool, error) {
+	commandTag, err := p.Db.ExecContext(ctx, SetPickupCodeQuery, orderId, codeHash, expiresAt, models.OrderWaiting)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return false, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error setting pickup code: %s", err.Error()))
+		return false, fmt.Errorf("unable to set pickup code: %v", err)
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+// IssueOrder выдаёт ожидающий заказ по действующему коду. Если такого заказа нет, возвращается пустой заказ
+func (p *PostgresOrderRepository) IssueOrder(ctx context.Context, pvzId uuid.UUID, codeHash, issuedBy string, issuedAt time.Time) (models.Order, error) {
+	logger.Info(ctx, "Trying to issue order")
+
+	var order postgres_models.PostgresOrder
+	if err := p.Db.QueryRowContext(ctx, IssueOrderQuery, pvzId, codeHash, issuedBy, models.OrderIssued, issuedAt, models.OrderWaiting,
+		models.ProductIssued, models.ProductStored, models.Closed, models.Verified).Scan(&order.OrderId,
+		&order.OrderClientId,
+		&order.OrderPvzId,
+		&order.OrderProductId,
+		&order.OrderStatus,
+		&order.OrderCreatedAt,
+		&order.OrderIssuedAt,
+		&order.OrderIssuedBy,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("There is no waiting order with this pickup code and stored product in pvz %s", pvzId))
+			return models.Order{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to issue order: %v", err))
+		return models.Order{}, errors.New("unable to issue order")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully issued order with id: %s", order.OrderId))
+	return postgres_models.ToOrder(order), nil
+}
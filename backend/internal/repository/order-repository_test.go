@@ -0,0 +1,212 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+var orderColumns = []string{"id", "client_id", "pvz_id", "product_id", "status", "created_at", "issued_at", "issued_by"}
+
+func TestGetOrderProduct(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresOrderRepository{Db: db}
+
+	productId := uuid.New()
+	pvzId := uuid.New()
+	columns := []string{"id", "status", "pvz_id", "status"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetOrderProductQuery)).
+		WithArgs(productId).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(productId, string(models.ProductStored), pvzId, string(models.Closed)))
+
+	got, err := repo.GetOrderProduct(context.Background(), productId)
+	assert.NoError(t, err)
+	assert.Equal(t, productId, got.Product.Id)
+	assert.Equal(t, models.ProductStored, got.Product.Status)
+	assert.Equal(t, pvzId, got.PvzId)
+	assert.Equal(t, models.Closed, got.ReceptionStatus)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetOrderProductQuery)).
+		WithArgs(productId).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	got, err = repo.GetOrderProduct(context.Background(), productId)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, got.Product.Id)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetOrderProductQuery)).
+		WithArgs(productId).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.GetOrderProduct(context.Background(), productId)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateOrder(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresOrderRepository{Db: db}
+
+	order := models.Order{
+		Id:        uuid.New(),
+		ClientId:  uuid.NewString(),
+		PvzId:     uuid.New(),
+		ProductId: uuid.New(),
+		Status:    models.OrderWaiting,
+		CreatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name      string
+		mockQuery func()
+		wantErr   bool
+	}{
+		{
+			name: "ok",
+			mockQuery: func() {
+				mock.ExpectExec(regexp.QuoteMeta(repository.CreateOrderQuery)).
+					WithArgs(order.Id, order.ClientId, order.PvzId, order.ProductId, order.Status, order.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "sql error",
+			mockQuery: func() {
+				mock.ExpectExec(regexp.QuoteMeta(repository.CreateOrderQuery)).
+					WithArgs(order.Id, order.ClientId, order.PvzId, order.ProductId, order.Status, order.CreatedAt).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockQuery()
+
+			err := repo.CreateOrder(context.Background(), order)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetClientOrders(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresOrderRepository{Db: db}
+
+	clientId := uuid.NewString()
+	orderId := uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetClientOrdersByStatusQuery)).
+		WithArgs(clientId, models.OrderWaiting).
+		WillReturnRows(sqlmock.NewRows(orderColumns).
+			AddRow(orderId, clientId, uuid.New(), uuid.New(), "waiting", createdAt, nil, nil))
+
+	orders, err := repo.GetClientOrders(context.Background(), clientId, models.OrderWaiting)
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, orderId, orders[0].Id)
+	assert.Equal(t, models.OrderWaiting, orders[0].Status)
+	assert.True(t, orders[0].IssuedAt.IsZero())
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetClientOrdersByStatusQuery)).
+		WithArgs(clientId, models.OrderWaiting).
+		WillReturnError(errors.New("some error"))
+
+	_, err = repo.GetClientOrders(context.Background(), clientId, models.OrderWaiting)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetPickupCode(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresOrderRepository{Db: db}
+
+	orderId := uuid.New()
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.SetPickupCodeQuery)).
+		WithArgs(orderId, "hash", expiresAt, models.OrderWaiting).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ok, err := repo.SetPickupCode(context.Background(), orderId, "hash", expiresAt)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.SetPickupCodeQuery)).
+		WithArgs(orderId, "hash", expiresAt, models.OrderWaiting).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ok, err = repo.SetPickupCode(context.Background(), orderId, "hash", expiresAt)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIssueOrder(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresOrderRepository{Db: db}
+
+	pvzId := uuid.New()
+	orderId := uuid.New()
+	employeeId := uuid.NewString()
+	issuedAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.IssueOrderQuery)).
+		WithArgs(pvzId, "hash", employeeId, models.OrderIssued, issuedAt, models.OrderWaiting, models.ProductIssued, models.ProductStored, models.Closed, models.Verified).
+		WillReturnRows(sqlmock.NewRows(orderColumns).
+			AddRow(orderId, uuid.NewString(), pvzId, uuid.New(), "issued", issuedAt.Add(-time.Hour), issuedAt, employeeId))
+
+	order, err := repo.IssueOrder(context.Background(), pvzId, "hash", employeeId, issuedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, orderId, order.Id)
+	assert.Equal(t, models.OrderIssued, order.Status)
+	assert.Equal(t, employeeId, order.IssuedBy)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.IssueOrderQuery)).
+		WithArgs(pvzId, "hash", employeeId, models.OrderIssued, issuedAt, models.OrderWaiting, models.ProductIssued, models.ProductStored, models.Closed, models.Verified).
+		WillReturnRows(sqlmock.NewRows(orderColumns))
+
+	order, err = repo.IssueOrder(context.Background(), pvzId, "hash", employeeId, issuedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, order.Id)
+
+	// товар ещё в открытой приёмке: guard r.status in (close, verified) не пропускает заказ
+	assert.Contains(t, repository.IssueOrderQuery, "r.status in ($9, $10)")
+	mock.ExpectQuery(regexp.QuoteMeta(repository.IssueOrderQuery)).
+		WithArgs(pvzId, "hash", employeeId, models.OrderIssued, issuedAt, models.OrderWaiting, models.ProductIssued, models.ProductStored, models.Closed, models.Verified).
+		WillReturnRows(sqlmock.NewRows(orderColumns))
+
+	order, err = repo.IssueOrder(context.Background(), pvzId, "hash", employeeId, issuedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, order.Id, "order of in-progress reception is not issued")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
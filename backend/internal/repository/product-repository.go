@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"pvz/config/postgres"
+	"pvz/internal/models"
+	"pvz/internal/models/postgres-models"
+	"pvz/pkg/logger"
+)
+
+const (
+	GetProductQuery = `
+		select pr.id, pr.received_at, pr.type, pr.reception_id, pr.over_capacity, pr.barcode, pr.external_order_id,
+		  pr.status, pr.issued_at, pr.returned_at, pr.sent_back_at,
+		  r.pvz_id, r.status, pvz.city
+		from product pr
+		join reception r on r.id = pr.reception_id
+		join pvz on pvz.id = r.pvz_id
+		where pr.id = $1
+	`
+
+	HasWaitingOrderQuery = `
+		select exists (select 1 from "order" where product_id = $1 and status = $2)
+	`
+
+	IssueProductQuery = `
+		update product set status = $2, issued_at = $3
+		where id = $1 and status = $4
+	`
+
+	ReturnProductQuery = `
+		update product set status = $2, returned_at = $3
+		where id = $1 and status = $4
+	`
+
+	// обратно на склад уходят все возвраты и товары, пролежавшие дольше срока хранения, из закрытых и проверенных приёмок
+	SendBackProductsQuery = `
+		update product pr set status = $2, sent_back_at = $3
+		from reception r
+		where r.id = pr.reception_id and r.pvz_id = $1 and r.status in ($4, $8)
+		  and (pr.status = $5 or (pr.status = $6 and pr.received_at < $7))
+		returning pr.id, pr.received_at, pr.type, pr.reception_id, pr.over_capacity, pr.barcode, pr.external_order_id,
+		  pr.status, pr.issued_at, pr.returned_at, pr.sent_back_at
+	`
+
+	// заказ на отправленный обратно товар клиент уже не получит
+	CancelSentBackOrdersQuery = `
+		update "order" set status = $2
+		where product_id = any($1::uuid[]) and status = $3
+	`
+)
+
+type PostgresProductRepository struct {
+	Db *sql.DB
+}
+
+func NewPostgresProductRepository() *PostgresProductRepository {
+	db, err := sql.Open("pgx", postgres.NewPostgresConfig().GetURL())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	return &PostgresProductRepository{Db: db}
+}
+
+func (p *PostgresProductRepository) Close() {
+	p.Db.Close()
+}
+
+// GetProduct возвращает товар вместе с ПВЗ и статусом его приёмки. Если товара нет, возвращается пустой результат
+func (p *PostgresProductRepository) GetProduct(ctx context.Context, productId uuid.UUID) (models.ProductLocation, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to get product %s", productId))
+
+	var (
+		product  postgres_models.PostgresProduct
+		location models.ProductLocation
+	)
+
+	err := p.Db.QueryRowContext(ctx, GetProductQuery, productId).Scan(&product.ProductId, &product.ProductReceivedAt,
+		&product.ProductType, &product.ProductReceptionId, &product.ProductOverCapacity, &product.ProductBarcode,
+		&product.ProductExternalOrderId, &product.ProductStatus, &product.ProductIssuedAt, &product.ProductReturnedAt,
+		&product.ProductSentBackAt, &location.PvzId, &location.ReceptionStatus, &location.City)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("Product %s does not exist", productId))
+			return models.ProductLocation{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to get product %s: %v", productId, err))
+		return models.ProductLocation{}, errors.New("unable to get product")
+	}
+
+	location.Product = postgres_models.ToProduct(product)
+	return location, nil
+}
+
+func (p *PostgresProductRepository) HasWaitingOrder(ctx context.Context, productId uuid.UUID) (bool, error) {
+	var hasOrder bool
+	if err := p.Db.QueryRowContext(ctx, HasWaitingOrderQuery, productId, models.OrderWaiting).Scan(&hasOrder); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to check orders of product %s: %v", productId, err))
+		return false, errors.New("unable to check product orders")
+	}
+
+	return hasOrder, nil
+}
+
+// IssueProduct выдаёт товар со склада, false означает, что товар уже не на складе
+func (p *PostgresProductRepository) IssueProduct(ctx context.Context, productId uuid.UUID, issuedAt time.Time) (bool, error) {
+	commandTag, err := p.Db.ExecContext(ctx, IssueProductQuery, productId, models.ProductIssued, issuedAt, models.ProductStored)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to issue product %s: %v", productId, err))
+		return false, errors.New("unable to issue product")
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+// ReturnProduct принимает возврат выданного товара, false означает, что товар не был выдан
+func (p *PostgresProductRepository) ReturnProduct(ctx context.Context, productId uuid.UUID, returnedAt time.Time) (bool, error) {
+	commandTag, err := p.Db.ExecContext(ctx, ReturnProductQuery, productId, models.ProductReturned, returnedAt, models.ProductIssued)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to return product %s: %v", productId, err))
+		return false, errors.New("unable to return product")
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+// SendBackProducts отправляет обратно на склад возвраты и товары из закрытых приёмок, принятые раньше receivedBefore
+func (p *PostgresProductRepository) SendBackProducts(ctx context.Context, pvzId uuid.UUID, receivedBefore, sentAt time.Time) ([]models.Product, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to send back products of pvz %s", pvzId))
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return nil, errors.New("unable to send back products")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, SendBackProductsQuery, pvzId, models.ProductSentBack, sentAt, models.Closed,
+		models.ProductReturned, models.ProductStored, receivedBefore, models.Verified)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to send back products: %v", err))
+		return nil, errors.New("unable to send back products")
+	}
+	defer rows.Close()
+
+	products := make([]models.Product, 0)
+	productIds := make([]string, 0)
+	for rows.Next() {
+		var product postgres_models.PostgresProduct
+		err = rows.Scan(&product.ProductId, &product.ProductReceivedAt, &product.ProductType, &product.ProductReceptionId,
+			&product.ProductOverCapacity, &product.ProductBarcode, &product.ProductExternalOrderId, &product.ProductStatus,
+			&product.ProductIssuedAt, &product.ProductReturnedAt, &product.ProductSentBackAt)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan product: %v", err))
+			return nil, errors.New("unable to send back products")
+		}
+		products = append(products, postgres_models.ToProduct(product))
+		productIds = append(productIds, product.ProductId.String())
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate products: %v", err))
+		return nil, errors.New("unable to send back products")
+	}
+	rows.Close()
+
+	if len(productIds) > 0 {
+		if _, err = tx.ExecContext(ctx, CancelSentBackOrdersQuery, productIds, models.OrderCancelled, models.OrderWaiting); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to cancel orders of sent back products: %v", err))
+			return nil, errors.New("unable to send back products")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return nil, errors.New("unable to send back products")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully sent back %d products of pvz %s", len(products), pvzId))
+	return products, nil
+}
@@ -0,0 +1,191 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+var productColumns = []string{
+	"id", "received_at", "type", "reception_id", "over_capacity", "barcode", "external_order_id",
+	"status", "issued_at", "returned_at", "sent_back_at",
+}
+
+func TestGetProduct(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresProductRepository{Db: db}
+
+	productId := uuid.New()
+	pvzId := uuid.New()
+	issuedAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetProductQuery)).
+		WithArgs(productId).
+		WillReturnRows(sqlmock.NewRows(append(productColumns, "pvz_id", "status", "city")).
+			AddRow(productId, issuedAt, "обувь", uuid.New(), false, nil, nil, string(models.ProductIssued), issuedAt, nil, nil,
+				pvzId, string(models.Closed), "Москва"))
+
+	got, err := repo.GetProduct(context.Background(), productId)
+	assert.NoError(t, err)
+	assert.Equal(t, productId, got.Product.Id)
+	assert.Equal(t, models.ProductIssued, got.Product.Status)
+	assert.Equal(t, issuedAt, got.Product.IssuedAt)
+	assert.True(t, got.Product.ReturnedAt.IsZero())
+	assert.Equal(t, pvzId, got.PvzId)
+	assert.Equal(t, models.Closed, got.ReceptionStatus)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetProductQuery)).
+		WithArgs(productId).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.GetProduct(context.Background(), productId)
+	assert.Error(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetProductQuery)).
+		WithArgs(productId).
+		WillReturnRows(sqlmock.NewRows(productColumns))
+	got, err = repo.GetProduct(context.Background(), productId)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, got.Product.Id)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHasWaitingOrder(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresProductRepository{Db: db}
+	productId := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.HasWaitingOrderQuery)).
+		WithArgs(productId, models.OrderWaiting).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	hasOrder, err := repo.HasWaitingOrder(context.Background(), productId)
+	assert.NoError(t, err)
+	assert.True(t, hasOrder)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.HasWaitingOrderQuery)).
+		WithArgs(productId, models.OrderWaiting).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.HasWaitingOrder(context.Background(), productId)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIssueAndReturnProduct(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresProductRepository{Db: db}
+	productId := uuid.New()
+	now := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.IssueProductQuery)).
+		WithArgs(productId, models.ProductIssued, now, models.ProductStored).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	isIssued, err := repo.IssueProduct(context.Background(), productId, now)
+	assert.NoError(t, err)
+	assert.True(t, isIssued)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.IssueProductQuery)).
+		WithArgs(productId, models.ProductIssued, now, models.ProductStored).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	isIssued, err = repo.IssueProduct(context.Background(), productId, now)
+	assert.NoError(t, err)
+	assert.False(t, isIssued)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.ReturnProductQuery)).
+		WithArgs(productId, models.ProductReturned, now, models.ProductIssued).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	isReturned, err := repo.ReturnProduct(context.Background(), productId, now)
+	assert.NoError(t, err)
+	assert.True(t, isReturned)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.ReturnProductQuery)).
+		WithArgs(productId, models.ProductReturned, now, models.ProductIssued).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.ReturnProduct(context.Background(), productId, now)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendBackProducts(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresProductRepository{Db: db}
+	pvzId := uuid.New()
+	now := time.Now()
+	receivedBefore := now.Add(-7 * 24 * time.Hour)
+
+	storedId, returnedId := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(repository.SendBackProductsQuery)).
+		WithArgs(pvzId, models.ProductSentBack, now, models.Closed, models.ProductReturned, models.ProductStored, receivedBefore, models.Verified).
+		WillReturnRows(sqlmock.NewRows(productColumns).
+			AddRow(storedId, receivedBefore, "обувь", uuid.New(), false, nil, nil, string(models.ProductSentBack), nil, nil, now).
+			AddRow(returnedId, now, "одежда", uuid.New(), false, "4600000000017", nil, string(models.ProductSentBack), now, now, now))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CancelSentBackOrdersQuery)).
+		WithArgs([]string{storedId.String(), returnedId.String()}, models.OrderCancelled, models.OrderWaiting).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	products, err := repo.SendBackProducts(context.Background(), pvzId, receivedBefore, now)
+	assert.NoError(t, err)
+	assert.Len(t, products, 2)
+	for _, product := range products {
+		assert.Equal(t, models.ProductSentBack, product.Status)
+		assert.Equal(t, now, product.SentBackAt)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(repository.SendBackProductsQuery)).
+		WithArgs(pvzId, models.ProductSentBack, now, models.Closed, models.ProductReturned, models.ProductStored, receivedBefore, models.Verified).
+		WillReturnRows(sqlmock.NewRows(productColumns))
+	mock.ExpectCommit()
+
+	products, err = repo.SendBackProducts(context.Background(), pvzId, receivedBefore, now)
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(repository.SendBackProductsQuery)).
+		WithArgs(pvzId, models.ProductSentBack, now, models.Closed, models.ProductReturned, models.ProductStored, receivedBefore, models.Verified).
+		WillReturnRows(sqlmock.NewRows(productColumns).
+			AddRow(storedId, receivedBefore, "обувь", uuid.New(), false, nil, nil, string(models.ProductSentBack), nil, nil, now))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CancelSentBackOrdersQuery)).
+		WithArgs([]string{storedId.String()}, models.OrderCancelled, models.OrderWaiting).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	_, err = repo.SendBackProducts(context.Background(), pvzId, receivedBefore, now)
+	assert.Error(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(repository.SendBackProductsQuery)).
+		WithArgs(pvzId, models.ProductSentBack, now, models.Closed, models.ProductReturned, models.ProductStored, receivedBefore, models.Verified).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	_, err = repo.SendBackProducts(context.Background(), pvzId, receivedBefore, now)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
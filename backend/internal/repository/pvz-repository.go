@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -20,7 +22,8 @@ import (
 
 const (
 	CreatePvzQuery = `
-		insert into pvz (id, registration_date, city) values ($1, $2, $3)
+		insert into pvz (id, registration_date, city, address, latitude, longitude, opening_hours, phone, capacity)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	GetPvzInfoQuery = `
@@ -28,7 +31,14 @@ const (
 		  select
 			pvz.id as pvz_id,
 			pvz.registration_date as pvz_registration_date,
-			pvz.city as pvz_city
+			pvz.city as pvz_city,
+			pvz.address as pvz_address,
+			pvz.latitude as pvz_latitude,
+			pvz.longitude as pvz_longitude,
+			pvz.opening_hours as pvz_opening_hours,
+			pvz.phone as pvz_phone,
+			pvz.capacity as pvz_capacity,
+			pvz.decommissioned_at as pvz_decommissioned_at
 		  from pvz
 		  where pvz.registration_date between $1 AND $2
 		  order by pvz.id
@@ -39,14 +49,27 @@ const (
 		  p.pvz_id,
 		  p.pvz_registration_date,
 		  p.pvz_city,
+		  p.pvz_address,
+		  p.pvz_latitude,
+		  p.pvz_longitude,
+		  p.pvz_opening_hours,
+		  p.pvz_phone,
+		  p.pvz_capacity,
+		  p.pvz_decommissioned_at,
 		  r.id,
 		  r.reception_datetime,
 		  r.status,
           r.pvz_id,
+		  r.outside_schedule,
+		  r.auto_closed,
 		  pr.id,
 		  pr.received_at,
 		  pr.type,
-          pr.reception_id
+          pr.reception_id,
+		  pr.over_capacity,
+		  pr.barcode,
+		  pr.external_order_id,
+		  pr.status
 		from paginated_pvzs p
 		left join reception r on r.pvz_id = p.pvz_id
 		left join product pr on pr.reception_id = r.id
@@ -54,9 +77,140 @@ const (
 	`
 
 	GetPvzListQuery = `
-		select id, registration_date, city
+		select id, registration_date, city, address, latitude, longitude, opening_hours, phone, capacity, decommissioned_at
 		from pvz
 	`
+
+	AssignEmployeeQuery = `
+		insert into user_pvz (user_id, pvz_id, assigned_at)
+		select u.id, p.id, $3
+		from "user" u, pvz p
+		where u.id = $1 and u.role = any(string_to_array($4, ',')) and p.id = $2 and p.decommissioned_at is null
+		on conflict (user_id, pvz_id) do update set assigned_at = user_pvz.assigned_at
+		returning assigned_at
+	`
+
+	UnassignEmployeeQuery = `
+		delete from user_pvz where user_id = $1 and pvz_id = $2
+	`
+
+	IsEmployeeAssignedQuery = `
+		select exists (select 1 from user_pvz where user_id = $1 and pvz_id = $2)
+	`
+
+	IsCityAvailableQuery = `
+		select exists (select 1 from city where name = $1 and enabled)
+	`
+
+	GetPvzQuery = `
+		select id, registration_date, city, address, latitude, longitude, opening_hours, phone, capacity, decommissioned_at
+		from pvz
+		where id = $1
+	`
+
+	UpdatePvzQuery = `
+		update pvz set
+		  city = coalesce($2, city),
+		  address = coalesce($3, address),
+		  latitude = coalesce($4, latitude),
+		  longitude = coalesce($5, longitude),
+		  opening_hours = coalesce($6, opening_hours),
+		  phone = coalesce($7, phone),
+		  capacity = coalesce($8, capacity)
+		where id = $1
+		returning id, registration_date, city, address, latitude, longitude, opening_hours, phone, capacity, decommissioned_at
+	`
+
+	GetPvzScheduleQuery = `
+		select pvz_id, timezone from pvz_schedule where pvz_id = $1
+	`
+
+	GetWorkingHoursQuery = `
+		select weekday, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI')
+		from pvz_working_hours
+		where pvz_id = $1
+		order by weekday
+	`
+
+	GetScheduleExceptionsQuery = `
+		select day, coalesce(to_char(opens_at, 'HH24:MI'), ''), coalesce(to_char(closes_at, 'HH24:MI'), ''), description
+		from pvz_schedule_exception
+		where pvz_id = $1
+		order by day
+	`
+
+	UpsertPvzScheduleQuery = `
+		insert into pvz_schedule (pvz_id, timezone) values ($1, $2)
+		on conflict (pvz_id) do update set timezone = excluded.timezone
+	`
+
+	DeleteWorkingHoursQuery = `
+		delete from pvz_working_hours where pvz_id = $1
+	`
+
+	DeleteScheduleExceptionsQuery = `
+		delete from pvz_schedule_exception where pvz_id = $1
+	`
+
+	CreateWorkingHoursQuery = `
+		insert into pvz_working_hours (pvz_id, weekday, opens_at, closes_at)
+		values ($1, $2, $3::time, $4::time)
+	`
+
+	CreateScheduleExceptionQuery = `
+		insert into pvz_schedule_exception (pvz_id, day, opens_at, closes_at, description)
+		values ($1, $2, nullif($3, '')::time, nullif($4, '')::time, $5)
+	`
+
+	// расстояние по формуле гаверсинусов в метрах, широта заранее отсекается по индексу:
+	// градус широты везде примерно 111 км
+	NearbyPvzQuery = `
+		with point as (
+		  select $1::float8 as lat, $2::float8 as lon, $3::float8 as radius
+		)
+
+		select id, registration_date, city, address, latitude, longitude, opening_hours, phone, capacity, decommissioned_at, distance
+		from (
+		  select pvz.*,
+			2 * 6371000 * asin(least(1, sqrt(
+			  power(sin(radians(pvz.latitude - point.lat) / 2), 2) +
+			  cos(radians(point.lat)) * cos(radians(pvz.latitude)) * power(sin(radians(pvz.longitude - point.lon) / 2), 2)
+			))) as distance
+		  from pvz, point
+		  where pvz.latitude is not null and pvz.decommissioned_at is null
+			and pvz.latitude between point.lat - point.radius / 111000 and point.lat + point.radius / 111000
+		) nearby, point
+		where distance <= point.radius
+		order by distance
+		limit $4
+	`
+
+	// на складе лежат хранящиеся и возвращённые товары неотменённых приёмок:
+	// выданные клиентам и отправленные обратно в счёт не идут.
+	// $2, $3 - учитываемые статусы товара, $4 - статус отменённой приёмки
+	onHandSubquery = `
+		(select count(*)
+		 from reception r
+		 join product pr on pr.reception_id = r.id
+		 where r.pvz_id = pvz.id and pr.status in ($2, $3) and r.status <> $4)`
+
+	GetOverloadedPvzQuery = `
+		select id, registration_date, city, address, latitude, longitude, opening_hours, phone, capacity, decommissioned_at, on_hand
+		from (
+		  select pvz.*,` + onHandSubquery + ` as on_hand
+		  from pvz
+		  where pvz.capacity > 0 and pvz.decommissioned_at is null
+		) load
+		where on_hand >= capacity * $1::float8
+		order by on_hand::float8 / capacity desc
+	`
+
+	// история приёмок остаётся на месте, ПВЗ с незакрытой приёмкой списать нельзя
+	DecommissionPvzQuery = `
+		update pvz set decommissioned_at = $2
+		where id = $1 and decommissioned_at is null
+		  and not exists (select 1 from reception where pvz_id = $1 and status = $3)
+	`
 )
 
 type PostgresPvzRepository struct {
@@ -80,7 +234,14 @@ func (p *PostgresPvzRepository) Close() {
 func (p *PostgresPvzRepository) CreatePvz(ctx context.Context, pvzData models.Pvz) error {
 	logger.Info(ctx, fmt.Sprintf("Trying to create pvz with Id: %s", pvzData.Id))
 
-	_, err := p.Db.ExecContext(ctx, CreatePvzQuery, pvzData.Id, pvzData.RegistrationDate, pvzData.City)
+	var latitude, longitude sql.NullFloat64
+	if pvzData.Location != nil {
+		latitude = sql.NullFloat64{Float64: pvzData.Location.Latitude, Valid: true}
+		longitude = sql.NullFloat64{Float64: pvzData.Location.Longitude, Valid: true}
+	}
+
+	_, err := p.Db.ExecContext(ctx, CreatePvzQuery, pvzData.Id, pvzData.RegistrationDate, pvzData.City,
+		pvzData.Address, latitude, longitude, pvzData.OpeningHours, pvzData.Phone, pvzData.Capacity)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -120,11 +281,12 @@ func (p *PostgresPvzRepository) GetPvzInfo(ctx context.Context, form forms.GetPv
 			product   postgres_models.PostgresProduct
 		)
 
-		err = rows.Scan(
-			&pvz.PvzId, &pvz.PvzRegistrationDate, &pvz.PvzCity,
-			&reception.ReceptionId, &reception.ReceptionTime, &reception.ReceptionStatus, &reception.PvzId,
-			&product.ProductId, &product.ProductReceivedAt, &product.ProductType, &product.ProductReceptionId,
-		)
+		err = rows.Scan(append(pvzFields(&pvz),
+			&reception.ReceptionId, &reception.ReceptionTime, &reception.ReceptionStatus, &reception.PvzId, &reception.ReceptionOutsideSchedule,
+			&reception.ReceptionAutoClosed,
+			&product.ProductId, &product.ProductReceivedAt, &product.ProductType, &product.ProductReceptionId, &product.ProductOverCapacity,
+			&product.ProductBarcode, &product.ProductExternalOrderId, &product.ProductStatus,
+		)...)
 
 		if err != nil {
 			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
@@ -193,21 +355,314 @@ func (p *PostgresPvzRepository) GetPvzList(ctx context.Context) ([]models.Pvz, e
 
 	var pvzList []models.Pvz
 	for rows.Next() {
-		var pvz models.Pvz
+		var pvz postgres_models.PostgresPvz
 
-		err = rows.Scan(&pvz.Id,
-			&pvz.RegistrationDate,
-			&pvz.City,
-		)
+		err = rows.Scan(pvzFields(&pvz)...)
 
 		if err != nil {
 			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
 			return nil, err
 		}
 
-		pvzList = append(pvzList, pvz)
+		pvzList = append(pvzList, postgres_models.ToPvz(pvz))
 	}
 
 	logger.Info(ctx, "Successfully get pvz list")
 	return pvzList, nil
 }
+
+func (p *PostgresPvzRepository) AssignEmployee(ctx context.Context, employee models.PvzEmployee, roles []string) (models.PvzEmployee, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to assign employee %s to pvz %s", employee.UserId, employee.PvzId))
+
+	err := p.Db.QueryRowContext(ctx, AssignEmployeeQuery, employee.UserId, employee.PvzId, employee.AssignedAt, strings.Join(roles, ",")).
+		Scan(&employee.AssignedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("Employee %s or pvz %s does not exist", employee.UserId, employee.PvzId))
+			return models.PvzEmployee{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("Error assigning employee: %s", err.Error()))
+		return models.PvzEmployee{}, fmt.Errorf("unable to assign employee: %v", err)
+	}
+
+	return employee, nil
+}
+
+func (p *PostgresPvzRepository) UnassignEmployee(ctx context.Context, userId string, pvzId uuid.UUID) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to unassign employee %s from pvz %s", userId, pvzId))
+
+	commandTag, err := p.Db.ExecContext(ctx, UnassignEmployeeQuery, userId, pvzId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error unassigning employee: %s", err.Error()))
+		return false, fmt.Errorf("unable to unassign employee: %v", err)
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+// IsEmployeeAssigned проверяет, закреплён ли сотрудник за ПВЗ
+func (p *PostgresPvzRepository) IsEmployeeAssigned(ctx context.Context, userId string, pvzId uuid.UUID) (bool, error) {
+	var isAssigned bool
+	if err := p.Db.QueryRowContext(ctx, IsEmployeeAssignedQuery, userId, pvzId).Scan(&isAssigned); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to check employee assignment: %v", err))
+		return false, errors.New("unable to check employee assignment")
+	}
+
+	return isAssigned, nil
+}
+
+func (p *PostgresPvzRepository) IsCityAvailable(ctx context.Context, city string) (bool, error) {
+	var isAvailable bool
+	if err := p.Db.QueryRowContext(ctx, IsCityAvailableQuery, city).Scan(&isAvailable); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to check city %s: %s", city, err.Error()))
+		return false, fmt.Errorf("unable to check city: %v", err)
+	}
+
+	return isAvailable, nil
+}
+
+func (p *PostgresPvzRepository) GetPvz(ctx context.Context, pvzId uuid.UUID) (models.Pvz, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to get pvz %s", pvzId))
+
+	var pvz postgres_models.PostgresPvz
+	err := p.Db.QueryRowContext(ctx, GetPvzQuery, pvzId).Scan(pvzFields(&pvz)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("Pvz %s does not exist", pvzId))
+			return models.Pvz{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("Error getting pvz: %s", err.Error()))
+		return models.Pvz{}, fmt.Errorf("unable to get pvz: %v", err)
+	}
+
+	return postgres_models.ToPvz(pvz), nil
+}
+
+func (p *PostgresPvzRepository) UpdatePvz(ctx context.Context, pvzId uuid.UUID, form forms.UpdatePvzForm) (models.Pvz, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to update pvz %s", pvzId))
+
+	var latitude, longitude sql.NullFloat64
+	if form.Location != nil {
+		latitude = sql.NullFloat64{Float64: form.Location.Latitude, Valid: true}
+		longitude = sql.NullFloat64{Float64: form.Location.Longitude, Valid: true}
+	}
+
+	var pvz postgres_models.PostgresPvz
+	err := p.Db.QueryRowContext(ctx, UpdatePvzQuery, pvzId, form.City, form.Address,
+		latitude, longitude, form.OpeningHours, form.Phone, form.Capacity).Scan(pvzFields(&pvz)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("Pvz %s does not exist", pvzId))
+			return models.Pvz{}, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return models.Pvz{}, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error updating pvz: %s", err.Error()))
+		return models.Pvz{}, fmt.Errorf("unable to update pvz: %v", err)
+	}
+
+	return postgres_models.ToPvz(pvz), nil
+}
+
+func (p *PostgresPvzRepository) DecommissionPvz(ctx context.Context, pvzId uuid.UUID, decommissionedAt time.Time) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to decommission pvz %s", pvzId))
+
+	commandTag, err := p.Db.ExecContext(ctx, DecommissionPvzQuery, pvzId, decommissionedAt, models.InProgress)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error decommissioning pvz: %s", err.Error()))
+		return false, fmt.Errorf("unable to decommission pvz: %v", err)
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+func (p *PostgresPvzRepository) GetNearbyPvz(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to get pvz within %.0f m of (%f, %f)", form.Radius, form.Latitude, form.Longitude))
+
+	rows, err := p.Db.QueryContext(ctx, NearbyPvzQuery, form.Latitude, form.Longitude, form.Radius, form.Limit)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return nil, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error getting nearby pvz: %s", err.Error()))
+		return nil, fmt.Errorf("unable to get nearby pvz: %v", err)
+	}
+	defer rows.Close()
+
+	var nearbyList []models.NearbyPvz
+	for rows.Next() {
+		var (
+			pvz      postgres_models.PostgresPvz
+			distance float64
+		)
+
+		if err = rows.Scan(append(pvzFields(&pvz), &distance)...); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
+			return nil, err
+		}
+
+		nearbyList = append(nearbyList, models.NearbyPvz{Pvz: postgres_models.ToPvz(pvz), Distance: distance})
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Found %d pvz nearby", len(nearbyList)))
+	return nearbyList, nil
+}
+
+// GetOverloadedPvz возвращает действующие ПВЗ с ограниченной вместимостью, загруженные не меньше чем на threshold
+func (p *PostgresPvzRepository) GetOverloadedPvz(ctx context.Context, threshold float64) ([]models.PvzLoad, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to get pvz with utilization above %.2f", threshold))
+
+	rows, err := p.Db.QueryContext(ctx, GetOverloadedPvzQuery, threshold, models.ProductStored, models.ProductReturned, models.Cancelled)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return nil, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error getting pvz utilization: %s", err.Error()))
+		return nil, fmt.Errorf("unable to get pvz utilization: %v", err)
+	}
+	defer rows.Close()
+
+	var loads []models.PvzLoad
+	for rows.Next() {
+		var (
+			pvz    postgres_models.PostgresPvz
+			onHand int
+		)
+
+		if err = rows.Scan(append(pvzFields(&pvz), &onHand)...); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
+			return nil, err
+		}
+
+		loads = append(loads, models.PvzLoad{Pvz: postgres_models.ToPvz(pvz), OnHand: onHand})
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Found %d overloaded pvz", len(loads)))
+	return loads, nil
+}
+
+func (p *PostgresPvzRepository) GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	return getPvzSchedule(ctx, p.Db, pvzId)
+}
+
+// ReplacePvzSchedule в одной транзакции заменяет недельное расписание и все исключения ПВЗ
+func (p *PostgresPvzRepository) ReplacePvzSchedule(ctx context.Context, schedule models.PvzSchedule) error {
+	logger.Info(ctx, fmt.Sprintf("Trying to replace schedule of pvz %s", schedule.PvzId))
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return errors.New("unable to replace schedule")
+	}
+	defer tx.Rollback()
+
+	type statement struct {
+		query string
+		args  []any
+	}
+
+	statements := []statement{
+		{UpsertPvzScheduleQuery, []any{schedule.PvzId, schedule.Timezone}},
+		{DeleteWorkingHoursQuery, []any{schedule.PvzId}},
+		{DeleteScheduleExceptionsQuery, []any{schedule.PvzId}},
+	}
+	for _, hours := range schedule.Week {
+		statements = append(statements, statement{
+			CreateWorkingHoursQuery, []any{schedule.PvzId, hours.Weekday, hours.OpensAt, hours.ClosesAt},
+		})
+	}
+	for _, exception := range schedule.Exceptions {
+		statements = append(statements, statement{
+			CreateScheduleExceptionQuery, []any{schedule.PvzId, exception.Date, exception.OpensAt, exception.ClosesAt, exception.Description},
+		})
+	}
+
+	for _, statement := range statements {
+		if _, err = tx.ExecContext(ctx, statement.query, statement.args...); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+				logger.Error(ctx, newErr.Error())
+				return newErr
+			}
+			logger.Error(ctx, fmt.Sprintf("Error replacing schedule: %s", err.Error()))
+			return fmt.Errorf("unable to replace schedule: %v", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return errors.New("unable to replace schedule")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully replaced schedule of pvz %s", schedule.PvzId))
+	return nil
+}
+
+// getPvzSchedule читает расписание ПВЗ, нужен и ПВЗ, и приёмкам. Если расписания нет, возвращается пустое
+func getPvzSchedule(ctx context.Context, db *sql.DB, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	var schedule models.PvzSchedule
+	err := db.QueryRowContext(ctx, GetPvzScheduleQuery, pvzId).Scan(&schedule.PvzId, &schedule.Timezone)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PvzSchedule{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("Error getting schedule: %s", err.Error()))
+		return models.PvzSchedule{}, fmt.Errorf("unable to get schedule: %v", err)
+	}
+
+	rows, err := db.QueryContext(ctx, GetWorkingHoursQuery, pvzId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error getting working hours: %s", err.Error()))
+		return models.PvzSchedule{}, fmt.Errorf("unable to get schedule: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hours models.WorkingHours
+		if err = rows.Scan(&hours.Weekday, &hours.OpensAt, &hours.ClosesAt); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
+			return models.PvzSchedule{}, err
+		}
+		schedule.Week = append(schedule.Week, hours)
+	}
+
+	exceptionRows, err := db.QueryContext(ctx, GetScheduleExceptionsQuery, pvzId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error getting schedule exceptions: %s", err.Error()))
+		return models.PvzSchedule{}, fmt.Errorf("unable to get schedule: %v", err)
+	}
+	defer exceptionRows.Close()
+
+	for exceptionRows.Next() {
+		var exception models.ScheduleException
+		err = exceptionRows.Scan(&exception.Date, &exception.OpensAt, &exception.ClosesAt, &exception.Description)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("Scanning error: %s", err.Error()))
+			return models.PvzSchedule{}, err
+		}
+		schedule.Exceptions = append(schedule.Exceptions, exception)
+	}
+
+	return schedule, nil
+}
+
+// pvzFields - поля ПВЗ в порядке колонок pvz во всех запросах репозитория
+func pvzFields(pvz *postgres_models.PostgresPvz) []any {
+	return []any{
+		&pvz.PvzId, &pvz.PvzRegistrationDate, &pvz.PvzCity, &pvz.PvzAddress, &pvz.PvzLatitude,
+		&pvz.PvzLongitude, &pvz.PvzOpeningHours, &pvz.PvzPhone, &pvz.PvzCapacity, &pvz.PvzDecommissionedAt,
+	}
+}
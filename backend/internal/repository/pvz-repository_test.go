@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
@@ -18,6 +19,10 @@ import (
 	"pvz/internal/repository/mocks"
 )
 
+var pvzColumns = []string{
+	"id", "registration_date", "city", "address", "latitude", "longitude", "opening_hours", "phone", "capacity", "decommissioned_at",
+}
+
 func TestCreatePvz(t *testing.T) {
 	db, mock, cleanup := mocks.SetupMockDB(t)
 	defer cleanup()
@@ -47,11 +52,16 @@ func TestCreatePvz(t *testing.T) {
 					Id:               id,
 					RegistrationDate: date,
 					City:             city,
+					Address:          "ул. Тверская, д. 1",
+					Location:         &models.GeoPoint{Latitude: 55.7578, Longitude: 37.6117},
+					OpeningHours:     "Пн-Вс 09:00-21:00",
+					Phone:            "+74951234567",
+					Capacity:         200,
 				},
 			},
 			mockQuery: func() {
 				mock.ExpectExec(`insert into pvz`).
-					WithArgs(id, date, city).
+					WithArgs(id, date, city, "ул. Тверская, д. 1", 55.7578, 37.6117, "Пн-Вс 09:00-21:00", "+74951234567", 200).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			wantErr: false,
@@ -68,7 +78,7 @@ func TestCreatePvz(t *testing.T) {
 			},
 			mockQuery: func() {
 				mock.ExpectExec(`insert into pvz`).
-					WithArgs(id, date, city).
+					WithArgs(id, date, city, "", nil, nil, "", "", 0).
 					WillReturnError(errors.New("some error"))
 			},
 			wantErr: true,
@@ -90,7 +100,7 @@ func TestCreatePvz(t *testing.T) {
 					Where:   "SQL statement",
 				}
 				mock.ExpectExec(`insert into pvz`).
-					WithArgs(id, date, city).
+					WithArgs(id, date, city, "", nil, nil, "", "", 0).
 					WillReturnError(pgErr)
 			},
 			wantErr: true,
@@ -143,13 +153,13 @@ func TestGetPvzInfo(t *testing.T) {
 			name: "ok",
 			mockQuery: func() {
 				rows := sqlmock.NewRows([]string{
-					"id", "registration_date", "city",
-					"id", "reception_datetime", "status", "pvz_id",
-					"id", "received_at", "type", "reception_id",
+					"id", "registration_date", "city", "address", "latitude", "longitude", "opening_hours", "phone", "capacity", "decommissioned_at",
+					"id", "reception_datetime", "status", "pvz_id", "outside_schedule", "auto_closed",
+					"id", "received_at", "type", "reception_id", "over_capacity", "barcode", "external_order_id", "status",
 				}).AddRow(
-					pvzId, start, city,
-					receptionId, start, string(models.InProgress), pvzId,
-					productId, end, productType, receptionId,
+					pvzId, start, city, "", nil, nil, "", "", 0, nil,
+					receptionId, start, string(models.InProgress), pvzId, false, false,
+					productId, end, productType, receptionId, false, "4600000000017", nil, string(models.ProductStored),
 				)
 				mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzInfoQuery)).
 					WithArgs(form.StartDate, form.EndDate, form.Limit, 0).
@@ -170,13 +180,13 @@ func TestGetPvzInfo(t *testing.T) {
 			name: "scan error",
 			mockQuery: func() {
 				rows := sqlmock.NewRows([]string{
-					"id", "registration_date", "city",
-					"id", "reception_datetime", "status", "pvz_id",
-					"id", "received_at", "type", "reception_id",
+					"id", "registration_date", "city", "address", "latitude", "longitude", "opening_hours", "phone", "capacity", "decommissioned_at",
+					"id", "reception_datetime", "status", "pvz_id", "outside_schedule", "auto_closed",
+					"id", "received_at", "type", "reception_id", "over_capacity", "barcode", "external_order_id", "status",
 				}).AddRow(
-					"invalid-uuid", start, city,
-					receptionId, start, string(models.InProgress), pvzId,
-					productId, end, productType, receptionId,
+					"invalid-uuid", start, city, "", nil, nil, "", "", 0, nil,
+					receptionId, start, string(models.InProgress), pvzId, false, false,
+					productId, end, productType, receptionId, false, "4600000000017", nil, string(models.ProductStored),
 				)
 				mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzInfoQuery)).
 					WithArgs(form.StartDate, form.EndDate, form.Limit, 0).
@@ -222,9 +232,9 @@ func TestGetPvzList(t *testing.T) {
 		{
 			name: "ok",
 			mockQuery: func() {
-				rows := sqlmock.NewRows([]string{"id", "registration_date", "city"}).
-					AddRow(idFirst.String(), dateFirst, cityFirst).
-					AddRow(idSecond.String(), dateSecond, citySecond)
+				rows := sqlmock.NewRows(pvzColumns).
+					AddRow(idFirst.String(), dateFirst, cityFirst, "", nil, nil, "", "", 0, nil).
+					AddRow(idSecond.String(), dateSecond, citySecond, "ул. Баумана, д. 2", 55.7887, 49.1221, "", "", 300, nil)
 
 				mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzListQuery)).
 					WillReturnRows(rows)
@@ -232,7 +242,14 @@ func TestGetPvzList(t *testing.T) {
 			wantErr: false,
 			want: []models.Pvz{
 				{Id: idFirst, RegistrationDate: dateFirst, City: cityFirst},
-				{Id: idSecond, RegistrationDate: dateSecond, City: citySecond},
+				{
+					Id:               idSecond,
+					RegistrationDate: dateSecond,
+					City:             citySecond,
+					Address:          "ул. Баумана, д. 2",
+					Location:         &models.GeoPoint{Latitude: 55.7887, Longitude: 49.1221},
+					Capacity:         300,
+				},
 			},
 		},
 		{
@@ -247,8 +264,8 @@ func TestGetPvzList(t *testing.T) {
 		{
 			name: "scan error",
 			mockQuery: func() {
-				rows := sqlmock.NewRows([]string{"id", "registration_date", "city"}).
-					AddRow("invalid-uuid", dateFirst, cityFirst)
+				rows := sqlmock.NewRows(pvzColumns).
+					AddRow("invalid-uuid", dateFirst, cityFirst, "", nil, nil, "", "", 0, nil)
 
 				mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzListQuery)).
 					WillReturnRows(rows)
@@ -282,3 +299,443 @@ func TestGetPvzList(t *testing.T) {
 		})
 	}
 }
+
+func TestAssignEmployee(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	employee := models.PvzEmployee{
+		UserId:     uuid.NewString(),
+		PvzId:      uuid.New(),
+		AssignedAt: time.Now().Truncate(time.Millisecond),
+	}
+	firstAssignedAt := employee.AssignedAt.Add(-time.Hour)
+	roles := []string{"employee", "senior_employee"}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    models.PvzEmployee
+		expectedErr bool
+	}{
+		{
+			name: "assigned",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.AssignEmployeeQuery)).
+					WithArgs(employee.UserId, employee.PvzId, employee.AssignedAt, "employee,senior_employee").
+					WillReturnRows(sqlmock.NewRows([]string{"assigned_at"}).AddRow(employee.AssignedAt))
+			},
+			expected: employee,
+		},
+		{
+			name: "already assigned keeps original date",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.AssignEmployeeQuery)).
+					WithArgs(employee.UserId, employee.PvzId, employee.AssignedAt, "employee,senior_employee").
+					WillReturnRows(sqlmock.NewRows([]string{"assigned_at"}).AddRow(firstAssignedAt))
+			},
+			expected: models.PvzEmployee{UserId: employee.UserId, PvzId: employee.PvzId, AssignedAt: firstAssignedAt},
+		},
+		{
+			name: "employee or pvz not found",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.AssignEmployeeQuery)).
+					WithArgs(employee.UserId, employee.PvzId, employee.AssignedAt, "employee,senior_employee").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expected: models.PvzEmployee{},
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.AssignEmployeeQuery)).
+					WithArgs(employee.UserId, employee.PvzId, employee.AssignedAt, "employee,senior_employee").
+					WillReturnError(errors.New("db error"))
+			},
+			expected:    models.PvzEmployee{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			got, err := repo.AssignEmployee(context.Background(), employee, roles)
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Equal(t, tt.expected, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUnassignEmployee(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	userId := uuid.NewString()
+	pvzId := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.UnassignEmployeeQuery)).
+		WithArgs(userId, pvzId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	isRemoved, err := repo.UnassignEmployee(context.Background(), userId, pvzId)
+	assert.NoError(t, err)
+	assert.True(t, isRemoved)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.UnassignEmployeeQuery)).
+		WithArgs(userId, pvzId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	isRemoved, err = repo.UnassignEmployee(context.Background(), userId, pvzId)
+	assert.NoError(t, err)
+	assert.False(t, isRemoved)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.UnassignEmployeeQuery)).
+		WithArgs(userId, pvzId).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.UnassignEmployee(context.Background(), userId, pvzId)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPvz(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	pvz := models.Pvz{Id: uuid.New(), RegistrationDate: time.Now().Truncate(time.Millisecond), City: "Москва", Phone: "+74951234567"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzQuery)).
+		WithArgs(pvz.Id).
+		WillReturnRows(sqlmock.NewRows(pvzColumns).AddRow(pvz.Id, pvz.RegistrationDate, pvz.City, "", nil, nil, "", pvz.Phone, 0, nil))
+	got, err := repo.GetPvz(context.Background(), pvz.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, pvz, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzQuery)).
+		WithArgs(pvz.Id).
+		WillReturnError(sql.ErrNoRows)
+	got, err = repo.GetPvz(context.Background(), pvz.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Pvz{}, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzQuery)).
+		WithArgs(pvz.Id).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.GetPvz(context.Background(), pvz.Id)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdatePvz(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	decommissionedAt := time.Now().Truncate(time.Millisecond)
+	city := "Казань"
+	openingHours := "Пн-Пт 10:00-20:00"
+	capacity := 150
+	pvz := models.Pvz{
+		Id:               uuid.New(),
+		RegistrationDate: decommissionedAt.Add(-time.Hour),
+		City:             city,
+		Location:         &models.GeoPoint{Latitude: 55.7887, Longitude: 49.1221},
+		OpeningHours:     openingHours,
+		Capacity:         capacity,
+		DecommissionedAt: decommissionedAt,
+	}
+	form := forms.UpdatePvzForm{
+		City:         &city,
+		Location:     &forms.LocationForm{Latitude: 55.7887, Longitude: 49.1221},
+		OpeningHours: &openingHours,
+		Capacity:     &capacity,
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdatePvzQuery)).
+		WithArgs(pvz.Id, city, nil, 55.7887, 49.1221, openingHours, nil, capacity).
+		WillReturnRows(sqlmock.NewRows(pvzColumns).
+			AddRow(pvz.Id, pvz.RegistrationDate, city, "", 55.7887, 49.1221, openingHours, "", capacity, decommissionedAt))
+	got, err := repo.UpdatePvz(context.Background(), pvz.Id, form)
+	assert.NoError(t, err)
+	assert.Equal(t, pvz, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdatePvzQuery)).
+		WithArgs(pvz.Id, city, nil, 55.7887, 49.1221, openingHours, nil, capacity).
+		WillReturnError(sql.ErrNoRows)
+	got, err = repo.UpdatePvz(context.Background(), pvz.Id, form)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Pvz{}, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.UpdatePvzQuery)).
+		WithArgs(pvz.Id, city, nil, 55.7887, 49.1221, openingHours, nil, capacity).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.UpdatePvz(context.Background(), pvz.Id, form)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNearbyPvz(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	form := forms.NearbyPvzForm{Latitude: 55.7558, Longitude: 37.6173, Radius: 5000, Limit: 50}
+	pvz := models.Pvz{
+		Id:               uuid.New(),
+		RegistrationDate: time.Now().Truncate(time.Millisecond),
+		City:             "Москва",
+		Address:          "ул. Тверская, д. 1",
+		Location:         &models.GeoPoint{Latitude: 55.7578, Longitude: 37.6117},
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.NearbyPvzQuery)).
+		WithArgs(form.Latitude, form.Longitude, form.Radius, form.Limit).
+		WillReturnRows(sqlmock.NewRows(append(pvzColumns, "distance")).
+			AddRow(pvz.Id, pvz.RegistrationDate, pvz.City, pvz.Address, 55.7578, 37.6117, "", "", 0, nil, 412.5))
+	got, err := repo.GetNearbyPvz(context.Background(), form)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.NearbyPvz{{Pvz: pvz, Distance: 412.5}}, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.NearbyPvzQuery)).
+		WithArgs(form.Latitude, form.Longitude, form.Radius, form.Limit).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.GetNearbyPvz(context.Background(), form)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOverloadedPvz(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	pvz := models.Pvz{
+		Id:               uuid.New(),
+		RegistrationDate: time.Now().Truncate(time.Millisecond),
+		City:             "Москва",
+		Capacity:         100,
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetOverloadedPvzQuery)).
+		WithArgs(0.9, models.ProductStored, models.ProductReturned, models.Cancelled).
+		WillReturnRows(sqlmock.NewRows(append(pvzColumns, "on_hand")).
+			AddRow(pvz.Id, pvz.RegistrationDate, pvz.City, "", nil, nil, "", "", 100, nil, 95))
+	got, err := repo.GetOverloadedPvz(context.Background(), 0.9)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.PvzLoad{{Pvz: pvz, OnHand: 95}}, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetOverloadedPvzQuery)).
+		WithArgs(0.9, models.ProductStored, models.ProductReturned, models.Cancelled).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.GetOverloadedPvz(context.Background(), 0.9)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDecommissionPvz(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	pvzId := uuid.New()
+	decommissionedAt := time.Now().Truncate(time.Millisecond)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.DecommissionPvzQuery)).
+		WithArgs(pvzId, decommissionedAt, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	isDecommissioned, err := repo.DecommissionPvz(context.Background(), pvzId, decommissionedAt)
+	assert.NoError(t, err)
+	assert.True(t, isDecommissioned)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.DecommissionPvzQuery)).
+		WithArgs(pvzId, decommissionedAt, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	isDecommissioned, err = repo.DecommissionPvz(context.Background(), pvzId, decommissionedAt)
+	assert.NoError(t, err)
+	assert.False(t, isDecommissioned)
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.DecommissionPvzQuery)).
+		WithArgs(pvzId, decommissionedAt, models.InProgress).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.DecommissionPvz(context.Background(), pvzId, decommissionedAt)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsCityAvailable(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.IsCityAvailableQuery)).
+		WithArgs("Казань").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	isAvailable, err := repo.IsCityAvailable(context.Background(), "Казань")
+	assert.NoError(t, err)
+	assert.True(t, isAvailable)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.IsCityAvailableQuery)).
+		WithArgs("Казань").
+		WillReturnError(errors.New("db error"))
+	_, err = repo.IsCityAvailable(context.Background(), "Казань")
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPvzSchedule(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	pvzId := uuid.New()
+	holiday := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzScheduleQuery)).
+		WithArgs(pvzId).
+		WillReturnRows(sqlmock.NewRows([]string{"pvz_id", "timezone"}).AddRow(pvzId, "Europe/Moscow"))
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetWorkingHoursQuery)).
+		WithArgs(pvzId).
+		WillReturnRows(sqlmock.NewRows([]string{"weekday", "opens_at", "closes_at"}).AddRow(1, "09:00", "21:00"))
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetScheduleExceptionsQuery)).
+		WithArgs(pvzId).
+		WillReturnRows(sqlmock.NewRows([]string{"day", "opens_at", "closes_at", "description"}).AddRow(holiday, "", "", "Новый год"))
+	got, err := repo.GetPvzSchedule(context.Background(), pvzId)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PvzSchedule{
+		PvzId:      pvzId,
+		Timezone:   "Europe/Moscow",
+		Week:       []models.WorkingHours{{Weekday: 1, OpensAt: "09:00", ClosesAt: "21:00"}},
+		Exceptions: []models.ScheduleException{{Date: holiday, Description: "Новый год"}},
+	}, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzScheduleQuery)).
+		WithArgs(pvzId).
+		WillReturnError(sql.ErrNoRows)
+	got, err = repo.GetPvzSchedule(context.Background(), pvzId)
+	assert.NoError(t, err)
+	assert.False(t, got.IsConfigured())
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzScheduleQuery)).
+		WithArgs(pvzId).
+		WillReturnError(errors.New("db error"))
+	_, err = repo.GetPvzSchedule(context.Background(), pvzId)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplacePvzSchedule(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	holiday := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := models.PvzSchedule{
+		PvzId:      uuid.New(),
+		Timezone:   "Europe/Moscow",
+		Week:       []models.WorkingHours{{Weekday: 1, OpensAt: "09:00", ClosesAt: "21:00"}},
+		Exceptions: []models.ScheduleException{{Date: holiday, Description: "Новый год"}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.UpsertPvzScheduleQuery)).
+		WithArgs(schedule.PvzId, schedule.Timezone).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteWorkingHoursQuery)).
+		WithArgs(schedule.PvzId).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteScheduleExceptionsQuery)).
+		WithArgs(schedule.PvzId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateWorkingHoursQuery)).
+		WithArgs(schedule.PvzId, 1, "09:00", "21:00").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateScheduleExceptionQuery)).
+		WithArgs(schedule.PvzId, holiday, "", "", "Новый год").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, repo.ReplacePvzSchedule(context.Background(), schedule))
+
+	// ошибка на любом шаге откатывает всю замену
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.UpsertPvzScheduleQuery)).
+		WithArgs(schedule.PvzId, schedule.Timezone).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteWorkingHoursQuery)).
+		WithArgs(schedule.PvzId).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+	assert.Error(t, repo.ReplacePvzSchedule(context.Background(), schedule))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsEmployeeAssigned(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresPvzRepository{Db: db}
+	userId := uuid.NewString()
+	pvzId := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name: "assigned",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.IsEmployeeAssignedQuery)).
+					WithArgs(userId, pvzId).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expected: true,
+		},
+		{
+			name: "not assigned",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.IsEmployeeAssignedQuery)).
+					WithArgs(userId, pvzId).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expected: false,
+		},
+		{
+			name: "query error",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.IsEmployeeAssignedQuery)).
+					WithArgs(userId, pvzId).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			isAssigned, err := repo.IsEmployeeAssigned(context.Background(), userId, pvzId)
+			if tt.expectedErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.expectedErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if isAssigned != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, isAssigned)
+			}
+		})
+	}
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -13,14 +15,16 @@ import (
 
 	"pvz/config/postgres"
 	"pvz/internal/models"
+	"pvz/internal/models/postgres-models"
 	"pvz/pkg/logger"
 )
 
 const (
 	CreateReceptionQuery = `
-		insert into reception (id, reception_datetime, pvz_id, status)
-		select $1, $2, $3, $4
-		where not exists (
+		insert into reception (id, reception_datetime, pvz_id, status, outside_schedule)
+		select $1, $2, $3, $4, $5
+		where exists (select 1 from pvz where id = $3 and decommissioned_at is null)
+		and not exists (
 			select id from reception
 			where pvz_id = $3 AND status = $4
 		)
@@ -33,8 +37,24 @@ const (
 	`
 
 	AddProductToOpenReceptionQuery = `
-		insert into product (id, received_at, type, reception_id)
-		values ($1, $2, $3, $4)
+		insert into product (id, received_at, type, reception_id, over_capacity, barcode, external_order_id)
+		values ($1, $2, $3, $4, $5, nullif($6, ''), nullif($7, ''))
+	`
+
+	GetReceivedBarcodesQuery = `
+		select barcode from product
+		where reception_id = $1 and barcode = any($2)
+	`
+
+	// по штрихкоду товар ищется во всех ПВЗ, последние приёмки идут первыми
+	SearchProductsByBarcodeQuery = `
+		select pr.id, pr.received_at, pr.type, pr.reception_id, pr.over_capacity, pr.barcode, pr.external_order_id, pr.status,
+		  r.pvz_id, r.status, pvz.city
+		from product pr
+		join reception r on r.id = pr.reception_id
+		join pvz on pvz.id = r.pvz_id
+		where pr.barcode = $1
+		order by pr.received_at desc
 	`
 
 	DeleteLastProductFromOpenReceptionQuery = `
@@ -48,12 +68,135 @@ const (
 		returning id, received_at, type, reception_id
 	`
 
+	// удалить товар можно только из открытой приёмки
+	DeleteProductFromOpenReceptionQuery = `
+		delete from product
+		where id = $2 and reception_id = (select id from reception where id = $1 and status = $3)
+	`
+
+	// закрыть можно только открытую приёмку: её могли отменить или закрыть с момента чтения
 	CloseReceptionQuery = `
-		update reception set status = $2
-		where id = $1
+		update reception set status = $2, auto_closed = $3
+		where id = $1 and status = $4
+	`
+
+	IsCategoryActiveQuery = `
+		select exists (select 1 from category where code = $1 and active)
+	`
+
+	ListReceptionsQuery = `
+		select id, reception_datetime, pvz_id, status, outside_schedule, auto_closed
+		from reception
+		where pvz_id = $1 and ($2 = '' or status = $2) and reception_datetime between $3 and $4
+		order by reception_datetime desc
+		limit $5 offset $6
+	`
+
+	GetReceptionQuery = `
+		select r.id, r.reception_datetime, r.pvz_id, r.status, r.outside_schedule, r.auto_closed,
+		  pr.id, pr.received_at, pr.type, pr.reception_id, pr.over_capacity, pr.barcode, pr.external_order_id, pr.status
+		from reception r
+		left join product pr on pr.reception_id = r.id
+		where r.id = $1
+		order by pr.received_at
+	`
+
+	// на складе лежат товары, ждущие клиента, и возвраты, ещё не отправленные обратно. Товары отменённых приёмок не считаются
+	GetPvzLoadQuery = `
+		select pvz.capacity,` + onHandSubquery + `
+		from pvz
+		where pvz.id = $1
+	`
+
+	// переоткрыть можно закрытую приёмку действующего ПВЗ, если у него нет другой открытой
+	ReopenReceptionQuery = `
+		update reception r set status = $2, auto_closed = false
+		where r.id = $1 and r.status = $3
+		  and exists (select 1 from pvz where pvz.id = r.pvz_id and pvz.decommissioned_at is null)
+		  and not exists (select 1 from reception o where o.pvz_id = r.pvz_id and o.status = $2)
+	`
+
+	// статус меняется, только если его не успели поменять с момента чтения приёмки
+	ChangeReceptionStatusQuery = `
+		update reception set status = $3
+		where id = $1 and status = $2
+	`
+
+	// приёмка простаивает, если с момента открытия, последнего товара и последнего переоткрытия прошло больше idle
+	ListStaleReceptionsQuery = `
+		select r.id, r.reception_datetime, r.pvz_id, r.status, r.outside_schedule, r.auto_closed
+		from reception r
+		where r.status = $1 and r.reception_datetime < $2
+		  and not exists (select 1 from product pr where pr.reception_id = r.id and pr.received_at >= $2)
+		  and not exists (select 1 from audit_log a where a.target_id = r.id and a.action = $3 and a.created_at >= $2)
+		order by r.reception_datetime
+	`
+
+	GetReceptionHistoryQuery = `
+		select id, actor_id, action, target_id, details, created_at
+		from audit_log
+		where target_id = $1
+		order by created_at
+	`
+
+	DeletePendingManifestQuery = `
+		delete from manifest
+		where pvz_id = $1 and reception_id is null
+	`
+
+	CreateManifestQuery = `
+		insert into manifest (id, pvz_id, created_at)
+		select $1, $2, $3
+		where exists (select 1 from pvz where id = $2 and decommissioned_at is null)
+	`
+
+	CreateManifestItemQuery = `
+		insert into manifest_item (manifest_id, barcode, type)
+		values ($1, $2, $3)
+	`
+
+	// переоткрытая приёмка сверяется с манифестом, привязанным при первом закрытии, а не с ждущим следующую приёмку
+	GetReceptionManifestQuery = `
+		select m.id, m.pvz_id, m.created_at, i.barcode, i.type
+		from manifest m
+		left join manifest_item i on i.manifest_id = m.id
+		where m.id = coalesce(
+		  (select id from manifest where reception_id = $2),
+		  (select id from manifest where pvz_id = $1 and reception_id is null))
+		order by i.barcode
+	`
+
+	// манифест привязывается к приёмке, только если его не заменили с момента чтения.
+	// Манифест переоткрытой приёмки уже привязан к ней
+	BindManifestQuery = `
+		update manifest set reception_id = $2
+		where id = $1 and (reception_id is null or reception_id = $2)
+	`
+
+	// при переоткрытии отчёт о расхождениях устаревает и строится заново при следующем закрытии
+	DeleteDiscrepanciesQuery = `
+		delete from reception_discrepancy where reception_id = $1
+	`
+
+	CreateDiscrepancyQuery = `
+		insert into reception_discrepancy (reception_id, kind, barcode, product_id, expected_type, actual_type)
+		values ($1, $2, nullif($3, ''), $4, nullif($5, ''), nullif($6, ''))
+	`
+
+	// отчёт есть только у закрытой приёмки: у переоткрытой манифест остаётся привязан, но расхождений ещё нет
+	GetDiscrepancyReportQuery = `
+		select m.id, d.kind, d.barcode, d.product_id, d.expected_type, d.actual_type
+		from manifest m
+		join reception r on r.id = m.reception_id
+		left join reception_discrepancy d on d.reception_id = m.reception_id
+		where m.reception_id = $1 and r.status in ($2, $3)
+		order by d.kind, d.barcode
 	`
 )
 
+// uniqueViolationCode - код ошибки postgres при нарушении уникального индекса
+const uniqueViolationCode = "23505"
+
 type PostgresReceptionRepository struct {
 	Db *sql.DB
 }
@@ -74,7 +217,8 @@ func (p *PostgresReceptionRepository) Close() {
 func (p *PostgresReceptionRepository) CreateReception(ctx context.Context, reception models.Reception) error {
 	logger.Info(ctx, "Trying to create reception")
 
-	commandTag, err := p.Db.ExecContext(ctx, CreateReceptionQuery, reception.Id, reception.DateTime, reception.PvzId, reception.Status)
+	commandTag, err := p.Db.ExecContext(ctx, CreateReceptionQuery, reception.Id, reception.DateTime, reception.PvzId, reception.Status,
+		reception.OutsideSchedule)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -87,14 +231,57 @@ func (p *PostgresReceptionRepository) CreateReception(ctx context.Context, recep
 	}
 
 	if rows, _ := commandTag.RowsAffected(); rows == 0 {
-		logger.Error(ctx, "One reception was not closed or non-existing pvzId was given")
-		return errors.New("one reception was not closed or non-existing pvzId was given")
+		logger.Error(ctx, "One reception was not closed, non-existing pvzId was given or pvz is decommissioned")
+		return errors.New("one reception was not closed or non-existing pvzId was given, or pvz is decommissioned")
 	}
 
 	logger.Info(ctx, fmt.Sprintf("Successfully created reception with Id: %s", reception.Id))
 	return nil
 }
 
+// CreateReceptionOutsideSchedule в одной транзакции создаёт приёмку вне часов работы ПВЗ и пишет запись в журнал,
+// по которой её проверяет модератор
+func (p *PostgresReceptionRepository) CreateReceptionOutsideSchedule(ctx context.Context, reception models.Reception, entry models.AuditEntry) error {
+	logger.Info(ctx, "Trying to create reception outside working hours")
+
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return fmt.Errorf("unable to encode audit details: %v", err)
+	}
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return errors.New("unable to create reception")
+	}
+	defer tx.Rollback()
+
+	commandTag, err := tx.ExecContext(ctx, CreateReceptionQuery, reception.Id, reception.DateTime, reception.PvzId, reception.Status,
+		reception.OutsideSchedule)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error creating reception: %s", err.Error()))
+		return fmt.Errorf("unable to create reception: %v", err)
+	}
+
+	if rows, _ := commandTag.RowsAffected(); rows == 0 {
+		logger.Error(ctx, "One reception was not closed, non-existing pvzId was given or pvz is decommissioned")
+		return errors.New("one reception was not closed or non-existing pvzId was given, or pvz is decommissioned")
+	}
+
+	if _, err = tx.ExecContext(ctx, CreateAuditEntryQuery, entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to write audit entry: %v", err))
+		return errors.New("unable to create reception")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return errors.New("unable to create reception")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully created reception with Id: %s outside working hours", reception.Id))
+	return nil
+}
+
 func (p *PostgresReceptionRepository) GetOpenReception(ctx context.Context, pvzId uuid.UUID) (models.Reception, error) {
 	logger.Info(ctx, "Trying to get open reception")
 
@@ -119,7 +306,8 @@ func (p *PostgresReceptionRepository) GetOpenReception(ctx context.Context, pvzI
 func (p *PostgresReceptionRepository) AddProduct(ctx context.Context, product models.Product) error {
 	logger.Info(ctx, "Trying to add product")
 
-	_, err := p.Db.ExecContext(ctx, AddProductToOpenReceptionQuery, product.Id, product.DateTime, product.ProductType, product.ReceptionId)
+	_, err := p.Db.ExecContext(ctx, AddProductToOpenReceptionQuery, product.Id, product.DateTime, product.ProductType, product.ReceptionId,
+		product.OverCapacity, product.Barcode, product.ExternalOrderId)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -136,6 +324,48 @@ func (p *PostgresReceptionRepository) AddProduct(ctx context.Context, product mo
 	return nil
 }
 
+// AddProducts добавляет пакет товаров в одной транзакции: либо все, либо ни одного
+func (p *PostgresReceptionRepository) AddProducts(ctx context.Context, products []models.Product) error {
+	logger.Info(ctx, fmt.Sprintf("Trying to add batch of %d products", len(products)))
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return errors.New("unable to add products")
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, AddProductToOpenReceptionQuery)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to prepare statement: %v", err))
+		return errors.New("unable to add products")
+	}
+	defer stmt.Close()
+
+	for _, product := range products {
+		_, err = stmt.ExecContext(ctx, product.Id, product.DateTime, product.ProductType, product.ReceptionId, product.OverCapacity,
+			product.Barcode, product.ExternalOrderId)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+				logger.Error(ctx, newErr.Error())
+				return newErr
+			}
+			logger.Error(ctx, fmt.Sprintf("Error adding product %s: %s", product.Id, err.Error()))
+			return fmt.Errorf("unable to add products: %v", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return errors.New("unable to add products")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully added %d products", len(products)))
+	return nil
+}
+
 func (p *PostgresReceptionRepository) RemoveProduct(ctx context.Context, receptionId uuid.UUID) error {
 	logger.Info(ctx, "Trying to remove product")
 
@@ -157,22 +387,598 @@ func (p *PostgresReceptionRepository) RemoveProduct(ctx context.Context, recepti
 	return nil
 }
 
-func (p *PostgresReceptionRepository) CloseReception(ctx context.Context, receptionData models.Reception) error {
+// DeleteProduct удаляет товар по id, false означает, что товара нет или приёмка уже закрыта
+func (p *PostgresReceptionRepository) DeleteProduct(ctx context.Context, receptionId uuid.UUID, productId uuid.UUID) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to delete product %s from reception %s", productId, receptionId))
+
+	commandTag, err := p.Db.ExecContext(ctx, DeleteProductFromOpenReceptionQuery, receptionId, productId, models.InProgress)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to delete product %s: %v", productId, err))
+		return false, errors.New("unable to delete product")
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+// CloseReception закрывает открытую приёмку. false означает, что приёмка уже не открыта
+func (p *PostgresReceptionRepository) CloseReception(ctx context.Context, receptionData models.Reception) (bool, error) {
 	logger.Info(ctx, "Trying to close reception")
 
-	_, err := p.Db.ExecContext(ctx, CloseReceptionQuery, receptionData.Id, models.Closed)
+	commandTag, err := p.Db.ExecContext(ctx, CloseReceptionQuery, receptionData.Id, models.Closed, receptionData.AutoClosed, models.InProgress)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
 			logger.Error(ctx, newErr.Error())
-			return newErr
+			return false, newErr
 		}
 
 		logger.Error(ctx, fmt.Sprintf("Error closint reception with id: %s. Error: %s", receptionData.Id, err.Error()))
-		return fmt.Errorf("unable to close reception: %v", err)
+		return false, fmt.Errorf("unable to close reception: %v", err)
+	}
+
+	if rows, _ := commandTag.RowsAffected(); rows == 0 {
+		logger.Error(ctx, fmt.Sprintf("Reception %s is not in progress anymore", receptionData.Id))
+		return false, nil
 	}
 
 	logger.Info(ctx, fmt.Sprintf("Successfully closed reception with id: %s", receptionData.Id))
-	return nil
+	return true, nil
+}
+
+func (p *PostgresReceptionRepository) IsCategoryActive(ctx context.Context, code string) (bool, error) {
+	var isActive bool
+	if err := p.Db.QueryRowContext(ctx, IsCategoryActiveQuery, code).Scan(&isActive); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to check category %s: %v", code, err))
+		return false, errors.New("unable to check category")
+	}
+
+	return isActive, nil
+}
+
+func (p *PostgresReceptionRepository) GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	return getPvzSchedule(ctx, p.Db, pvzId)
+}
+
+// GetPvzLoad возвращает вместимость ПВЗ и число товаров на складе. Для несуществующего ПВЗ загрузка нулевая
+func (p *PostgresReceptionRepository) GetPvzLoad(ctx context.Context, pvzId uuid.UUID) (models.PvzLoad, error) {
+	load := models.PvzLoad{Pvz: models.Pvz{Id: pvzId}}
+	err := p.Db.QueryRowContext(ctx, GetPvzLoadQuery, pvzId, models.ProductStored, models.ProductReturned, models.Cancelled).Scan(&load.Pvz.Capacity, &load.OnHand)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.PvzLoad{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to get load of pvz %s: %v", pvzId, err))
+		return models.PvzLoad{}, errors.New("unable to get pvz load")
+	}
+
+	return load, nil
+}
+
+// ListReceptions возвращает историю приёмок ПВЗ, новые идут первыми
+func (p *PostgresReceptionRepository) ListReceptions(ctx context.Context, filter models.ReceptionFilter) ([]models.Reception, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to list receptions of pvz %s, status: %q", filter.PvzId, filter.Status))
+
+	rows, err := p.Db.QueryContext(ctx, ListReceptionsQuery, filter.PvzId, filter.Status, filter.StartDate, filter.EndDate,
+		filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to list receptions: %v", err))
+		return nil, errors.New("unable to list receptions")
+	}
+	defer rows.Close()
+
+	receptions := make([]models.Reception, 0)
+	for rows.Next() {
+		var reception postgres_models.PostgresReception
+		err = rows.Scan(&reception.ReceptionId, &reception.ReceptionTime, &reception.PvzId, &reception.ReceptionStatus,
+			&reception.ReceptionOutsideSchedule, &reception.ReceptionAutoClosed)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan reception: %v", err))
+			return nil, errors.New("unable to list receptions")
+		}
+		receptions = append(receptions, postgres_models.ToReception(reception))
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate receptions: %v", err))
+		return nil, errors.New("unable to list receptions")
+	}
+
+	return receptions, nil
+}
+
+// ListStaleReceptions возвращает открытые приёмки, в которых ничего не происходило с момента idleSince
+func (p *PostgresReceptionRepository) ListStaleReceptions(ctx context.Context, idleSince time.Time) ([]models.Reception, error) {
+	rows, err := p.Db.QueryContext(ctx, ListStaleReceptionsQuery, models.InProgress, idleSince, models.AuditReceptionReopen)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to list stale receptions: %v", err))
+		return nil, errors.New("unable to list stale receptions")
+	}
+	defer rows.Close()
+
+	receptions := make([]models.Reception, 0)
+	for rows.Next() {
+		var reception postgres_models.PostgresReception
+		err = rows.Scan(&reception.ReceptionId, &reception.ReceptionTime, &reception.PvzId, &reception.ReceptionStatus,
+			&reception.ReceptionOutsideSchedule, &reception.ReceptionAutoClosed)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan reception: %v", err))
+			return nil, errors.New("unable to list stale receptions")
+		}
+		receptions = append(receptions, postgres_models.ToReception(reception))
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate stale receptions: %v", err))
+		return nil, errors.New("unable to list stale receptions")
+	}
+
+	return receptions, nil
+}
+
+// GetReception возвращает приёмку с товарами в порядке приёма. Если приёмки нет, возвращается пустая
+func (p *PostgresReceptionRepository) GetReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to get reception %s", receptionId))
+
+	rows, err := p.Db.QueryContext(ctx, GetReceptionQuery, receptionId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get reception: %v", err))
+		return models.ReceptionProducts{}, errors.New("unable to get reception")
+	}
+	defer rows.Close()
+
+	result := models.ReceptionProducts{Products: []models.Product{}}
+	for rows.Next() {
+		var (
+			reception postgres_models.PostgresReception
+			product   postgres_models.PostgresProduct
+		)
+
+		err = rows.Scan(&reception.ReceptionId, &reception.ReceptionTime, &reception.PvzId, &reception.ReceptionStatus,
+			&reception.ReceptionOutsideSchedule, &reception.ReceptionAutoClosed,
+			&product.ProductId, &product.ProductReceivedAt, &product.ProductType, &product.ProductReceptionId,
+			&product.ProductOverCapacity, &product.ProductBarcode, &product.ProductExternalOrderId, &product.ProductStatus)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan reception: %v", err))
+			return models.ReceptionProducts{}, errors.New("unable to get reception")
+		}
+
+		result.Reception = postgres_models.ToReception(reception)
+		if product.ProductId != uuid.Nil {
+			result.Products = append(result.Products, postgres_models.ToProduct(product))
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate reception products: %v", err))
+		return models.ReceptionProducts{}, errors.New("unable to get reception")
+	}
+
+	if result.Reception.Id == uuid.Nil {
+		logger.Error(ctx, fmt.Sprintf("Reception %s does not exist", receptionId))
+		return models.ReceptionProducts{}, nil
+	}
+
+	return result, nil
+}
+
+// GetReceivedBarcodes возвращает те из переданных штрихкодов, что уже приняты в приёмку
+func (p *PostgresReceptionRepository) GetReceivedBarcodes(ctx context.Context, receptionId uuid.UUID, barcodes []string) ([]string, error) {
+	rows, err := p.Db.QueryContext(ctx, GetReceivedBarcodesQuery, receptionId, barcodes)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to check barcodes of reception %s: %v", receptionId, err))
+		return nil, errors.New("unable to check barcodes")
+	}
+	defer rows.Close()
+
+	received := make([]string, 0)
+	for rows.Next() {
+		var barcode string
+		if err = rows.Scan(&barcode); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan barcode: %v", err))
+			return nil, errors.New("unable to check barcodes")
+		}
+		received = append(received, barcode)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate barcodes: %v", err))
+		return nil, errors.New("unable to check barcodes")
+	}
+
+	return received, nil
+}
+
+func (p *PostgresReceptionRepository) SearchProducts(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to search products by barcode %s", barcode))
+
+	rows, err := p.Db.QueryContext(ctx, SearchProductsByBarcodeQuery, barcode)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to search products: %v", err))
+		return nil, errors.New("unable to search products")
+	}
+	defer rows.Close()
+
+	locations := make([]models.ProductLocation, 0)
+	for rows.Next() {
+		var (
+			product  postgres_models.PostgresProduct
+			location models.ProductLocation
+		)
+
+		err = rows.Scan(&product.ProductId, &product.ProductReceivedAt, &product.ProductType, &product.ProductReceptionId,
+			&product.ProductOverCapacity, &product.ProductBarcode, &product.ProductExternalOrderId, &product.ProductStatus,
+			&location.PvzId, &location.ReceptionStatus, &location.City)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan product: %v", err))
+			return nil, errors.New("unable to search products")
+		}
+
+		location.Product = postgres_models.ToProduct(product)
+		locations = append(locations, location)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate products: %v", err))
+		return nil, errors.New("unable to search products")
+	}
+
+	return locations, nil
+}
+
+// ReplaceManifest в одной транзакции заменяет манифест, ждущий следующую приёмку ПВЗ.
+// false означает, что ПВЗ нет или он выведен из эксплуатации
+func (p *PostgresReceptionRepository) ReplaceManifest(ctx context.Context, manifest models.Manifest) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to replace manifest of pvz %s", manifest.PvzId))
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return false, errors.New("unable to replace manifest")
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, DeletePendingManifestQuery, manifest.PvzId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to delete pending manifest: %v", err))
+		return false, errors.New("unable to replace manifest")
+	}
+
+	commandTag, err := tx.ExecContext(ctx, CreateManifestQuery, manifest.Id, manifest.PvzId, manifest.CreatedAt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to create manifest: %v", err))
+		return false, errors.New("unable to replace manifest")
+	}
+
+	if rows, _ := commandTag.RowsAffected(); rows == 0 {
+		logger.Error(ctx, fmt.Sprintf("Pvz %s does not exist or is decommissioned", manifest.PvzId))
+		return false, nil
+	}
+
+	for _, item := range manifest.Items {
+		if _, err = tx.ExecContext(ctx, CreateManifestItemQuery, manifest.Id, item.Barcode, item.ProductType); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+				logger.Error(ctx, newErr.Error())
+				return false, newErr
+			}
+			logger.Error(ctx, fmt.Sprintf("Error adding manifest item %s: %s", item.Barcode, err.Error()))
+			return false, fmt.Errorf("unable to replace manifest: %v", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return false, errors.New("unable to replace manifest")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully replaced manifest of pvz %s with %d items", manifest.PvzId, len(manifest.Items)))
+	return true, nil
+}
+
+// GetReceptionManifest возвращает манифест, уже привязанный к приёмке, а если его нет - ждущий следующую приёмку ПВЗ.
+// Если манифеста нет, возвращается пустой
+func (p *PostgresReceptionRepository) GetReceptionManifest(ctx context.Context, reception models.Reception) (models.Manifest, error) {
+	rows, err := p.Db.QueryContext(ctx, GetReceptionManifestQuery, reception.PvzId, reception.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get manifest of reception %s: %v", reception.Id, err))
+		return models.Manifest{}, errors.New("unable to get manifest")
+	}
+	defer rows.Close()
+
+	manifest := models.Manifest{Items: []models.ManifestItem{}}
+	for rows.Next() {
+		var barcode, productType sql.NullString
+		if err = rows.Scan(&manifest.Id, &manifest.PvzId, &manifest.CreatedAt, &barcode, &productType); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan manifest: %v", err))
+			return models.Manifest{}, errors.New("unable to get manifest")
+		}
+
+		if barcode.Valid {
+			manifest.Items = append(manifest.Items, models.ManifestItem{Barcode: barcode.String, ProductType: productType.String})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate manifest items: %v", err))
+		return models.Manifest{}, errors.New("unable to get manifest")
+	}
+
+	if manifest.Id == uuid.Nil {
+		return models.Manifest{}, nil
+	}
+
+	return manifest, nil
+}
+
+// CloseReceptionWithReport в одной транзакции закрывает приёмку, привязывает к ней манифест и сохраняет расхождения.
+// false означает, что приёмка уже не открыта
+func (p *PostgresReceptionRepository) CloseReceptionWithReport(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to close reception %s with discrepancy report", receptionData.Id))
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return false, errors.New("unable to close reception")
+	}
+	defer tx.Rollback()
+
+	isClosed, err := closeReceptionTx(ctx, tx, receptionData, report)
+	if err != nil || !isClosed {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return false, errors.New("unable to close reception")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully closed reception %s with %d discrepancies", receptionData.Id, len(report.Items)))
+	return true, nil
+}
+
+// AutoCloseReception закрывает простаивающую приёмку от имени системы: закрытие, отчёт о расхождениях
+// (если приёмку сверяли с манифестом) и запись в журнал сохраняются в одной транзакции
+func (p *PostgresReceptionRepository) AutoCloseReception(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport, entry models.AuditEntry) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to close stale reception %s", receptionData.Id))
+
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return false, fmt.Errorf("unable to encode audit details: %v", err)
+	}
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return false, errors.New("unable to close reception")
+	}
+	defer tx.Rollback()
+
+	isClosed, err := closeReceptionTx(ctx, tx, receptionData, report)
+	if err != nil || !isClosed {
+		return false, err
+	}
+
+	if _, err = tx.ExecContext(ctx, CreateAuditEntryQuery, entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to write audit entry: %v", err))
+		return false, errors.New("unable to close reception")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return false, errors.New("unable to close reception")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully closed stale reception %s with %d discrepancies", receptionData.Id, len(report.Items)))
+	return true, nil
+}
+
+// closeReceptionTx закрывает приёмку внутри tx. Если отчёт построен по манифесту, манифест привязывается
+// к приёмке, а расхождения сохраняются. false без ошибки - приёмка уже не в работе
+func closeReceptionTx(ctx context.Context, tx *sql.Tx, receptionData models.Reception, report models.DiscrepancyReport) (bool, error) {
+	commandTag, err := tx.ExecContext(ctx, CloseReceptionQuery, receptionData.Id, models.Closed, receptionData.AutoClosed, models.InProgress)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error closing reception with id: %s. Error: %s", receptionData.Id, err.Error()))
+		return false, fmt.Errorf("unable to close reception: %v", err)
+	}
+
+	if rows, _ := commandTag.RowsAffected(); rows == 0 {
+		logger.Error(ctx, fmt.Sprintf("Reception %s is not in progress anymore", receptionData.Id))
+		return false, nil
+	}
+
+	if report.ManifestId == uuid.Nil {
+		return true, nil
+	}
+
+	commandTag, err = tx.ExecContext(ctx, BindManifestQuery, report.ManifestId, receptionData.Id)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to bind manifest %s: %v", report.ManifestId, err))
+		return false, errors.New("unable to close reception")
+	}
+
+	if rows, _ := commandTag.RowsAffected(); rows == 0 {
+		logger.Error(ctx, fmt.Sprintf("Manifest %s was replaced while reception %s was closing", report.ManifestId, receptionData.Id))
+		return false, errors.New("manifest was replaced, try to close reception again")
+	}
+
+	for _, item := range report.Items {
+		productId := uuid.NullUUID{UUID: item.ProductId, Valid: item.ProductId != uuid.Nil}
+		_, err = tx.ExecContext(ctx, CreateDiscrepancyQuery, receptionData.Id, item.Kind, item.Barcode, productId,
+			item.ExpectedType, item.ActualType)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to save discrepancy of reception %s: %v", receptionData.Id, err))
+			return false, errors.New("unable to close reception")
+		}
+	}
+
+	return true, nil
+}
+
+// GetDiscrepancyReport возвращает расхождения приёмки с манифестом. Если манифеста не было, возвращается пустой отчёт
+func (p *PostgresReceptionRepository) GetDiscrepancyReport(ctx context.Context, receptionId uuid.UUID) (models.DiscrepancyReport, error) {
+	rows, err := p.Db.QueryContext(ctx, GetDiscrepancyReportQuery, receptionId, models.Closed, models.Verified)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get discrepancies of reception %s: %v", receptionId, err))
+		return models.DiscrepancyReport{}, errors.New("unable to get discrepancy report")
+	}
+	defer rows.Close()
+
+	report := models.DiscrepancyReport{ReceptionId: receptionId, Items: []models.Discrepancy{}}
+	for rows.Next() {
+		var discrepancy postgres_models.PostgresDiscrepancy
+		err = rows.Scan(&report.ManifestId, &discrepancy.DiscrepancyKind, &discrepancy.DiscrepancyBarcode,
+			&discrepancy.DiscrepancyProductId, &discrepancy.DiscrepancyExpectedType, &discrepancy.DiscrepancyActualType)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan discrepancy: %v", err))
+			return models.DiscrepancyReport{}, errors.New("unable to get discrepancy report")
+		}
+
+		if discrepancy.DiscrepancyKind.Valid {
+			report.Items = append(report.Items, postgres_models.ToDiscrepancy(discrepancy))
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate discrepancies: %v", err))
+		return models.DiscrepancyReport{}, errors.New("unable to get discrepancy report")
+	}
+
+	if report.ManifestId == uuid.Nil {
+		return models.DiscrepancyReport{}, nil
+	}
+
+	return report, nil
+}
+
+// ReopenReception в одной транзакции возвращает закрытую приёмку в работу, удаляет её отчёт о расхождениях
+// и пишет запись в журнал. Манифест остаётся привязан к приёмке, и при закрытии она сверяется с ним заново.
+// false означает, что приёмка не закрыта, у ПВЗ уже есть открытая приёмка или ПВЗ выведен из эксплуатации
+func (p *PostgresReceptionRepository) ReopenReception(ctx context.Context, receptionId uuid.UUID, entry models.AuditEntry) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to reopen reception %s", receptionId))
+
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return false, fmt.Errorf("unable to encode audit details: %v", err)
+	}
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return false, errors.New("unable to reopen reception")
+	}
+	defer tx.Rollback()
+
+	commandTag, err := tx.ExecContext(ctx, ReopenReceptionQuery, receptionId, models.InProgress, models.Closed)
+	if err != nil {
+		// параллельно открытую приёмку отсекает уникальный индекс reception_in_progress_pvz_idx
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			logger.Error(ctx, fmt.Sprintf("Pvz of reception %s already has an open reception", receptionId))
+			return false, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to reopen reception %s: %v", receptionId, err))
+		return false, errors.New("unable to reopen reception")
+	}
+
+	if rows, _ := commandTag.RowsAffected(); rows == 0 {
+		logger.Error(ctx, fmt.Sprintf("Reception %s can not be reopened", receptionId))
+		return false, nil
+	}
+
+	if _, err = tx.ExecContext(ctx, DeleteDiscrepanciesQuery, receptionId); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to delete discrepancies of reception %s: %v", receptionId, err))
+		return false, errors.New("unable to reopen reception")
+	}
+
+	if _, err = tx.ExecContext(ctx, CreateAuditEntryQuery, entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to write audit entry: %v", err))
+		return false, errors.New("unable to reopen reception")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return false, errors.New("unable to reopen reception")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully reopened reception %s", receptionId))
+	return true, nil
+}
+
+// ChangeReceptionStatus в одной транзакции переводит приёмку из статуса from в to и пишет запись в журнал.
+// false означает, что статус приёмки уже не from
+func (p *PostgresReceptionRepository) ChangeReceptionStatus(ctx context.Context, receptionId uuid.UUID, from, to models.Status, entry models.AuditEntry) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to change status of reception %s from %s to %s", receptionId, from, to))
+
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return false, fmt.Errorf("unable to encode audit details: %v", err)
+	}
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return false, errors.New("unable to change reception status")
+	}
+	defer tx.Rollback()
+
+	commandTag, err := tx.ExecContext(ctx, ChangeReceptionStatusQuery, receptionId, from, to)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to change status of reception %s: %v", receptionId, err))
+		return false, errors.New("unable to change reception status")
+	}
+
+	if rows, _ := commandTag.RowsAffected(); rows == 0 {
+		logger.Error(ctx, fmt.Sprintf("Reception %s is not %s anymore", receptionId, from))
+		return false, nil
+	}
+
+	if _, err = tx.ExecContext(ctx, CreateAuditEntryQuery, entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to write audit entry: %v", err))
+		return false, errors.New("unable to change reception status")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return false, errors.New("unable to change reception status")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully changed status of reception %s to %s", receptionId, to))
+	return true, nil
+}
+
+// GetReceptionHistory возвращает записи журнала о приёмке в порядке их появления
+func (p *PostgresReceptionRepository) GetReceptionHistory(ctx context.Context, receptionId uuid.UUID) ([]models.AuditEntry, error) {
+	rows, err := p.Db.QueryContext(ctx, GetReceptionHistoryQuery, receptionId)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to get history of reception %s: %v", receptionId, err))
+		return nil, errors.New("unable to get reception history")
+	}
+	defer rows.Close()
+
+	entries := make([]models.AuditEntry, 0)
+	for rows.Next() {
+		var (
+			entry   models.AuditEntry
+			details []byte
+		)
+		if err = rows.Scan(&entry.Id, &entry.ActorId, &entry.Action, &entry.TargetId, &details, &entry.CreatedAt); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan audit entry: %v", err))
+			return nil, errors.New("unable to get reception history")
+		}
+
+		if err = json.Unmarshal(details, &entry.Details); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to decode audit details: %v", err))
+			return nil, errors.New("unable to get reception history")
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate audit entries: %v", err))
+		return nil, errors.New("unable to get reception history")
+	}
+
+	return entries, nil
 }
@@ -4,7 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +12,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
 
 	"pvz/internal/models"
 	"pvz/internal/repository"
@@ -41,7 +42,7 @@ func TestCreateReception(t *testing.T) {
 			name: "successfully creates reception",
 			setupMock: func() {
 				mock.ExpectExec("insert into reception").
-					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status).
+					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status, false).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedErr: false,
@@ -50,7 +51,7 @@ func TestCreateReception(t *testing.T) {
 			name: "no rows affected",
 			setupMock: func() {
 				mock.ExpectExec("insert into reception").
-					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status).
+					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status, false).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			expectedErr:  true,
@@ -60,7 +61,7 @@ func TestCreateReception(t *testing.T) {
 			name: "query error",
 			setupMock: func() {
 				mock.ExpectExec("insert into reception").
-					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status).
+					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status, false).
 					WillReturnError(errors.New("db error"))
 			},
 			expectedErr:  true,
@@ -75,7 +76,7 @@ func TestCreateReception(t *testing.T) {
 					Where:   "SQL insert",
 				}
 				mock.ExpectExec("insert into reception").
-					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status).
+					WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status, false).
 					WillReturnError(pgErr)
 			},
 			expectedErr:  true,
@@ -181,6 +182,7 @@ func TestAddProduct(t *testing.T) {
 		DateTime:    time.Now(),
 		ProductType: "TypeA",
 		ReceptionId: uuid.New(),
+		Barcode:     "4600000000017",
 	}
 
 	tests := []struct {
@@ -192,7 +194,7 @@ func TestAddProduct(t *testing.T) {
 			name: "successfully adds product to open reception",
 			setupMock: func() {
 				mock.ExpectExec("insert into product").
-					WithArgs(product.Id, product.DateTime, product.ProductType, product.ReceptionId).
+					WithArgs(product.Id, product.DateTime, product.ProductType, product.ReceptionId, false, product.Barcode, "").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedErr: false,
@@ -201,7 +203,7 @@ func TestAddProduct(t *testing.T) {
 			name: "query error while adding product",
 			setupMock: func() {
 				mock.ExpectExec("insert into product").
-					WithArgs(product.Id, product.DateTime, product.ProductType, product.ReceptionId).
+					WithArgs(product.Id, product.DateTime, product.ProductType, product.ReceptionId, false, product.Barcode, "").
 					WillReturnError(errors.New("db error"))
 			},
 			expectedErr: true,
@@ -210,7 +212,7 @@ func TestAddProduct(t *testing.T) {
 			name: "pg error",
 			setupMock: func() {
 				mock.ExpectExec("insert into product").
-					WithArgs(product.Id, product.DateTime, product.ProductType, product.ReceptionId).
+					WithArgs(product.Id, product.DateTime, product.ProductType, product.ReceptionId, false, product.Barcode, "").
 					WillReturnError(&pgconn.PgError{
 						Message: "some weird SQL Error",
 						Detail:  "Super Mega Detailed error",
@@ -314,22 +316,34 @@ func TestCloseReception(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupMock   func()
+		expected    bool
 		expectedErr bool
 	}{
 		{
 			name: "successfully closes reception",
 			setupMock: func() {
 				mock.ExpectExec("update reception set status").
-					WithArgs(reception.Id, models.Closed).
+					WithArgs(reception.Id, models.Closed, false, models.InProgress).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
+			expected:    true,
+			expectedErr: false,
+		},
+		{
+			name: "reception was cancelled before closing",
+			setupMock: func() {
+				mock.ExpectExec("update reception set status").
+					WithArgs(reception.Id, models.Closed, false, models.InProgress).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expected:    false,
 			expectedErr: false,
 		},
 		{
 			name: "query error while closing reception",
 			setupMock: func() {
 				mock.ExpectExec("update reception set status").
-					WithArgs(reception.Id, models.Closed).
+					WithArgs(reception.Id, models.Closed, false, models.InProgress).
 					WillReturnError(errors.New("db error"))
 			},
 			expectedErr: true,
@@ -338,7 +352,7 @@ func TestCloseReception(t *testing.T) {
 			name: "pg error",
 			setupMock: func() {
 				mock.ExpectExec("update reception set status").
-					WithArgs(reception.Id, models.Closed).
+					WithArgs(reception.Id, models.Closed, false, models.InProgress).
 					WillReturnError(&pgconn.PgError{
 						Message: "some weird SQL Error",
 						Detail:  "Super Mega Detailed error",
@@ -352,13 +366,917 @@ func TestCloseReception(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
-			err := repo.CloseReception(context.Background(), reception)
+			isClosed, err := repo.CloseReception(context.Background(), reception)
 			if tt.expectedErr && err == nil {
 				t.Fatalf("expected error, got nil")
 			}
 			if !tt.expectedErr && err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
+			assert.Equal(t, tt.expected, isClosed)
 		})
 	}
 }
+
+func TestIsCategoryActive(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.IsCategoryActiveQuery)).
+		WithArgs("книги").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	isActive, err := repo.IsCategoryActive(context.Background(), "книги")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if isActive {
+		t.Errorf("expected inactive category")
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.IsCategoryActiveQuery)).
+		WithArgs("книги").
+		WillReturnError(errors.New("db error"))
+	if _, err = repo.IsCategoryActive(context.Background(), "книги"); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestGetPvzLoad(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	pvzId := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzLoadQuery)).
+		WithArgs(pvzId, models.ProductStored, models.ProductReturned, models.Cancelled).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "on_hand"}).AddRow(100, 42))
+	load, err := repo.GetPvzLoad(context.Background(), pvzId)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if load.Pvz.Capacity != 100 || load.OnHand != 42 {
+		t.Errorf("expected 42 of 100 items, got %d of %d", load.OnHand, load.Pvz.Capacity)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzLoadQuery)).
+		WithArgs(pvzId, models.ProductStored, models.ProductReturned, models.Cancelled).
+		WillReturnError(sql.ErrNoRows)
+	if load, err = repo.GetPvzLoad(context.Background(), pvzId); err != nil || load.ExceedsCapacity(1) {
+		t.Fatalf("expected empty load for unknown pvz, got %+v, %v", load, err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetPvzLoadQuery)).
+		WithArgs(pvzId, models.ProductStored, models.ProductReturned, models.Cancelled).
+		WillReturnError(errors.New("db error"))
+	if _, err = repo.GetPvzLoad(context.Background(), pvzId); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestListReceptions(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	filter := models.ReceptionFilter{
+		PvzId:     uuid.New(),
+		Status:    models.Closed,
+		StartDate: time.Now().Add(-time.Hour),
+		EndDate:   time.Now(),
+		Page:      2,
+		Limit:     5,
+	}
+	receptionId := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListReceptionsQuery)).
+		WithArgs(filter.PvzId, filter.Status, filter.StartDate, filter.EndDate, 5, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reception_datetime", "pvz_id", "status", "outside_schedule", "auto_closed"}).
+			AddRow(receptionId, filter.EndDate, filter.PvzId, string(models.Closed), true, true))
+	receptions, err := repo.ListReceptions(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(receptions) != 1 || receptions[0].Id != receptionId || !receptions[0].OutsideSchedule || !receptions[0].AutoClosed {
+		t.Errorf("unexpected receptions: %+v", receptions)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListReceptionsQuery)).
+		WithArgs(filter.PvzId, filter.Status, filter.StartDate, filter.EndDate, 5, 5).
+		WillReturnError(errors.New("db error"))
+	if _, err = repo.ListReceptions(context.Background(), filter); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestListStaleReceptions(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	idleSince := time.Now().Add(-12 * time.Hour)
+	receptionId := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListStaleReceptionsQuery)).
+		WithArgs(models.InProgress, idleSince, models.AuditReceptionReopen).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reception_datetime", "pvz_id", "status", "outside_schedule", "auto_closed"}).
+			AddRow(receptionId, idleSince.Add(-time.Hour), uuid.New(), string(models.InProgress), false, false))
+
+	receptions, err := repo.ListStaleReceptions(context.Background(), idleSince)
+	assert.NoError(t, err)
+	assert.Len(t, receptions, 1)
+	assert.Equal(t, receptionId, receptions[0].Id)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListStaleReceptionsQuery)).
+		WithArgs(models.InProgress, idleSince, models.AuditReceptionReopen).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.ListStaleReceptions(context.Background(), idleSince)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReception(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	pvzId := uuid.New()
+	now := time.Now()
+	columns := []string{
+		"id", "reception_datetime", "pvz_id", "status", "outside_schedule", "auto_closed",
+		"id", "received_at", "type", "reception_id", "over_capacity", "barcode", "external_order_id", "status",
+	}
+
+	tests := []struct {
+		name         string
+		setupMock    func()
+		wantProducts int
+		wantFound    bool
+		expectedErr  bool
+	}{
+		{
+			name: "reception with products",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionQuery)).
+					WithArgs(receptionId).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(receptionId, now, pvzId, string(models.InProgress), false, false, uuid.New(), now, "обувь", receptionId, false, "4600000000017", "WB-1", string(models.ProductStored)).
+						AddRow(receptionId, now, pvzId, string(models.InProgress), false, false, uuid.New(), now, "одежда", receptionId, true, nil, nil, string(models.ProductIssued)))
+			},
+			wantProducts: 2,
+			wantFound:    true,
+		},
+		{
+			name: "empty reception",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionQuery)).
+					WithArgs(receptionId).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(receptionId, now, pvzId, string(models.Closed), false, false, nil, nil, nil, nil, nil, nil, nil, nil))
+			},
+			wantFound: true,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionQuery)).
+					WithArgs(receptionId).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name: "query error",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionQuery)).
+					WithArgs(receptionId).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			reception, err := repo.GetReception(context.Background(), receptionId)
+			if tt.expectedErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.expectedErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (reception.Reception.Id == receptionId) != tt.wantFound {
+				t.Errorf("expected found %v, got reception %s", tt.wantFound, reception.Reception.Id)
+			}
+			if len(reception.Products) != tt.wantProducts {
+				t.Errorf("expected %d products, got %d", tt.wantProducts, len(reception.Products))
+			}
+		})
+	}
+}
+
+func TestDeleteProduct(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	productId := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteProductFromOpenReceptionQuery)).
+		WithArgs(receptionId, productId, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	isDeleted, err := repo.DeleteProduct(context.Background(), receptionId, productId)
+	if err != nil || !isDeleted {
+		t.Fatalf("expected product to be deleted, got %v, %v", isDeleted, err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteProductFromOpenReceptionQuery)).
+		WithArgs(receptionId, productId, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	if isDeleted, err = repo.DeleteProduct(context.Background(), receptionId, productId); err != nil || isDeleted {
+		t.Fatalf("expected nothing to be deleted, got %v, %v", isDeleted, err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteProductFromOpenReceptionQuery)).
+		WithArgs(receptionId, productId, models.InProgress).
+		WillReturnError(errors.New("db error"))
+	if _, err = repo.DeleteProduct(context.Background(), receptionId, productId); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestAddProducts(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	now := time.Now()
+	products := []models.Product{
+		{Id: uuid.New(), DateTime: now, ProductType: "обувь", ReceptionId: receptionId, Barcode: "4600000000017", ExternalOrderId: "WB-1"},
+		{Id: uuid.New(), DateTime: now.Add(time.Millisecond), ProductType: "одежда", ReceptionId: receptionId, OverCapacity: true},
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr bool
+	}{
+		{
+			name: "all products are added",
+			setupMock: func() {
+				mock.ExpectBegin()
+				prepared := mock.ExpectPrepare(regexp.QuoteMeta(repository.AddProductToOpenReceptionQuery))
+				for _, product := range products {
+					prepared.ExpectExec().
+						WithArgs(product.Id, product.DateTime, product.ProductType, product.ReceptionId, product.OverCapacity,
+							product.Barcode, product.ExternalOrderId).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "failed insert rolls back the whole batch",
+			setupMock: func() {
+				mock.ExpectBegin()
+				prepared := mock.ExpectPrepare(regexp.QuoteMeta(repository.AddProductToOpenReceptionQuery))
+				prepared.ExpectExec().
+					WithArgs(products[0].Id, products[0].DateTime, products[0].ProductType, products[0].ReceptionId, false, "4600000000017", "WB-1").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				prepared.ExpectExec().
+					WithArgs(products[1].Id, products[1].DateTime, products[1].ProductType, products[1].ReceptionId, true, "", "").
+					WillReturnError(&pgconn.PgError{Message: "violates foreign key constraint"})
+				mock.ExpectRollback()
+			},
+			expectedErr: true,
+		},
+		{
+			name: "begin error",
+			setupMock: func() {
+				mock.ExpectBegin().WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.AddProducts(context.Background(), products)
+			if tt.expectedErr && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tt.expectedErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetReceivedBarcodes(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	barcodes := []string{"4600000000017", "4600000000024"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceivedBarcodesQuery)).
+		WithArgs(receptionId, barcodes).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode"}).AddRow("4600000000024"))
+	received, err := repo.GetReceivedBarcodes(context.Background(), receptionId, barcodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 1 || received[0] != "4600000000024" {
+		t.Errorf("expected only second barcode to be received, got %v", received)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceivedBarcodesQuery)).
+		WithArgs(receptionId, barcodes).
+		WillReturnError(errors.New("db error"))
+	if _, err = repo.GetReceivedBarcodes(context.Background(), receptionId, barcodes); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	barcode := "4600000000017"
+	pvzId := uuid.New()
+	receptionId := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.SearchProductsByBarcodeQuery)).
+		WithArgs(barcode).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "received_at", "type", "reception_id", "over_capacity", "barcode", "external_order_id", "status", "pvz_id", "status", "city",
+		}).AddRow(uuid.New(), now, "обувь", receptionId, false, barcode, "WB-1", string(models.ProductStored), pvzId, string(models.Closed), "Москва"))
+	locations, err := repo.SearchProducts(context.Background(), barcode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(locations) != 1 {
+		t.Fatalf("expected 1 location, got %d", len(locations))
+	}
+	if locations[0].PvzId != pvzId || locations[0].City != "Москва" || locations[0].Product.ExternalOrderId != "WB-1" {
+		t.Errorf("unexpected location: %+v", locations[0])
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.SearchProductsByBarcodeQuery)).
+		WithArgs(barcode).
+		WillReturnError(errors.New("db error"))
+	if _, err = repo.SearchProducts(context.Background(), barcode); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestReplaceManifest(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	manifest := models.Manifest{
+		Id:        uuid.New(),
+		PvzId:     uuid.New(),
+		CreatedAt: time.Now(),
+		Items:     []models.ManifestItem{{Barcode: "A-1", ProductType: "обувь"}, {Barcode: "A-2", ProductType: "одежда"}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeletePendingManifestQuery)).
+		WithArgs(manifest.PvzId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateManifestQuery)).
+		WithArgs(manifest.Id, manifest.PvzId, manifest.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	for _, item := range manifest.Items {
+		mock.ExpectExec(regexp.QuoteMeta(repository.CreateManifestItemQuery)).
+			WithArgs(manifest.Id, item.Barcode, item.ProductType).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	isReplaced, err := repo.ReplaceManifest(context.Background(), manifest)
+	assert.NoError(t, err)
+	assert.True(t, isReplaced)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeletePendingManifestQuery)).
+		WithArgs(manifest.PvzId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateManifestQuery)).
+		WithArgs(manifest.Id, manifest.PvzId, manifest.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	isReplaced, err = repo.ReplaceManifest(context.Background(), manifest)
+	assert.NoError(t, err)
+	assert.False(t, isReplaced, "pvz does not exist")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeletePendingManifestQuery)).
+		WithArgs(manifest.PvzId).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateManifestQuery)).
+		WithArgs(manifest.Id, manifest.PvzId, manifest.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateManifestItemQuery)).
+		WithArgs(manifest.Id, "A-1", "обувь").
+		WillReturnError(&pgconn.PgError{Message: "violates foreign key constraint"})
+	mock.ExpectRollback()
+
+	_, err = repo.ReplaceManifest(context.Background(), manifest)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReceptionManifest(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	manifestId := uuid.New()
+	pvzId := uuid.New()
+	reception := models.Reception{Id: uuid.New(), PvzId: pvzId, Status: models.InProgress}
+	createdAt := time.Now()
+	columns := []string{"id", "pvz_id", "created_at", "barcode", "type"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionManifestQuery)).
+		WithArgs(pvzId, reception.Id).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(manifestId, pvzId, createdAt, "A-1", "обувь").
+			AddRow(manifestId, pvzId, createdAt, "A-2", "одежда"))
+
+	got, err := repo.GetReceptionManifest(context.Background(), reception)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Manifest{
+		Id:        manifestId,
+		PvzId:     pvzId,
+		CreatedAt: createdAt,
+		Items:     []models.ManifestItem{{Barcode: "A-1", ProductType: "обувь"}, {Barcode: "A-2", ProductType: "одежда"}},
+	}, got)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionManifestQuery)).
+		WithArgs(pvzId, reception.Id).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	got, err = repo.GetReceptionManifest(context.Background(), reception)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, got.Id)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionManifestQuery)).
+		WithArgs(pvzId, reception.Id).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.GetReceptionManifest(context.Background(), reception)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCloseReceptionWithReport(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	reception := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.InProgress}
+	productId := uuid.New()
+	report := models.DiscrepancyReport{
+		ReceptionId: reception.Id,
+		ManifestId:  uuid.New(),
+		Items: []models.Discrepancy{
+			{Kind: models.Shortage, Barcode: "A-1", ExpectedType: "обувь"},
+			{Kind: models.Surplus, ProductId: productId, ActualType: "одежда"},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CloseReceptionQuery)).
+		WithArgs(reception.Id, models.Closed, false, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.BindManifestQuery)).
+		WithArgs(report.ManifestId, reception.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateDiscrepancyQuery)).
+		WithArgs(reception.Id, models.Shortage, "A-1", nil, "обувь", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateDiscrepancyQuery)).
+		WithArgs(reception.Id, models.Surplus, "", productId, "", "одежда").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	isClosed, err := repo.CloseReceptionWithReport(context.Background(), reception, report)
+	assert.NoError(t, err)
+	assert.True(t, isClosed)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CloseReceptionQuery)).
+		WithArgs(reception.Id, models.Closed, false, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.BindManifestQuery)).
+		WithArgs(report.ManifestId, reception.Id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = repo.CloseReceptionWithReport(context.Background(), reception, report)
+	assert.Error(t, err, "manifest was replaced concurrently")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CloseReceptionQuery)).
+		WithArgs(reception.Id, models.Closed, false, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	isClosed, err = repo.CloseReceptionWithReport(context.Background(), reception, report)
+	assert.NoError(t, err)
+	assert.False(t, isClosed, "reception was cancelled concurrently")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAutoCloseReception(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	reception := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.InProgress, AutoClosed: true}
+	report := models.DiscrepancyReport{
+		ReceptionId: reception.Id,
+		ManifestId:  uuid.New(),
+		Items:       []models.Discrepancy{{Kind: models.Shortage, Barcode: "A-1", ExpectedType: "обувь"}},
+	}
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   models.SystemActorId,
+		Action:    models.AuditReceptionAutoClose,
+		TargetId:  reception.Id.String(),
+		Details:   map[string]string{"discrepancies": "1"},
+		CreatedAt: time.Now(),
+	}
+	details := []byte(`{"discrepancies":"1"}`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CloseReceptionQuery)).
+		WithArgs(reception.Id, models.Closed, true, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.BindManifestQuery)).
+		WithArgs(report.ManifestId, reception.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateDiscrepancyQuery)).
+		WithArgs(reception.Id, models.Shortage, "A-1", nil, "обувь", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, models.SystemActorId, entry.Action, entry.TargetId, details, entry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	isClosed, err := repo.AutoCloseReception(context.Background(), reception, report, entry)
+	assert.NoError(t, err)
+	assert.True(t, isClosed)
+
+	// без манифеста пишутся только закрытие и запись журнала
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CloseReceptionQuery)).
+		WithArgs(reception.Id, models.Closed, true, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, models.SystemActorId, entry.Action, entry.TargetId, details, entry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	isClosed, err = repo.AutoCloseReception(context.Background(), reception, models.DiscrepancyReport{}, entry)
+	assert.NoError(t, err)
+	assert.True(t, isClosed)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CloseReceptionQuery)).
+		WithArgs(reception.Id, models.Closed, true, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	isClosed, err = repo.AutoCloseReception(context.Background(), reception, models.DiscrepancyReport{}, entry)
+	assert.NoError(t, err)
+	assert.False(t, isClosed, "reception was closed concurrently")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CloseReceptionQuery)).
+		WithArgs(reception.Id, models.Closed, true, models.InProgress).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	_, err = repo.AutoCloseReception(context.Background(), reception, models.DiscrepancyReport{}, entry)
+	assert.Error(t, err, "reception is not closed without its audit entry")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDiscrepancyReport(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	manifestId := uuid.New()
+	productId := uuid.New()
+	columns := []string{"id", "kind", "barcode", "product_id", "expected_type", "actual_type"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetDiscrepancyReportQuery)).
+		WithArgs(receptionId, models.Closed, models.Verified).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(manifestId, "shortage", "A-1", nil, "обувь", nil).
+			AddRow(manifestId, "wrong_category", "A-2", productId, "одежда", "обувь"))
+
+	got, err := repo.GetDiscrepancyReport(context.Background(), receptionId)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DiscrepancyReport{
+		ReceptionId: receptionId,
+		ManifestId:  manifestId,
+		Items: []models.Discrepancy{
+			{Kind: models.Shortage, Barcode: "A-1", ExpectedType: "обувь"},
+			{Kind: models.WrongCategory, Barcode: "A-2", ProductId: productId, ExpectedType: "одежда", ActualType: "обувь"},
+		},
+	}, got)
+
+	// приёмка сошлась с манифестом: строка манифеста есть, расхождений нет
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetDiscrepancyReportQuery)).
+		WithArgs(receptionId, models.Closed, models.Verified).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(manifestId, nil, nil, nil, nil, nil))
+
+	got, err = repo.GetDiscrepancyReport(context.Background(), receptionId)
+	assert.NoError(t, err)
+	assert.Equal(t, manifestId, got.ManifestId)
+	assert.Empty(t, got.Items)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetDiscrepancyReportQuery)).
+		WithArgs(receptionId, models.Closed, models.Verified).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	got, err = repo.GetDiscrepancyReport(context.Background(), receptionId)
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, got.ManifestId)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetDiscrepancyReportQuery)).
+		WithArgs(receptionId, models.Closed, models.Verified).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.GetDiscrepancyReport(context.Background(), receptionId)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateReceptionOutsideSchedule(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	reception := models.Reception{
+		Id:              uuid.New(),
+		DateTime:        time.Now(),
+		PvzId:           uuid.New(),
+		Status:          models.InProgress,
+		OutsideSchedule: true,
+	}
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   uuid.NewString(),
+		Action:    models.AuditReceptionScheduleOverride,
+		TargetId:  reception.Id.String(),
+		Details:   map[string]string{"reason": "поздняя поставка"},
+		CreatedAt: time.Now(),
+	}
+	details := []byte(`{"reason":"поздняя поставка"}`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateReceptionQuery)).
+		WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status, true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateReceptionOutsideSchedule(context.Background(), reception, entry)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateReceptionQuery)).
+		WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status, true).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = repo.CreateReceptionOutsideSchedule(context.Background(), reception, entry)
+	assert.Error(t, err, "pvz already has an open reception")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateReceptionQuery)).
+		WithArgs(reception.Id, reception.DateTime, reception.PvzId, reception.Status, true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	err = repo.CreateReceptionOutsideSchedule(context.Background(), reception, entry)
+	assert.Error(t, err, "reception is not created without its audit entry")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReopenReception(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   uuid.NewString(),
+		Action:    models.AuditReceptionReopen,
+		TargetId:  receptionId.String(),
+		Details:   map[string]string{"reason": "закрыта раньше времени"},
+		CreatedAt: time.Now(),
+	}
+	details := []byte(`{"reason":"закрыта раньше времени"}`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.ReopenReceptionQuery)).
+		WithArgs(receptionId, models.InProgress, models.Closed).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteDiscrepanciesQuery)).
+		WithArgs(receptionId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	isReopened, err := repo.ReopenReception(context.Background(), receptionId, entry)
+	assert.NoError(t, err)
+	assert.True(t, isReopened)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.ReopenReceptionQuery)).
+		WithArgs(receptionId, models.InProgress, models.Closed).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	isReopened, err = repo.ReopenReception(context.Background(), receptionId, entry)
+	assert.NoError(t, err)
+	assert.False(t, isReopened, "pvz already has an open reception")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.ReopenReceptionQuery)).
+		WithArgs(receptionId, models.InProgress, models.Closed).
+		WillReturnError(&pgconn.PgError{Code: "23505", Message: "duplicate key value violates unique constraint"})
+	mock.ExpectRollback()
+
+	isReopened, err = repo.ReopenReception(context.Background(), receptionId, entry)
+	assert.NoError(t, err)
+	assert.False(t, isReopened, "reception was opened concurrently")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.ReopenReceptionQuery)).
+		WithArgs(receptionId, models.InProgress, models.Closed).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.DeleteDiscrepanciesQuery)).
+		WithArgs(receptionId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	_, err = repo.ReopenReception(context.Background(), receptionId, entry)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeReceptionStatus(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   uuid.NewString(),
+		Action:    models.AuditReceptionCancel,
+		TargetId:  receptionId.String(),
+		Details:   map[string]string{"reason": "открыта по ошибке"},
+		CreatedAt: time.Now(),
+	}
+	details := []byte(`{"reason":"открыта по ошибке"}`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.ChangeReceptionStatusQuery)).
+		WithArgs(receptionId, models.InProgress, models.Cancelled).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	isChanged, err := repo.ChangeReceptionStatus(context.Background(), receptionId, models.InProgress, models.Cancelled, entry)
+	assert.NoError(t, err)
+	assert.True(t, isChanged)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.ChangeReceptionStatusQuery)).
+		WithArgs(receptionId, models.InProgress, models.Cancelled).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	isChanged, err = repo.ChangeReceptionStatus(context.Background(), receptionId, models.InProgress, models.Cancelled, entry)
+	assert.NoError(t, err)
+	assert.False(t, isChanged, "reception was closed concurrently")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(repository.ChangeReceptionStatusQuery)).
+		WithArgs(receptionId, models.InProgress, models.Cancelled).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	_, err = repo.ChangeReceptionStatus(context.Background(), receptionId, models.InProgress, models.Cancelled, entry)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReceptionHistory(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresReceptionRepository{Db: db}
+	receptionId := uuid.New()
+	actorId := uuid.NewString()
+	entryId := uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionHistoryQuery)).
+		WithArgs(receptionId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor_id", "action", "target_id", "details", "created_at"}).
+			AddRow(entryId, actorId, string(models.AuditReceptionReopen), receptionId.String(), []byte(`{"reason":"ошибка"}`), createdAt))
+
+	entries, err := repo.GetReceptionHistory(context.Background(), receptionId)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AuditEntry{{
+		Id:        entryId,
+		ActorId:   actorId,
+		Action:    models.AuditReceptionReopen,
+		TargetId:  receptionId.String(),
+		Details:   map[string]string{"reason": "ошибка"},
+		CreatedAt: createdAt,
+	}}, entries)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.GetReceptionHistoryQuery)).
+		WithArgs(receptionId).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.GetReceptionHistory(context.Background(), receptionId)
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"pvz/config/postgres"
+	"pvz/internal/models"
+	"pvz/pkg/logger"
+)
+
+const (
+	CreateRefreshTokenQuery = `
+		insert into refresh_token (id, user_id, token_hash, created_at, expires_at)
+		values ($1, $2, $3, $4, $5)
+	`
+
+	GetRefreshTokenQuery = `
+		select id, user_id, token_hash, created_at, expires_at, revoked_at
+		from refresh_token
+		where token_hash = $1
+	`
+
+	RevokeRefreshTokenQuery = `
+		update refresh_token set revoked_at = $2
+		where id = $1 and revoked_at is null
+	`
+
+	RevokeUserRefreshTokensQuery = `
+		update refresh_token set revoked_at = $2
+		where user_id = $1 and revoked_at is null
+	`
+
+	RevokeAccessTokenQuery = `
+		insert into revoked_token (jti, expires_at) values ($1, $2)
+		on conflict (jti) do nothing
+	`
+
+	// токен также считается отозванным, если пользователь деактивирован, его роль сменилась
+	// или пароль был сброшен после выдачи токена
+	IsAccessTokenRevokedQuery = `
+		select exists (select 1 from revoked_token where jti = $1)
+			or exists (select 1 from "user" where id = $2 and (status <> $4 or role <> $3 or password_changed_at > $5))
+	`
+)
+
+type PostgresTokenRepository struct {
+	Db *sql.DB
+}
+
+func NewPostgresTokenRepository() *PostgresTokenRepository {
+	db, err := sql.Open("pgx", postgres.NewPostgresConfig().GetURL())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	return &PostgresTokenRepository{Db: db}
+}
+
+func (p *PostgresTokenRepository) Close() {
+	p.Db.Close()
+}
+
+func (p *PostgresTokenRepository) CreateRefreshToken(ctx context.Context, token models.RefreshToken) error {
+	logger.Info(ctx, fmt.Sprintf("Trying to save refresh token for user: %s", token.UserId))
+
+	_, err := p.Db.ExecContext(ctx, CreateRefreshTokenQuery, token.Id, token.UserId, token.TokenHash, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("Error saving refresh token: %s", err.Error()))
+		return fmt.Errorf("unable to save refresh token: %v", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresTokenRepository) GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	logger.Info(ctx, "Trying to get refresh token")
+
+	var (
+		token     models.RefreshToken
+		revokedAt sql.NullTime
+	)
+	err := p.Db.QueryRowContext(ctx, GetRefreshTokenQuery, tokenHash).Scan(&token.Id,
+		&token.UserId,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&revokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, "Refresh token does not exist")
+			return models.RefreshToken{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to get refresh token: %v", err))
+		return models.RefreshToken{}, errors.New("unable to get refresh token")
+	}
+
+	token.RevokedAt = revokedAt.Time
+
+	logger.Info(ctx, fmt.Sprintf("Successfully got refresh token with id: %s", token.Id))
+	return token, nil
+}
+
+func (p *PostgresTokenRepository) RevokeRefreshToken(ctx context.Context, tokenId uuid.UUID) (bool, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to revoke refresh token with id: %s", tokenId))
+
+	commandTag, err := p.Db.ExecContext(ctx, RevokeRefreshTokenQuery, tokenId, time.Now())
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error revoking refresh token: %s", err.Error()))
+		return false, fmt.Errorf("unable to revoke refresh token: %v", err)
+	}
+
+	rows, _ := commandTag.RowsAffected()
+	return rows > 0, nil
+}
+
+func (p *PostgresTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userId string) error {
+	logger.Info(ctx, fmt.Sprintf("Trying to revoke all refresh tokens of user: %s", userId))
+
+	_, err := p.Db.ExecContext(ctx, RevokeUserRefreshTokensQuery, userId, time.Now())
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error revoking refresh tokens: %s", err.Error()))
+		return fmt.Errorf("unable to revoke refresh tokens: %v", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresTokenRepository) RevokeAccessToken(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	logger.Info(ctx, fmt.Sprintf("Trying to revoke access token: %s", tokenId))
+
+	_, err := p.Db.ExecContext(ctx, RevokeAccessTokenQuery, tokenId, expiresAt)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error revoking access token: %s", err.Error()))
+		return fmt.Errorf("unable to revoke access token: %v", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresTokenRepository) IsAccessTokenRevoked(ctx context.Context, tokenId, userId, role string, issuedAt time.Time) (bool, error) {
+	var isRevoked bool
+	if err := p.Db.QueryRowContext(ctx, IsAccessTokenRevokedQuery, tokenId, userId, role, models.UserActive, issuedAt).Scan(&isRevoked); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to check access token revocation: %v", err))
+		return false, errors.New("unable to check access token revocation")
+	}
+
+	return isRevoked, nil
+}
@@ -0,0 +1,285 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+func TestCreateRefreshToken(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresTokenRepository{Db: db}
+	token := models.RefreshToken{
+		Id:        uuid.New(),
+		UserId:    uuid.NewString(),
+		TokenHash: "hash",
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectExec("insert into refresh_token").
+					WithArgs(token.Id, token.UserId, token.TokenHash, token.CreatedAt, token.ExpiresAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedErr: false,
+		},
+		{
+			name: "pg error",
+			setupMock: func() {
+				mock.ExpectExec("insert into refresh_token").
+					WithArgs(token.Id, token.UserId, token.TokenHash, token.CreatedAt, token.ExpiresAt).
+					WillReturnError(&pgconn.PgError{Message: "violates foreign key constraint"})
+			},
+			expectedErr: true,
+		},
+		{
+			name: "sql error",
+			setupMock: func() {
+				mock.ExpectExec("insert into refresh_token").
+					WithArgs(token.Id, token.UserId, token.TokenHash, token.CreatedAt, token.ExpiresAt).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.CreateRefreshToken(context.Background(), token)
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetRefreshToken(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresTokenRepository{Db: db}
+	token := models.RefreshToken{
+		Id:        uuid.New(),
+		UserId:    uuid.NewString(),
+		TokenHash: "hash",
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Millisecond),
+	}
+	columns := []string{"id", "user_id", "token_hash", "created_at", "expires_at", "revoked_at"}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    models.RefreshToken
+		expectedErr bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetRefreshTokenQuery)).
+					WithArgs(token.TokenHash).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(token.Id, token.UserId, token.TokenHash, token.CreatedAt, token.ExpiresAt, nil))
+			},
+			expected: token,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetRefreshTokenQuery)).
+					WithArgs(token.TokenHash).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expected: models.RefreshToken{},
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(repository.GetRefreshTokenQuery)).
+					WithArgs(token.TokenHash).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			got, err := repo.GetRefreshToken(context.Background(), token.TokenHash)
+			assert.Equal(t, tt.expectedErr, err != nil)
+			if !tt.expectedErr {
+				assert.Equal(t, tt.expected, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresTokenRepository{Db: db}
+	tokenId := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name: "revoked",
+			setupMock: func() {
+				mock.ExpectExec("update refresh_token set revoked_at").
+					WithArgs(tokenId, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expected: true,
+		},
+		{
+			name: "already revoked",
+			setupMock: func() {
+				mock.ExpectExec("update refresh_token set revoked_at").
+					WithArgs(tokenId, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expected: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectExec("update refresh_token set revoked_at").
+					WithArgs(tokenId, sqlmock.AnyArg()).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			got, err := repo.RevokeRefreshToken(context.Background(), tokenId)
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Equal(t, tt.expected, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeUserRefreshTokens(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresTokenRepository{Db: db}
+	userId := uuid.NewString()
+
+	mock.ExpectExec("update refresh_token set revoked_at").
+		WithArgs(userId, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	assert.NoError(t, repo.RevokeUserRefreshTokens(context.Background(), userId))
+
+	mock.ExpectExec("update refresh_token set revoked_at").
+		WithArgs(userId, sqlmock.AnyArg()).
+		WillReturnError(errors.New("db error"))
+	assert.Error(t, repo.RevokeUserRefreshTokens(context.Background(), userId))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresTokenRepository{Db: db}
+	expiresAt := time.Now().Add(time.Minute)
+
+	mock.ExpectExec("insert into revoked_token").
+		WithArgs("jti", expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.NoError(t, repo.RevokeAccessToken(context.Background(), "jti", expiresAt))
+
+	mock.ExpectExec("insert into revoked_token").
+		WithArgs("jti", expiresAt).
+		WillReturnError(errors.New("db error"))
+	assert.Error(t, repo.RevokeAccessToken(context.Background(), "jti", expiresAt))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsAccessTokenRevoked(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresTokenRepository{Db: db}
+	issuedAt := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name: "revoked",
+			setupMock: func() {
+				mock.ExpectQuery("select exists").
+					WithArgs("jti", "user-id", "employee", models.UserActive, issuedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expected: true,
+		},
+		{
+			name: "not revoked",
+			setupMock: func() {
+				mock.ExpectQuery("select exists").
+					WithArgs("jti", "user-id", "employee", models.UserActive, issuedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			expected: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectQuery("select exists").
+					WithArgs("jti", "user-id", "employee", models.UserActive, issuedAt).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			got, err := repo.IsAccessTokenRevoked(context.Background(), "jti", "user-id", "employee", issuedAt)
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Equal(t, tt.expected, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"pvz/internal/models"
+	"pvz/pkg/logger"
+)
+
+const (
+	ListUsersQuery = `
+		select id, email, role, status from "user"
+		where ($1 = '' or role = $1) and ($2 = '' or status = $2)
+		order by email
+		limit $3 offset $4
+	`
+
+	UpdateUserStatusQuery = `
+		update "user" set status = $2 where id = $1
+		returning id, email, role, status
+	`
+
+	UpdateUserRoleQuery = `
+		update "user" set role = $2 where id = $1
+		returning id, email, role, status
+	`
+
+	// по password_changed_at отзываются access-токены, выданные до сброса
+	ResetUserPasswordQuery = `
+		update "user" set password = $2, salt = '', password_changed_at = $3 where id = $1
+		returning id, email, role, status
+	`
+
+	CreateAuditEntryQuery = `
+		insert into audit_log (id, actor_id, action, target_id, details, created_at)
+		values ($1, $2, $3, $4, $5, $6)
+	`
+)
+
+func (p *PostgresUserRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to list users, role: %q, status: %q", filter.Role, filter.Status))
+
+	rows, err := p.Db.QueryContext(ctx, ListUsersQuery, filter.Role, filter.Status, filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to list users: %v", err))
+		return nil, errors.New("unable to list users")
+	}
+	defer rows.Close()
+
+	users := make([]models.User, 0)
+	for rows.Next() {
+		var user models.User
+		if err = rows.Scan(&user.Id, &user.Email, &user.Role, &user.Status); err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to scan user: %v", err))
+			return nil, errors.New("unable to list users")
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to iterate users: %v", err))
+		return nil, errors.New("unable to list users")
+	}
+
+	return users, nil
+}
+
+func (p *PostgresUserRepository) UpdateUserStatus(ctx context.Context, userId string, status models.UserStatus, entry models.AuditEntry) (models.User, error) {
+	return p.updateUserWithAudit(ctx, entry, UpdateUserStatusQuery, userId, status)
+}
+
+func (p *PostgresUserRepository) UpdateUserRole(ctx context.Context, userId, role string, entry models.AuditEntry) (models.User, error) {
+	return p.updateUserWithAudit(ctx, entry, UpdateUserRoleQuery, userId, role)
+}
+
+func (p *PostgresUserRepository) ResetUserPassword(ctx context.Context, userId, password string, entry models.AuditEntry) (models.User, error) {
+	return p.updateUserWithAudit(ctx, entry, ResetUserPasswordQuery, userId, password, entry.CreatedAt)
+}
+
+// updateUserWithAudit в одной транзакции меняет пользователя, отзывает его refresh-токены и пишет запись в журнал.
+// Если пользователя нет, возвращается пустой пользователь и ничего не меняется
+func (p *PostgresUserRepository) updateUserWithAudit(ctx context.Context, entry models.AuditEntry, query string, args ...interface{}) (models.User, error) {
+	logger.Info(ctx, fmt.Sprintf("Trying to apply %s to user %s", entry.Action, entry.TargetId))
+
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return models.User{}, fmt.Errorf("unable to encode audit details: %v", err)
+	}
+
+	tx, err := p.Db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to begin transaction: %v", err))
+		return models.User{}, errors.New("unable to update user")
+	}
+	defer tx.Rollback()
+
+	var user models.User
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&user.Id, &user.Email, &user.Role, &user.Status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Error(ctx, fmt.Sprintf("User with id: %s does not exist", entry.TargetId))
+			return models.User{}, nil
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to update user: %v", err))
+		return models.User{}, errors.New("unable to update user")
+	}
+
+	if _, err = tx.ExecContext(ctx, RevokeUserRefreshTokensQuery, user.Id, entry.CreatedAt); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to revoke refresh tokens: %v", err))
+		return models.User{}, errors.New("unable to update user")
+	}
+
+	if _, err = tx.ExecContext(ctx, CreateAuditEntryQuery, entry.Id, entry.ActorId, entry.Action, entry.TargetId, details, entry.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			newErr := fmt.Errorf("SQL Error: %s, Detail: %s, Where: %s", pgErr.Message, pgErr.Detail, pgErr.Where)
+			logger.Error(ctx, newErr.Error())
+			return models.User{}, newErr
+		}
+		logger.Error(ctx, fmt.Sprintf("unable to write audit entry: %v", err))
+		return models.User{}, errors.New("unable to update user")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, fmt.Sprintf("unable to commit transaction: %v", err))
+		return models.User{}, errors.New("unable to update user")
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Successfully applied %s to user %s", entry.Action, user.Id))
+	return user, nil
+}
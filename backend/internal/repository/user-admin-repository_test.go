@@ -0,0 +1,162 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/repository"
+	"pvz/internal/repository/mocks"
+)
+
+func TestListUsers(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresUserRepository{Db: db}
+	userId := uuid.NewString()
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListUsersQuery)).
+		WithArgs("employee", models.UserActive, 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "status"}).
+			AddRow(userId, "employee@mail.ru", "employee", "active"))
+
+	users, err := repo.ListUsers(context.Background(), models.UserFilter{Role: "employee", Status: models.UserActive, Page: 2, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.User{{Id: userId, Email: "employee@mail.ru", Role: "employee", Status: models.UserActive}}, users)
+
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ListUsersQuery)).
+		WithArgs("", models.UserStatus(""), 10, 0).
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.ListUsers(context.Background(), models.UserFilter{Page: 1, Limit: 10})
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateUserStatus(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresUserRepository{Db: db}
+
+	userId := uuid.NewString()
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   uuid.NewString(),
+		Action:    models.AuditUserDeactivate,
+		TargetId:  userId,
+		Details:   map[string]string{},
+		CreatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name      string
+		mockQuery func()
+		want      models.User
+		wantErr   bool
+	}{
+		{
+			name: "ok",
+			mockQuery: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateUserStatusQuery)).
+					WithArgs(userId, models.UserDeactivated).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "status"}).
+						AddRow(userId, "employee@mail.ru", "employee", "deactivated"))
+				mock.ExpectExec(regexp.QuoteMeta(repository.RevokeUserRefreshTokensQuery)).
+					WithArgs(userId, entry.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+					WithArgs(entry.Id, entry.ActorId, entry.Action, userId, []byte("{}"), entry.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: models.User{Id: userId, Email: "employee@mail.ru", Role: "employee", Status: models.UserDeactivated},
+		},
+		{
+			name: "user not found",
+			mockQuery: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateUserStatusQuery)).
+					WithArgs(userId, models.UserDeactivated).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "status"}))
+				mock.ExpectRollback()
+			},
+			want: models.User{},
+		},
+		{
+			name: "audit error rolls back",
+			mockQuery: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(repository.UpdateUserStatusQuery)).
+					WithArgs(userId, models.UserDeactivated).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "status"}).
+						AddRow(userId, "employee@mail.ru", "employee", "deactivated"))
+				mock.ExpectExec(regexp.QuoteMeta(repository.RevokeUserRefreshTokensQuery)).
+					WithArgs(userId, entry.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			want:    models.User{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockQuery()
+
+			got, err := repo.UpdateUserStatus(context.Background(), userId, models.UserDeactivated, entry)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestResetUserPassword(t *testing.T) {
+	db, mock, cleanup := mocks.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &repository.PostgresUserRepository{Db: db}
+
+	userId := uuid.NewString()
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   uuid.NewString(),
+		Action:    models.AuditUserPasswordReset,
+		TargetId:  userId,
+		Details:   map[string]string{},
+		CreatedAt: time.Now(),
+	}
+
+	// время сброса пишется в password_changed_at, по нему отзываются выданные ранее access-токены
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(repository.ResetUserPasswordQuery)).
+		WithArgs(userId, "hashed", entry.CreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "status"}).
+			AddRow(userId, "employee@mail.ru", "employee", "active"))
+	mock.ExpectExec(regexp.QuoteMeta(repository.RevokeUserRefreshTokensQuery)).
+		WithArgs(userId, entry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(repository.CreateAuditEntryQuery)).
+		WithArgs(entry.Id, entry.ActorId, entry.Action, userId, []byte("{}"), entry.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := repo.ResetUserPassword(context.Background(), userId, "hashed", entry)
+	assert.NoError(t, err)
+	assert.Equal(t, models.User{Id: userId, Email: "employee@mail.ru", Role: "employee", Status: models.UserActive}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,26 +15,88 @@ import (
 	"pvz/pkg/logger"
 )
 
+const (
+	MaxEmailLoginFailures = 5
+	// с одного IP могут входить многие пользователи (NAT, офис), поэтому порог выше
+	MaxIPLoginFailures = 20
+	LoginLockBase      = time.Minute
+	LoginLockMax       = time.Hour
+	// окно больше максимальной блокировки, чтобы после её окончания следующая неудача удлиняла блокировку
+	LoginFailureWindow = 24 * time.Hour
+)
+
+var (
+	InvalidRefreshToken  = errors.New("invalid refresh token")
+	WrongAuthData        = errors.New("wrong auth data")
+	AccountLocked        = errors.New("account is temporarily locked")
+	TooManyLoginAttempts = errors.New("too many login attempts")
+	UserNotFound         = errors.New("user not found")
+	AccountDeactivated   = errors.New("account is deactivated")
+)
+
+// LoginLockError сообщает, до какого момента вход заблокирован. Причина - AccountLocked или TooManyLoginAttempts
+type LoginLockError struct {
+	Reason error
+	Until  time.Time
+}
+
+func (e *LoginLockError) Error() string {
+	return fmt.Sprintf("%s until %s", e.Reason, e.Until.Format(time.RFC3339))
+}
+
+func (e *LoginLockError) Unwrap() error {
+	return e.Reason
+}
+
 type UserRepository interface {
 	CreateUser(ctx context.Context, user models.User) error
 	IsUserExist(ctx context.Context, email string) (bool, error)
 	GetUserByEmail(ctx context.Context, logInData models.LoginData) (models.User, error)
+	GetUserById(ctx context.Context, userId string) (models.User, error)
+	UpdatePassword(ctx context.Context, userId, password, salt string) error
+}
+
+type TokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token models.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tokenId uuid.UUID) (bool, error)
+	RevokeUserRefreshTokens(ctx context.Context, userId string) error
+	RevokeAccessToken(ctx context.Context, tokenId string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, tokenId, userId, role string, issuedAt time.Time) (bool, error)
+}
+
+type LoginFailureRepository interface {
+	GetLoginLock(ctx context.Context, kind models.LoginSubject, subject string, now time.Time) (time.Time, error)
+	RegisterLoginFailure(ctx context.Context, kind models.LoginSubject, subject string, now time.Time, window time.Duration) (int, error)
+	LockLogin(ctx context.Context, kind models.LoginSubject, subject string, until time.Time) error
+	ResetLoginFailures(ctx context.Context, kind models.LoginSubject, subject string) error
 }
 
 type AuthService struct {
-	userRepo UserRepository
+	userRepo         UserRepository
+	tokenRepo        TokenRepository
+	loginFailureRepo LoginFailureRepository
+	hasher           utils.PasswordHasher
 }
 
-func NewAuthService(userRepo UserRepository) *AuthService {
+func NewAuthService(userRepo UserRepository, tokenRepo TokenRepository, loginFailureRepo LoginFailureRepository, hasher utils.PasswordHasher) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		loginFailureRepo: loginFailureRepo,
+		hasher:           hasher,
 	}
 }
 
 func (a *AuthService) DummyLogin(ctx context.Context, role string) (string, error) {
 	logger.Info(ctx, "Trying to gen.bat token")
 
-	token, err := utils.GenerateToken(role)
+	// у тестового пользователя нет записи в БД, поэтому идентификатор генерируется на каждый вход
+	token, _, err := utils.GenerateToken(models.Principal{
+		UserId: uuid.NewString(),
+		Role:   role,
+		Dummy:  true,
+	})
 	if err != nil {
 		logger.Error(ctx, fmt.Sprintf("Error generating token: %s", err.Error()))
 		return "", err
@@ -46,13 +110,16 @@ func (a *AuthService) DummyLogin(ctx context.Context, role string) (string, erro
 func (a *AuthService) CreateUser(ctx context.Context, in forms.SignUpFormIn) (models.User, error) {
 	logger.Info(ctx, "Trying to create user")
 
-	salt := utils.GenSalt()
-	hashedPass := utils.HashPassword(in.Password, salt)
+	hashedPass, err := a.hasher.Hash(in.Password)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error hashing password: %s", err.Error()))
+		return models.User{}, err
+	}
+
 	user := models.User{
 		Id:       uuid.NewString(),
 		Email:    in.Email,
 		Password: hashedPass,
-		Salt:     salt,
 		Role:     in.Role,
 	}
 
@@ -72,21 +139,265 @@ func (a *AuthService) IsUserExist(ctx context.Context, email string) (bool, erro
 	return isExists, nil
 }
 
-func (a *AuthService) LogInUser(ctx context.Context, logInForm forms.LogInFormIn) (string, error) {
+func (a *AuthService) LogInUser(ctx context.Context, logInForm forms.LogInFormIn) (models.TokenPair, error) {
 	loginData := models.LoginData{
 		Email:    logInForm.Email,
 		Password: logInForm.Password,
 	}
+	emailKey := normalizeEmail(loginData.Email)
+
+	if err := a.checkLoginLock(ctx, models.LoginByIP, logInForm.ClientIP, TooManyLoginAttempts); err != nil {
+		return models.TokenPair{}, err
+	}
+
+	if err := a.checkLoginLock(ctx, models.LoginByEmail, emailKey, AccountLocked); err != nil {
+		return models.TokenPair{}, err
+	}
 
 	user, err := a.userRepo.GetUserByEmail(ctx, loginData)
 	if err != nil {
-		return "", err
+		return models.TokenPair{}, err
+	}
+
+	isValid, err := a.checkPassword(loginData.Password, user)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to verify password of user %s: %s", user.Id, err.Error()))
+		isValid = false
 	}
 
-	if !utils.CheckPassword(loginData.Password, user.Password, user.Salt) {
+	if !isValid {
 		logger.Error(ctx, "Passwords don't match")
-		return "", errors.New("wrong auth data")
+		a.registerLoginFailure(ctx, models.LoginByEmail, emailKey, MaxEmailLoginFailures)
+		a.registerLoginFailure(ctx, models.LoginByIP, logInForm.ClientIP, MaxIPLoginFailures)
+		return models.TokenPair{}, WrongAuthData
+	}
+
+	// статус проверяется после пароля, чтобы по ответу нельзя было узнать о деактивации чужого аккаунта
+	if user.Status == models.UserDeactivated {
+		logger.Error(ctx, fmt.Sprintf("Deactivated user %s tried to log in", user.Id))
+		return models.TokenPair{}, AccountDeactivated
+	}
+
+	// счётчик IP не сбрасывается: иначе подбор можно было бы прерывать входом в свой аккаунт
+	if err = a.loginFailureRepo.ResetLoginFailures(ctx, models.LoginByEmail, emailKey); err != nil {
+		return models.TokenPair{}, err
+	}
+
+	if utils.IsLegacyPasswordHash(user.Password) || a.hasher.NeedsRehash(user.Password) {
+		a.rehashPassword(ctx, user.Id, loginData.Password)
+	}
+
+	return a.issueTokens(ctx, user)
+}
+
+// UnlockUser снимает блокировку входа с аккаунта пользователя и обнуляет счётчик его неудачных попыток
+func (a *AuthService) UnlockUser(ctx context.Context, userId string) error {
+	user, err := a.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if user.Id == "" {
+		return UserNotFound
+	}
+
+	if err = a.loginFailureRepo.ResetLoginFailures(ctx, models.LoginByEmail, normalizeEmail(user.Email)); err != nil {
+		return err
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Login of user %s was unlocked by user %s", user.Id, principal.UserId))
+
+	return nil
+}
+
+func (a *AuthService) checkLoginLock(ctx context.Context, kind models.LoginSubject, subject string, reason error) error {
+	if subject == "" {
+		return nil
+	}
+
+	lockedUntil, err := a.loginFailureRepo.GetLoginLock(ctx, kind, subject, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !lockedUntil.IsZero() {
+		logger.Warn(ctx, fmt.Sprintf("Login attempt by locked %s %s", kind, subject))
+		return &LoginLockError{Reason: reason, Until: lockedUntil}
+	}
+
+	return nil
+}
+
+// registerLoginFailure блокирует вход после maxFailures неудач, каждая следующая неудача удваивает блокировку.
+// Ошибки хранилища только логируются, чтобы пользователь получил обычный ответ о неверных данных
+func (a *AuthService) registerLoginFailure(ctx context.Context, kind models.LoginSubject, subject string, maxFailures int) {
+	if subject == "" {
+		return
+	}
+
+	now := time.Now()
+	failures, err := a.loginFailureRepo.RegisterLoginFailure(ctx, kind, subject, now, LoginFailureWindow)
+	if err != nil || failures < maxFailures {
+		return
+	}
+
+	_ = a.loginFailureRepo.LockLogin(ctx, kind, subject, now.Add(loginLockDuration(failures-maxFailures)))
+}
+
+func loginLockDuration(extraFailures int) time.Duration {
+	duration := LoginLockBase
+	for i := 0; i < extraFailures && duration < LoginLockMax; i++ {
+		duration *= 2
+	}
+
+	return min(duration, LoginLockMax)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (a *AuthService) checkPassword(password string, user models.User) (bool, error) {
+	if utils.IsLegacyPasswordHash(user.Password) {
+		return utils.CheckPassword(password, user.Password, user.Salt), nil
+	}
+
+	return utils.VerifyPassword(password, user.Password)
+}
+
+// rehashPassword переводит пароль на текущий алгоритм; ошибка не мешает входу, попытка повторится при следующем логине
+func (a *AuthService) rehashPassword(ctx context.Context, userId, password string) {
+	hashedPass, err := a.hasher.Hash(password)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error rehashing password of user %s: %s", userId, err.Error()))
+		return
+	}
+
+	if err = a.userRepo.UpdatePassword(ctx, userId, hashedPass, ""); err != nil {
+		return
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Password of user %s was rehashed", userId))
+}
+
+func (a *AuthService) RefreshToken(ctx context.Context, refreshToken string) (models.TokenPair, error) {
+	logger.Info(ctx, "Trying to refresh token pair")
+
+	token, err := a.tokenRepo.GetRefreshToken(ctx, utils.HashRefreshToken(refreshToken))
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	if token.Id == uuid.Nil {
+		return models.TokenPair{}, InvalidRefreshToken
+	}
+
+	if !token.RevokedAt.IsZero() {
+		// повторное использование уже ротированного токена: считаем, что его украли, и завершаем все сессии
+		logger.Warn(ctx, fmt.Sprintf("Refresh token %s was reused, revoking all sessions of user %s", token.Id, token.UserId))
+		if err = a.tokenRepo.RevokeUserRefreshTokens(ctx, token.UserId); err != nil {
+			return models.TokenPair{}, err
+		}
+		return models.TokenPair{}, InvalidRefreshToken
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		logger.Error(ctx, fmt.Sprintf("Refresh token %s has expired", token.Id))
+		return models.TokenPair{}, InvalidRefreshToken
+	}
+
+	isRevoked, err := a.tokenRepo.RevokeRefreshToken(ctx, token.Id)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	if !isRevoked {
+		logger.Error(ctx, fmt.Sprintf("Refresh token %s was concurrently rotated", token.Id))
+		return models.TokenPair{}, InvalidRefreshToken
+	}
+
+	user, err := a.userRepo.GetUserById(ctx, token.UserId)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	if user.Id == "" || user.Status == models.UserDeactivated {
+		return models.TokenPair{}, InvalidRefreshToken
+	}
+
+	return a.issueTokens(ctx, user)
+}
+
+func (a *AuthService) Logout(ctx context.Context, principal models.Principal, refreshToken string) error {
+	logger.Info(ctx, fmt.Sprintf("Trying to logout, access token: %s", principal.TokenId))
+
+	if err := a.tokenRepo.RevokeAccessToken(ctx, principal.TokenId, principal.ExpiresAt); err != nil {
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	token, err := a.tokenRepo.GetRefreshToken(ctx, utils.HashRefreshToken(refreshToken))
+	if err != nil {
+		return err
+	}
+
+	if token.Id == uuid.Nil || token.UserId != principal.UserId {
+		return InvalidRefreshToken
+	}
+
+	if _, err = a.tokenRepo.RevokeRefreshToken(ctx, token.Id); err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "Successfully logged out")
+	return nil
+}
+
+func (a *AuthService) GetJWKS(ctx context.Context) models.JSONWebKeySet {
+	return utils.CurrentTokenKeys().JWKS()
+}
+
+func (a *AuthService) IsTokenRevoked(ctx context.Context, principal models.Principal) (bool, error) {
+	return a.tokenRepo.IsAccessTokenRevoked(ctx, principal.TokenId, principal.UserId, principal.Role, principal.IssuedAt)
+}
+
+func (a *AuthService) issueTokens(ctx context.Context, user models.User) (models.TokenPair, error) {
+	accessToken, principal, err := utils.GenerateToken(models.Principal{
+		UserId: user.Id,
+		Email:  user.Email,
+		Role:   user.Role,
+	})
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error generating access token: %s", err.Error()))
+		return models.TokenPair{}, err
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error generating refresh token: %s", err.Error()))
+		return models.TokenPair{}, err
+	}
+
+	now := time.Now()
+	err = a.tokenRepo.CreateRefreshToken(ctx, models.RefreshToken{
+		Id:        uuid.New(),
+		UserId:    user.Id,
+		TokenHash: utils.HashRefreshToken(refreshToken),
+		CreatedAt: now,
+		ExpiresAt: now.Add(utils.RefreshTokenTTL),
+	})
+	if err != nil {
+		return models.TokenPair{}, err
 	}
 
-	return user.Role, nil
+	logger.Info(ctx, fmt.Sprintf("Token pair was successfully issued for user: %s", user.Id))
+	return models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    principal.ExpiresAt,
+	}, nil
 }
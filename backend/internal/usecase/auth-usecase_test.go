@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"pvz/internal/delivery/forms"
@@ -15,12 +17,33 @@ import (
 	"pvz/internal/utils"
 )
 
+var testHasher = &utils.BcryptHasher{Cost: 4}
+
+// rehashedPassword проверяет, что в репозиторий передан хеш пароля, полученный текущим хешером
+type rehashedPassword struct {
+	password string
+}
+
+func (r rehashedPassword) Matches(x interface{}) bool {
+	encoded, ok := x.(string)
+	if !ok {
+		return false
+	}
+
+	isValid, err := utils.VerifyPassword(r.password, encoded)
+	return err == nil && isValid && !testHasher.NeedsRehash(encoded)
+}
+
+func (r rehashedPassword) String() string {
+	return "is current hash of " + r.password
+}
+
 func TestAuthService_CreateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	service := usecase.NewAuthService(mockRepo)
+	service := usecase.NewAuthService(mockRepo, nil, nil, testHasher)
 
 	tests := []struct {
 		name    string
@@ -37,7 +60,14 @@ func TestAuthService_CreateUser(t *testing.T) {
 				Role:     "admin",
 			},
 			mock: func() {
-				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user models.User) error {
+					assert.False(t, utils.IsLegacyPasswordHash(user.Password))
+					assert.Empty(t, user.Salt)
+					ok, err := utils.VerifyPassword("secure", user.Password)
+					assert.NoError(t, err)
+					assert.True(t, ok)
+					return nil
+				})
 			},
 			wantErr: false,
 			want:    &models.User{Email: "test@example.com", Role: "admin"},
@@ -75,7 +105,7 @@ func TestAuthService_IsUserExist(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	service := usecase.NewAuthService(mockRepo)
+	service := usecase.NewAuthService(mockRepo, nil, nil, testHasher)
 
 	tests := []struct {
 		name    string
@@ -128,11 +158,25 @@ func TestAuthService_LogInUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserRepository(ctrl)
-	service := usecase.NewAuthService(mockRepo)
+	mockTokenRepo := mocks.NewMockTokenRepository(ctrl)
+	mockFailureRepo := mocks.NewMockLoginFailureRepository(ctrl)
+	service := usecase.NewAuthService(mockRepo, mockTokenRepo, mockFailureRepo, testHasher)
+
+	// блокировки проверяются отдельно в TestAuthService_LogInUserLockout
+	mockFailureRepo.EXPECT().GetLoginLock(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Time{}, nil).AnyTimes()
+	mockFailureRepo.EXPECT().RegisterLoginFailure(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, nil).AnyTimes()
+	mockFailureRepo.EXPECT().ResetLoginFailures(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	rawPassword := "password123"
-	salt := utils.GenSalt()
-	hashed := utils.HashPassword(rawPassword, salt)
+	hashed, err := testHasher.Hash(rawPassword)
+	assert.NoError(t, err)
+	outdated, err := (&utils.BcryptHasher{Cost: 5}).Hash(rawPassword)
+	assert.NoError(t, err)
+	legacySalt := "randomSalt"
+	legacy := utils.HashPassword(rawPassword, legacySalt)
+
+	isRehashed := gomock.AssignableToTypeOf("")
+	verifiesRawPassword := rehashedPassword{password: rawPassword}
 
 	tests := []struct {
 		name    string
@@ -149,15 +193,108 @@ func TestAuthService_LogInUser(t *testing.T) {
 			},
 			mock: func() {
 				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
+					Id:       "user-id",
 					Email:    "login@example.com",
 					Password: hashed,
-					Salt:     salt,
 					Role:     "user",
 				}, nil)
+				mockTokenRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want:    "user",
+			wantErr: false,
+		},
+		{
+			name: "legacy hash is upgraded",
+			input: forms.LogInFormIn{
+				Email:    "login@example.com",
+				Password: rawPassword,
+			},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
+					Id:       "user-id",
+					Email:    "login@example.com",
+					Password: legacy,
+					Salt:     legacySalt,
+					Role:     "user",
+				}, nil)
+				mockRepo.EXPECT().UpdatePassword(gomock.Any(), "user-id", verifiesRawPassword, "").Return(nil)
+				mockTokenRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want:    "user",
+			wantErr: false,
+		},
+		{
+			name: "outdated parameters are upgraded",
+			input: forms.LogInFormIn{
+				Email:    "login@example.com",
+				Password: rawPassword,
+			},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
+					Id:       "user-id",
+					Email:    "login@example.com",
+					Password: outdated,
+					Role:     "user",
+				}, nil)
+				mockRepo.EXPECT().UpdatePassword(gomock.Any(), "user-id", verifiesRawPassword, "").Return(nil)
+				mockTokenRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			want:    "user",
 			wantErr: false,
 		},
+		{
+			name: "failed upgrade does not block login",
+			input: forms.LogInFormIn{
+				Email:    "login@example.com",
+				Password: rawPassword,
+			},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
+					Id:       "user-id",
+					Email:    "login@example.com",
+					Password: legacy,
+					Salt:     legacySalt,
+					Role:     "user",
+				}, nil)
+				mockRepo.EXPECT().UpdatePassword(gomock.Any(), "user-id", isRehashed, "").Return(errors.New("db fail"))
+				mockTokenRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want:    "user",
+			wantErr: false,
+		},
+		{
+			name: "wrong legacy password",
+			input: forms.LogInFormIn{
+				Email:    "login@example.com",
+				Password: "wrongpass",
+			},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
+					Email:    "login@example.com",
+					Password: legacy,
+					Salt:     legacySalt,
+					Role:     "user",
+				}, nil)
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "unknown hash format",
+			input: forms.LogInFormIn{
+				Email:    "login@example.com",
+				Password: rawPassword,
+			},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
+					Email:    "login@example.com",
+					Password: "$md5$hash",
+					Role:     "user",
+				}, nil)
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "wrong password",
 			input: forms.LogInFormIn{
@@ -168,13 +305,30 @@ func TestAuthService_LogInUser(t *testing.T) {
 				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
 					Email:    "login@example.com",
 					Password: hashed,
-					Salt:     salt,
 					Role:     "user",
 				}, nil)
 			},
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name: "deactivated account",
+			input: forms.LogInFormIn{
+				Email:    "login@example.com",
+				Password: rawPassword,
+			},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(models.User{
+					Id:       "user-id",
+					Email:    "login@example.com",
+					Password: hashed,
+					Role:     "user",
+					Status:   models.UserDeactivated,
+				}, nil)
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "repo error",
 			input: forms.LogInFormIn{
@@ -192,15 +346,156 @@ func TestAuthService_LogInUser(t *testing.T) {
 	for _, tt := range tests {
 		tt.mock()
 		t.Run(tt.name, func(t *testing.T) {
-			role, err := service.LogInUser(context.Background(), tt.input)
-			assert.Equal(t, tt.want, role)
+			pair, err := service.LogInUser(context.Background(), tt.input)
 			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Empty(t, pair.AccessToken)
+				return
+			}
+
+			principal, err := utils.ParseToken(pair.AccessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, principal.Role)
+			assert.Equal(t, "user-id", principal.UserId)
+			assert.Equal(t, "login@example.com", principal.Email)
+			assert.NotEmpty(t, pair.RefreshToken)
 		})
 	}
 }
 
+func TestAuthService_LogInUserLockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockFailureRepo := mocks.NewMockLoginFailureRepository(ctrl)
+	service := usecase.NewAuthService(mockRepo, nil, mockFailureRepo, testHasher)
+
+	hashed, err := testHasher.Hash("password123")
+	assert.NoError(t, err)
+	user := models.User{Id: "user-id", Email: "login@example.com", Password: hashed, Role: "employee"}
+
+	form := forms.LogInFormIn{Email: " Login@Example.com", Password: "wrongpass", ClientIP: "10.0.0.1"}
+	lockedUntil := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		mock      func()
+		wantErr   error
+		wantUntil time.Time
+	}{
+		{
+			name: "locked ip",
+			mock: func() {
+				mockFailureRepo.EXPECT().GetLoginLock(gomock.Any(), models.LoginByIP, "10.0.0.1", gomock.Any()).Return(lockedUntil, nil)
+			},
+			wantErr:   usecase.TooManyLoginAttempts,
+			wantUntil: lockedUntil,
+		},
+		{
+			name: "locked account",
+			mock: func() {
+				mockFailureRepo.EXPECT().GetLoginLock(gomock.Any(), models.LoginByIP, "10.0.0.1", gomock.Any()).Return(time.Time{}, nil)
+				mockFailureRepo.EXPECT().GetLoginLock(gomock.Any(), models.LoginByEmail, "login@example.com", gomock.Any()).Return(lockedUntil, nil)
+			},
+			wantErr:   usecase.AccountLocked,
+			wantUntil: lockedUntil,
+		},
+		{
+			name: "failure below threshold is only counted",
+			mock: func() {
+				mockFailureRepo.EXPECT().GetLoginLock(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Time{}, nil).Times(2)
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockFailureRepo.EXPECT().RegisterLoginFailure(gomock.Any(), models.LoginByEmail, "login@example.com", gomock.Any(), usecase.LoginFailureWindow).
+					Return(usecase.MaxEmailLoginFailures-1, nil)
+				mockFailureRepo.EXPECT().RegisterLoginFailure(gomock.Any(), models.LoginByIP, "10.0.0.1", gomock.Any(), usecase.LoginFailureWindow).
+					Return(1, nil)
+			},
+			wantErr: usecase.WrongAuthData,
+		},
+		{
+			name: "threshold failure locks account with backoff",
+			mock: func() {
+				mockFailureRepo.EXPECT().GetLoginLock(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Time{}, nil).Times(2)
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockFailureRepo.EXPECT().RegisterLoginFailure(gomock.Any(), models.LoginByEmail, "login@example.com", gomock.Any(), usecase.LoginFailureWindow).
+					Return(usecase.MaxEmailLoginFailures+2, nil)
+				mockFailureRepo.EXPECT().RegisterLoginFailure(gomock.Any(), models.LoginByIP, "10.0.0.1", gomock.Any(), usecase.LoginFailureWindow).
+					Return(1, nil)
+				mockFailureRepo.EXPECT().LockLogin(gomock.Any(), models.LoginByEmail, "login@example.com", lockedFor{4 * usecase.LoginLockBase}).
+					Return(nil)
+			},
+			wantErr: usecase.WrongAuthData,
+		},
+		{
+			name: "backoff is capped",
+			mock: func() {
+				mockFailureRepo.EXPECT().GetLoginLock(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(time.Time{}, nil).Times(2)
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Return(user, nil)
+				mockFailureRepo.EXPECT().RegisterLoginFailure(gomock.Any(), models.LoginByEmail, "login@example.com", gomock.Any(), usecase.LoginFailureWindow).
+					Return(usecase.MaxEmailLoginFailures+50, nil)
+				mockFailureRepo.EXPECT().RegisterLoginFailure(gomock.Any(), models.LoginByIP, "10.0.0.1", gomock.Any(), usecase.LoginFailureWindow).
+					Return(usecase.MaxIPLoginFailures, nil)
+				mockFailureRepo.EXPECT().LockLogin(gomock.Any(), models.LoginByEmail, "login@example.com", lockedFor{usecase.LoginLockMax}).
+					Return(nil)
+				mockFailureRepo.EXPECT().LockLogin(gomock.Any(), models.LoginByIP, "10.0.0.1", lockedFor{usecase.LoginLockBase}).
+					Return(nil)
+			},
+			wantErr: usecase.WrongAuthData,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.LogInUser(context.Background(), form)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var lockErr *usecase.LoginLockError
+			if !tt.wantUntil.IsZero() && assert.ErrorAs(t, err, &lockErr) {
+				assert.Equal(t, tt.wantUntil, lockErr.Until)
+			}
+		})
+	}
+}
+
+// lockedFor проверяет, что блокировка выставлена примерно на duration от текущего момента
+type lockedFor struct {
+	duration time.Duration
+}
+
+func (l lockedFor) Matches(x interface{}) bool {
+	until, ok := x.(time.Time)
+	if !ok {
+		return false
+	}
+
+	delta := time.Until(until) - l.duration
+	return delta <= 0 && delta > -time.Second
+}
+
+func (l lockedFor) String() string {
+	return "locked for " + l.duration.String()
+}
+
+func TestAuthService_UnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockFailureRepo := mocks.NewMockLoginFailureRepository(ctrl)
+	service := usecase.NewAuthService(mockRepo, nil, mockFailureRepo, testHasher)
+
+	mockRepo.EXPECT().GetUserById(gomock.Any(), "user-id").Return(models.User{Id: "user-id", Email: "User@Example.com"}, nil)
+	mockFailureRepo.EXPECT().ResetLoginFailures(gomock.Any(), models.LoginByEmail, "user@example.com").Return(nil)
+	assert.NoError(t, service.UnlockUser(context.Background(), "user-id"))
+
+	mockRepo.EXPECT().GetUserById(gomock.Any(), "missing-id").Return(models.User{}, nil)
+	assert.ErrorIs(t, service.UnlockUser(context.Background(), "missing-id"), usecase.UserNotFound)
+}
+
 func TestAuthService_DummyLogin(t *testing.T) {
-	service := usecase.NewAuthService(nil)
+	service := usecase.NewAuthService(nil, nil, nil, testHasher)
 
 	tests := []struct {
 		name    string
@@ -221,7 +516,178 @@ func TestAuthService_DummyLogin(t *testing.T) {
 				assert.Empty(t, token)
 			} else {
 				assert.NotEmpty(t, token)
+				principal, parseErr := utils.ParseToken(token)
+				assert.NoError(t, parseErr)
+				assert.True(t, principal.Dummy)
+			}
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestAuthService_RefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	mockTokenRepo := mocks.NewMockTokenRepository(ctrl)
+	service := usecase.NewAuthService(mockRepo, mockTokenRepo, nil, testHasher)
+
+	refreshToken := "refresh-token"
+	tokenHash := utils.HashRefreshToken(refreshToken)
+	user := models.User{Id: uuid.NewString(), Email: "emp@example.com", Role: string(models.Employee)}
+	stored := models.RefreshToken{
+		Id:        uuid.New(),
+		UserId:    user.Id,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	revoked := stored
+	revoked.RevokedAt = time.Now().Add(-time.Minute)
+
+	expired := stored
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success rotation",
+			mock: func() {
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), tokenHash).Return(stored, nil)
+				mockTokenRepo.EXPECT().RevokeRefreshToken(gomock.Any(), stored.Id).Return(true, nil)
+				mockRepo.EXPECT().GetUserById(gomock.Any(), user.Id).Return(user, nil)
+				mockTokenRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "user was deleted",
+			mock: func() {
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), tokenHash).Return(stored, nil)
+				mockTokenRepo.EXPECT().RevokeRefreshToken(gomock.Any(), stored.Id).Return(true, nil)
+				mockRepo.EXPECT().GetUserById(gomock.Any(), user.Id).Return(models.User{}, nil)
+			},
+			wantErr: usecase.InvalidRefreshToken,
+		},
+		{
+			name: "unknown token",
+			mock: func() {
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), tokenHash).Return(models.RefreshToken{}, nil)
+			},
+			wantErr: usecase.InvalidRefreshToken,
+		},
+		{
+			name: "reused token revokes all sessions",
+			mock: func() {
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), tokenHash).Return(revoked, nil)
+				mockTokenRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), stored.UserId).Return(nil)
+			},
+			wantErr: usecase.InvalidRefreshToken,
+		},
+		{
+			name: "expired token",
+			mock: func() {
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), tokenHash).Return(expired, nil)
+			},
+			wantErr: usecase.InvalidRefreshToken,
+		},
+		{
+			name: "concurrent rotation",
+			mock: func() {
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), tokenHash).Return(stored, nil)
+				mockTokenRepo.EXPECT().RevokeRefreshToken(gomock.Any(), stored.Id).Return(false, nil)
+			},
+			wantErr: usecase.InvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			pair, err := service.RefreshToken(context.Background(), refreshToken)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
 			}
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, refreshToken, pair.RefreshToken)
+
+			principal, err := utils.ParseToken(pair.AccessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, user.Id, principal.UserId)
+			assert.Equal(t, user.Email, principal.Email)
+		})
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokenRepo := mocks.NewMockTokenRepository(ctrl)
+	service := usecase.NewAuthService(nil, mockTokenRepo, nil, testHasher)
+
+	principal := models.Principal{UserId: uuid.NewString(), TokenId: uuid.NewString(), Role: string(models.Employee), ExpiresAt: time.Now().Add(time.Minute)}
+	refreshToken := "refresh-token"
+	stored := models.RefreshToken{Id: uuid.New(), UserId: principal.UserId}
+	foreign := models.RefreshToken{Id: uuid.New(), UserId: uuid.NewString()}
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		mock         func()
+		wantErr      bool
+	}{
+		{
+			name: "access token only",
+			mock: func() {
+				mockTokenRepo.EXPECT().RevokeAccessToken(gomock.Any(), principal.TokenId, principal.ExpiresAt).Return(nil)
+			},
+		},
+		{
+			name:         "access and refresh tokens",
+			refreshToken: refreshToken,
+			mock: func() {
+				mockTokenRepo.EXPECT().RevokeAccessToken(gomock.Any(), principal.TokenId, principal.ExpiresAt).Return(nil)
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), utils.HashRefreshToken(refreshToken)).Return(stored, nil)
+				mockTokenRepo.EXPECT().RevokeRefreshToken(gomock.Any(), stored.Id).Return(true, nil)
+			},
+		},
+		{
+			name:         "unknown refresh token",
+			refreshToken: refreshToken,
+			mock: func() {
+				mockTokenRepo.EXPECT().RevokeAccessToken(gomock.Any(), principal.TokenId, principal.ExpiresAt).Return(nil)
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), utils.HashRefreshToken(refreshToken)).Return(models.RefreshToken{}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:         "refresh token of another user",
+			refreshToken: refreshToken,
+			mock: func() {
+				mockTokenRepo.EXPECT().RevokeAccessToken(gomock.Any(), principal.TokenId, principal.ExpiresAt).Return(nil)
+				mockTokenRepo.EXPECT().GetRefreshToken(gomock.Any(), utils.HashRefreshToken(refreshToken)).Return(foreign, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "repository error",
+			mock: func() {
+				mockTokenRepo.EXPECT().RevokeAccessToken(gomock.Any(), principal.TokenId, principal.ExpiresAt).Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.Logout(context.Background(), principal, tt.refreshToken)
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+var (
+	CategoryNotFound       = errors.New("category not found")
+	CategoryAlreadyExists  = errors.New("category already exists")
+	ParentCategoryNotFound = errors.New("parent category not found")
+	CategoryCycle          = errors.New("category can't be nested into itself or its descendant")
+)
+
+type CategoryRepository interface {
+	ListCategories(ctx context.Context) ([]models.Category, error)
+	GetCategory(ctx context.Context, categoryId uuid.UUID) (models.Category, error)
+	CreateCategory(ctx context.Context, category models.Category) (bool, error)
+	UpdateCategory(ctx context.Context, categoryId uuid.UUID, form forms.UpdateCategoryForm) (models.Category, error)
+}
+
+type CategoryService struct {
+	categoryRepo CategoryRepository
+}
+
+func NewCategoryService(categoryRepo CategoryRepository) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (c *CategoryService) ListCategories(ctx context.Context) ([]models.Category, error) {
+	return c.categoryRepo.ListCategories(ctx)
+}
+
+func (c *CategoryService) CreateCategory(ctx context.Context, form forms.CreateCategoryForm) (models.Category, error) {
+	if form.ParentId != uuid.Nil {
+		if err := c.checkParent(ctx, uuid.Nil, form.ParentId); err != nil {
+			return models.Category{}, err
+		}
+	}
+
+	category := models.Category{
+		Id:        uuid.New(),
+		Code:      form.Code,
+		Names:     form.Names,
+		Active:    true,
+		ParentId:  form.ParentId,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	isCreated, err := c.categoryRepo.CreateCategory(ctx, category)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	if !isCreated {
+		return models.Category{}, CategoryAlreadyExists
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Category %s was created by user %s", category.Code, principal.UserId))
+
+	return category, nil
+}
+
+// UpdateCategory меняет названия, активность и родителя. Код категории не меняется, так как хранится в товарах
+func (c *CategoryService) UpdateCategory(ctx context.Context, categoryId uuid.UUID, form forms.UpdateCategoryForm) (models.Category, error) {
+	if form.ParentId != nil && *form.ParentId != uuid.Nil {
+		if err := c.checkParent(ctx, categoryId, *form.ParentId); err != nil {
+			return models.Category{}, err
+		}
+	}
+
+	category, err := c.categoryRepo.UpdateCategory(ctx, categoryId, form)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	if category.Id == uuid.Nil {
+		return models.Category{}, CategoryNotFound
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Category %s was updated by user %s", categoryId, principal.UserId))
+
+	return category, nil
+}
+
+// checkParent проверяет, что родитель существует и что категория не окажется среди собственных предков
+func (c *CategoryService) checkParent(ctx context.Context, categoryId, parentId uuid.UUID) error {
+	visited := make(map[uuid.UUID]struct{})
+	for id := parentId; id != uuid.Nil; {
+		if id == categoryId {
+			return CategoryCycle
+		}
+		if _, ok := visited[id]; ok {
+			return CategoryCycle
+		}
+		visited[id] = struct{}{}
+
+		ancestor, err := c.categoryRepo.GetCategory(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if ancestor.Id == uuid.Nil {
+			return ParentCategoryNotFound
+		}
+
+		id = ancestor.ParentId
+	}
+
+	return nil
+}
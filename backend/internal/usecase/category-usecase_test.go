@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/usecase/mocks"
+)
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := usecase.NewCategoryService(mockRepo)
+
+	parentId := uuid.New()
+	form := forms.CreateCategoryForm{Code: "книги", Names: map[string]string{"ru": "Книги"}, ParentId: parentId}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockRepo.EXPECT().GetCategory(gomock.Any(), parentId).Return(models.Category{Id: parentId}, nil)
+				mockRepo.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, category models.Category) (bool, error) {
+					assert.Equal(t, "книги", category.Code)
+					assert.Equal(t, parentId, category.ParentId)
+					assert.True(t, category.Active)
+					return true, nil
+				})
+			},
+		},
+		{
+			name: "parent not found",
+			mock: func() {
+				mockRepo.EXPECT().GetCategory(gomock.Any(), parentId).Return(models.Category{}, nil)
+			},
+			wantErr: usecase.ParentCategoryNotFound,
+		},
+		{
+			name: "code is taken",
+			mock: func() {
+				mockRepo.EXPECT().GetCategory(gomock.Any(), parentId).Return(models.Category{Id: parentId}, nil)
+				mockRepo.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: usecase.CategoryAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			category, err := service.CreateCategory(context.Background(), form)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, form.Names, category.Names)
+			}
+		})
+	}
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCategoryRepository(ctrl)
+	service := usecase.NewCategoryService(mockRepo)
+
+	categoryId := uuid.New()
+	childId := uuid.New()
+	otherId := uuid.New()
+	root := uuid.Nil
+	active := false
+
+	tests := []struct {
+		name    string
+		form    forms.UpdateCategoryForm
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "deactivate",
+			form: forms.UpdateCategoryForm{Active: &active},
+			mock: func() {
+				mockRepo.EXPECT().UpdateCategory(gomock.Any(), categoryId, forms.UpdateCategoryForm{Active: &active}).
+					Return(models.Category{Id: categoryId}, nil)
+			},
+		},
+		{
+			name: "move to root",
+			form: forms.UpdateCategoryForm{ParentId: &root},
+			mock: func() {
+				mockRepo.EXPECT().UpdateCategory(gomock.Any(), categoryId, gomock.Any()).Return(models.Category{Id: categoryId}, nil)
+			},
+		},
+		{
+			name: "move under another category",
+			form: forms.UpdateCategoryForm{ParentId: &otherId},
+			mock: func() {
+				mockRepo.EXPECT().GetCategory(gomock.Any(), otherId).Return(models.Category{Id: otherId}, nil)
+				mockRepo.EXPECT().UpdateCategory(gomock.Any(), categoryId, gomock.Any()).Return(models.Category{Id: categoryId, ParentId: otherId}, nil)
+			},
+		},
+		{
+			name:    "parent is itself",
+			form:    forms.UpdateCategoryForm{ParentId: &categoryId},
+			mock:    func() {},
+			wantErr: usecase.CategoryCycle,
+		},
+		{
+			name: "parent is a descendant",
+			form: forms.UpdateCategoryForm{ParentId: &childId},
+			mock: func() {
+				mockRepo.EXPECT().GetCategory(gomock.Any(), childId).Return(models.Category{Id: childId, ParentId: categoryId}, nil)
+			},
+			wantErr: usecase.CategoryCycle,
+		},
+		{
+			name: "not found",
+			form: forms.UpdateCategoryForm{Active: &active},
+			mock: func() {
+				mockRepo.EXPECT().UpdateCategory(gomock.Any(), categoryId, gomock.Any()).Return(models.Category{}, nil)
+			},
+			wantErr: usecase.CategoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			_, err := service.UpdateCategory(context.Background(), categoryId, tt.form)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
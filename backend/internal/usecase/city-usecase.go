@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+var (
+	CityNotFound      = errors.New("city not found")
+	CityAlreadyExists = errors.New("city already exists")
+)
+
+type CityRepository interface {
+	ListCities(ctx context.Context) ([]models.City, error)
+	GetCityByName(ctx context.Context, name string) (models.City, error)
+	CreateCity(ctx context.Context, city models.City) (bool, error)
+	UpdateCity(ctx context.Context, cityId uuid.UUID, form forms.UpdateCityForm) (models.City, error)
+}
+
+type CityService struct {
+	cityRepo CityRepository
+}
+
+func NewCityService(cityRepo CityRepository) *CityService {
+	return &CityService{
+		cityRepo: cityRepo,
+	}
+}
+
+func (c *CityService) ListCities(ctx context.Context) ([]models.City, error) {
+	return c.cityRepo.ListCities(ctx)
+}
+
+func (c *CityService) CreateCity(ctx context.Context, form forms.CreateCityForm) (models.City, error) {
+	city := models.City{
+		Id:        uuid.New(),
+		Name:      form.Name,
+		Enabled:   true,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	isCreated, err := c.cityRepo.CreateCity(ctx, city)
+	if err != nil {
+		return models.City{}, err
+	}
+
+	if !isCreated {
+		return models.City{}, CityAlreadyExists
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("City %s was created by user %s", city.Name, principal.UserId))
+
+	return city, nil
+}
+
+// UpdateCity переименовывает и включает/отключает город. ПВЗ переименованного города обновляются вместе с ним
+func (c *CityService) UpdateCity(ctx context.Context, cityId uuid.UUID, form forms.UpdateCityForm) (models.City, error) {
+	if form.Name != nil {
+		existing, err := c.cityRepo.GetCityByName(ctx, *form.Name)
+		if err != nil {
+			return models.City{}, err
+		}
+
+		if existing.Id != uuid.Nil && existing.Id != cityId {
+			return models.City{}, CityAlreadyExists
+		}
+	}
+
+	city, err := c.cityRepo.UpdateCity(ctx, cityId, form)
+	if err != nil {
+		return models.City{}, err
+	}
+
+	if city.Id == uuid.Nil {
+		return models.City{}, CityNotFound
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("City %s was updated by user %s", cityId, principal.UserId))
+
+	return city, nil
+}
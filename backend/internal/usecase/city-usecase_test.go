@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/usecase/mocks"
+)
+
+func TestCityService_CreateCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCityRepository(ctrl)
+	service := usecase.NewCityService(mockRepo)
+
+	mockRepo.EXPECT().CreateCity(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, city models.City) (bool, error) {
+		assert.Equal(t, "Томск", city.Name)
+		assert.True(t, city.Enabled)
+		assert.NotEqual(t, uuid.Nil, city.Id)
+		return true, nil
+	})
+	city, err := service.CreateCity(context.Background(), forms.CreateCityForm{Name: "Томск"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Томск", city.Name)
+
+	mockRepo.EXPECT().CreateCity(gomock.Any(), gomock.Any()).Return(false, nil)
+	_, err = service.CreateCity(context.Background(), forms.CreateCityForm{Name: "Томск"})
+	assert.ErrorIs(t, err, usecase.CityAlreadyExists)
+
+	dbErr := errors.New("db error")
+	mockRepo.EXPECT().CreateCity(gomock.Any(), gomock.Any()).Return(false, dbErr)
+	_, err = service.CreateCity(context.Background(), forms.CreateCityForm{Name: "Томск"})
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestCityService_UpdateCity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCityRepository(ctrl)
+	service := usecase.NewCityService(mockRepo)
+
+	cityId := uuid.New()
+	name := "Санкт-Петербург"
+	enabled := false
+	renamed := models.City{Id: cityId, Name: name, Enabled: true}
+
+	tests := []struct {
+		name    string
+		form    forms.UpdateCityForm
+		mock    func()
+		want    models.City
+		wantErr error
+	}{
+		{
+			name: "rename",
+			form: forms.UpdateCityForm{Name: &name},
+			mock: func() {
+				mockRepo.EXPECT().GetCityByName(gomock.Any(), name).Return(models.City{}, nil)
+				mockRepo.EXPECT().UpdateCity(gomock.Any(), cityId, forms.UpdateCityForm{Name: &name}).Return(renamed, nil)
+			},
+			want: renamed,
+		},
+		{
+			name: "rename to own name",
+			form: forms.UpdateCityForm{Name: &name},
+			mock: func() {
+				mockRepo.EXPECT().GetCityByName(gomock.Any(), name).Return(renamed, nil)
+				mockRepo.EXPECT().UpdateCity(gomock.Any(), cityId, gomock.Any()).Return(renamed, nil)
+			},
+			want: renamed,
+		},
+		{
+			name: "name is taken",
+			form: forms.UpdateCityForm{Name: &name},
+			mock: func() {
+				mockRepo.EXPECT().GetCityByName(gomock.Any(), name).Return(models.City{Id: uuid.New(), Name: name}, nil)
+			},
+			wantErr: usecase.CityAlreadyExists,
+		},
+		{
+			name: "disable",
+			form: forms.UpdateCityForm{Enabled: &enabled},
+			mock: func() {
+				mockRepo.EXPECT().UpdateCity(gomock.Any(), cityId, forms.UpdateCityForm{Enabled: &enabled}).
+					Return(models.City{Id: cityId, Name: name}, nil)
+			},
+			want: models.City{Id: cityId, Name: name},
+		},
+		{
+			name: "not found",
+			form: forms.UpdateCityForm{Enabled: &enabled},
+			mock: func() {
+				mockRepo.EXPECT().UpdateCity(gomock.Any(), cityId, gomock.Any()).Return(models.City{}, nil)
+			},
+			wantErr: usecase.CityNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			got, err := service.UpdateCity(context.Background(), cityId, tt.form)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
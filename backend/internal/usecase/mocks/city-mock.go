@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase\city-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	forms "pvz/internal/delivery/forms"
+	models "pvz/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockCityRepository is a mock of CityRepository interface.
+type MockCityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCityRepositoryMockRecorder
+}
+
+// MockCityRepositoryMockRecorder is the mock recorder for MockCityRepository.
+type MockCityRepositoryMockRecorder struct {
+	mock *MockCityRepository
+}
+
+// NewMockCityRepository creates a new mock instance.
+func NewMockCityRepository(ctrl *gomock.Controller) *MockCityRepository {
+	mock := &MockCityRepository{ctrl: ctrl}
+	mock.recorder = &MockCityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCityRepository) EXPECT() *MockCityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCity mocks base method.
+func (m *MockCityRepository) CreateCity(ctx context.Context, city models.City) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCity", ctx, city)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCity indicates an expected call of CreateCity.
+func (mr *MockCityRepositoryMockRecorder) CreateCity(ctx, city interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCity", reflect.TypeOf((*MockCityRepository)(nil).CreateCity), ctx, city)
+}
+
+// GetCityByName mocks base method.
+func (m *MockCityRepository) GetCityByName(ctx context.Context, name string) (models.City, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCityByName", ctx, name)
+	ret0, _ := ret[0].(models.City)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCityByName indicates an expected call of GetCityByName.
+func (mr *MockCityRepositoryMockRecorder) GetCityByName(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCityByName", reflect.TypeOf((*MockCityRepository)(nil).GetCityByName), ctx, name)
+}
+
+// ListCities mocks base method.
+func (m *MockCityRepository) ListCities(ctx context.Context) ([]models.City, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCities", ctx)
+	ret0, _ := ret[0].([]models.City)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCities indicates an expected call of ListCities.
+func (mr *MockCityRepositoryMockRecorder) ListCities(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCities", reflect.TypeOf((*MockCityRepository)(nil).ListCities), ctx)
+}
+
+// UpdateCity mocks base method.
+func (m *MockCityRepository) UpdateCity(ctx context.Context, cityId uuid.UUID, form forms.UpdateCityForm) (models.City, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCity", ctx, cityId, form)
+	ret0, _ := ret[0].(models.City)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCity indicates an expected call of UpdateCity.
+func (mr *MockCityRepositoryMockRecorder) UpdateCity(ctx, cityId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCity", reflect.TypeOf((*MockCityRepository)(nil).UpdateCity), ctx, cityId, form)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase\order-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, order models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderRepositoryMockRecorder) CreateOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderRepository)(nil).CreateOrder), ctx, order)
+}
+
+// GetClientOrders mocks base method.
+func (m *MockOrderRepository) GetClientOrders(ctx context.Context, clientId string, status models.OrderStatus) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientOrders", ctx, clientId, status)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientOrders indicates an expected call of GetClientOrders.
+func (mr *MockOrderRepositoryMockRecorder) GetClientOrders(ctx, clientId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientOrders", reflect.TypeOf((*MockOrderRepository)(nil).GetClientOrders), ctx, clientId, status)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderRepository) GetOrder(ctx context.Context, orderId uuid.UUID) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, orderId)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderRepositoryMockRecorder) GetOrder(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderRepository)(nil).GetOrder), ctx, orderId)
+}
+
+// GetOrderProduct mocks base method.
+func (m *MockOrderRepository) GetOrderProduct(ctx context.Context, productId uuid.UUID) (models.ProductLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderProduct", ctx, productId)
+	ret0, _ := ret[0].(models.ProductLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderProduct indicates an expected call of GetOrderProduct.
+func (mr *MockOrderRepositoryMockRecorder) GetOrderProduct(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderProduct", reflect.TypeOf((*MockOrderRepository)(nil).GetOrderProduct), ctx, productId)
+}
+
+// IssueOrder mocks base method.
+func (m *MockOrderRepository) IssueOrder(ctx context.Context, pvzId uuid.UUID, codeHash, issuedBy string, issuedAt time.Time) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueOrder", ctx, pvzId, codeHash, issuedBy, issuedAt)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueOrder indicates an expected call of IssueOrder.
+func (mr *MockOrderRepositoryMockRecorder) IssueOrder(ctx, pvzId, codeHash, issuedBy, issuedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueOrder", reflect.TypeOf((*MockOrderRepository)(nil).IssueOrder), ctx, pvzId, codeHash, issuedBy, issuedAt)
+}
+
+// SetPickupCode mocks base method.
+func (m *MockOrderRepository) SetPickupCode(ctx context.Context, orderId uuid.UUID, codeHash string, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPickupCode", ctx, orderId, codeHash, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPickupCode indicates an expected call of SetPickupCode.
+func (mr *MockOrderRepositoryMockRecorder) SetPickupCode(ctx, orderId, codeHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPickupCode", reflect.TypeOf((*MockOrderRepository)(nil).SetPickupCode), ctx, orderId, codeHash, expiresAt)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase\product-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockProductRepository is a mock of ProductRepository interface.
+type MockProductRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductRepositoryMockRecorder
+}
+
+// MockProductRepositoryMockRecorder is the mock recorder for MockProductRepository.
+type MockProductRepositoryMockRecorder struct {
+	mock *MockProductRepository
+}
+
+// NewMockProductRepository creates a new mock instance.
+func NewMockProductRepository(ctrl *gomock.Controller) *MockProductRepository {
+	mock := &MockProductRepository{ctrl: ctrl}
+	mock.recorder = &MockProductRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetProduct mocks base method.
+func (m *MockProductRepository) GetProduct(ctx context.Context, productId uuid.UUID) (models.ProductLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, productId)
+	ret0, _ := ret[0].(models.ProductLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockProductRepositoryMockRecorder) GetProduct(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProductRepository)(nil).GetProduct), ctx, productId)
+}
+
+// HasWaitingOrder mocks base method.
+func (m *MockProductRepository) HasWaitingOrder(ctx context.Context, productId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasWaitingOrder", ctx, productId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasWaitingOrder indicates an expected call of HasWaitingOrder.
+func (mr *MockProductRepositoryMockRecorder) HasWaitingOrder(ctx, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasWaitingOrder", reflect.TypeOf((*MockProductRepository)(nil).HasWaitingOrder), ctx, productId)
+}
+
+// IssueProduct mocks base method.
+func (m *MockProductRepository) IssueProduct(ctx context.Context, productId uuid.UUID, issuedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueProduct", ctx, productId, issuedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueProduct indicates an expected call of IssueProduct.
+func (mr *MockProductRepositoryMockRecorder) IssueProduct(ctx, productId, issuedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueProduct", reflect.TypeOf((*MockProductRepository)(nil).IssueProduct), ctx, productId, issuedAt)
+}
+
+// ReturnProduct mocks base method.
+func (m *MockProductRepository) ReturnProduct(ctx context.Context, productId uuid.UUID, returnedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnProduct", ctx, productId, returnedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnProduct indicates an expected call of ReturnProduct.
+func (mr *MockProductRepositoryMockRecorder) ReturnProduct(ctx, productId, returnedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnProduct", reflect.TypeOf((*MockProductRepository)(nil).ReturnProduct), ctx, productId, returnedAt)
+}
+
+// SendBackProducts mocks base method.
+func (m *MockProductRepository) SendBackProducts(ctx context.Context, pvzId uuid.UUID, receivedBefore, sentAt time.Time) ([]models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBackProducts", ctx, pvzId, receivedBefore, sentAt)
+	ret0, _ := ret[0].([]models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBackProducts indicates an expected call of SendBackProducts.
+func (mr *MockProductRepositoryMockRecorder) SendBackProducts(ctx, pvzId, receivedBefore, sentAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBackProducts", reflect.TypeOf((*MockProductRepository)(nil).SendBackProducts), ctx, pvzId, receivedBefore, sentAt)
+}
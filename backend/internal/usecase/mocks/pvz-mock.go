@@ -9,8 +9,10 @@ import (
 	forms "pvz/internal/delivery/forms"
 	models "pvz/internal/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockPvzRepository is a mock of PvzRepository interface.
@@ -36,6 +38,21 @@ func (m *MockPvzRepository) EXPECT() *MockPvzRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AssignEmployee mocks base method.
+func (m *MockPvzRepository) AssignEmployee(ctx context.Context, employee models.PvzEmployee, roles []string) (models.PvzEmployee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignEmployee", ctx, employee, roles)
+	ret0, _ := ret[0].(models.PvzEmployee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignEmployee indicates an expected call of AssignEmployee.
+func (mr *MockPvzRepositoryMockRecorder) AssignEmployee(ctx, employee, roles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignEmployee", reflect.TypeOf((*MockPvzRepository)(nil).AssignEmployee), ctx, employee, roles)
+}
+
 // CreatePvz mocks base method.
 func (m *MockPvzRepository) CreatePvz(ctx context.Context, pvzData models.Pvz) error {
 	m.ctrl.T.Helper()
@@ -50,6 +67,66 @@ func (mr *MockPvzRepositoryMockRecorder) CreatePvz(ctx, pvzData interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePvz", reflect.TypeOf((*MockPvzRepository)(nil).CreatePvz), ctx, pvzData)
 }
 
+// DecommissionPvz mocks base method.
+func (m *MockPvzRepository) DecommissionPvz(ctx context.Context, pvzId uuid.UUID, decommissionedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecommissionPvz", ctx, pvzId, decommissionedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecommissionPvz indicates an expected call of DecommissionPvz.
+func (mr *MockPvzRepositoryMockRecorder) DecommissionPvz(ctx, pvzId, decommissionedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecommissionPvz", reflect.TypeOf((*MockPvzRepository)(nil).DecommissionPvz), ctx, pvzId, decommissionedAt)
+}
+
+// GetNearbyPvz mocks base method.
+func (m *MockPvzRepository) GetNearbyPvz(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyPvz", ctx, form)
+	ret0, _ := ret[0].([]models.NearbyPvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyPvz indicates an expected call of GetNearbyPvz.
+func (mr *MockPvzRepositoryMockRecorder) GetNearbyPvz(ctx, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyPvz", reflect.TypeOf((*MockPvzRepository)(nil).GetNearbyPvz), ctx, form)
+}
+
+// GetOverloadedPvz mocks base method.
+func (m *MockPvzRepository) GetOverloadedPvz(ctx context.Context, threshold float64) ([]models.PvzLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverloadedPvz", ctx, threshold)
+	ret0, _ := ret[0].([]models.PvzLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverloadedPvz indicates an expected call of GetOverloadedPvz.
+func (mr *MockPvzRepositoryMockRecorder) GetOverloadedPvz(ctx, threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverloadedPvz", reflect.TypeOf((*MockPvzRepository)(nil).GetOverloadedPvz), ctx, threshold)
+}
+
+// GetPvz mocks base method.
+func (m *MockPvzRepository) GetPvz(ctx context.Context, pvzId uuid.UUID) (models.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvz", ctx, pvzId)
+	ret0, _ := ret[0].(models.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvz indicates an expected call of GetPvz.
+func (mr *MockPvzRepositoryMockRecorder) GetPvz(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvz", reflect.TypeOf((*MockPvzRepository)(nil).GetPvz), ctx, pvzId)
+}
+
 // GetPvzInfo mocks base method.
 func (m *MockPvzRepository) GetPvzInfo(ctx context.Context, form forms.GetPvzInfoForm) ([]models.PvzInfo, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +156,77 @@ func (mr *MockPvzRepositoryMockRecorder) GetPvzList(ctx interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzList", reflect.TypeOf((*MockPvzRepository)(nil).GetPvzList), ctx)
 }
+
+// GetPvzSchedule mocks base method.
+func (m *MockPvzRepository) GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzSchedule", ctx, pvzId)
+	ret0, _ := ret[0].(models.PvzSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzSchedule indicates an expected call of GetPvzSchedule.
+func (mr *MockPvzRepositoryMockRecorder) GetPvzSchedule(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzSchedule", reflect.TypeOf((*MockPvzRepository)(nil).GetPvzSchedule), ctx, pvzId)
+}
+
+// IsCityAvailable mocks base method.
+func (m *MockPvzRepository) IsCityAvailable(ctx context.Context, city string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCityAvailable", ctx, city)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCityAvailable indicates an expected call of IsCityAvailable.
+func (mr *MockPvzRepositoryMockRecorder) IsCityAvailable(ctx, city interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCityAvailable", reflect.TypeOf((*MockPvzRepository)(nil).IsCityAvailable), ctx, city)
+}
+
+// ReplacePvzSchedule mocks base method.
+func (m *MockPvzRepository) ReplacePvzSchedule(ctx context.Context, schedule models.PvzSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePvzSchedule", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePvzSchedule indicates an expected call of ReplacePvzSchedule.
+func (mr *MockPvzRepositoryMockRecorder) ReplacePvzSchedule(ctx, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePvzSchedule", reflect.TypeOf((*MockPvzRepository)(nil).ReplacePvzSchedule), ctx, schedule)
+}
+
+// UnassignEmployee mocks base method.
+func (m *MockPvzRepository) UnassignEmployee(ctx context.Context, userId string, pvzId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignEmployee", ctx, userId, pvzId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnassignEmployee indicates an expected call of UnassignEmployee.
+func (mr *MockPvzRepositoryMockRecorder) UnassignEmployee(ctx, userId, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignEmployee", reflect.TypeOf((*MockPvzRepository)(nil).UnassignEmployee), ctx, userId, pvzId)
+}
+
+// UpdatePvz mocks base method.
+func (m *MockPvzRepository) UpdatePvz(ctx context.Context, pvzId uuid.UUID, form forms.UpdatePvzForm) (models.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePvz", ctx, pvzId, form)
+	ret0, _ := ret[0].(models.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePvz indicates an expected call of UpdatePvz.
+func (mr *MockPvzRepositoryMockRecorder) UpdatePvz(ctx, pvzId, form interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePvz", reflect.TypeOf((*MockPvzRepository)(nil).UpdatePvz), ctx, pvzId, form)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	models "pvz/internal/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -50,20 +51,80 @@ func (mr *MockReceptionRepositoryMockRecorder) AddProduct(ctx, product interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockReceptionRepository)(nil).AddProduct), ctx, product)
 }
 
-// CloseReception mocks base method.
-func (m *MockReceptionRepository) CloseReception(ctx context.Context, receptionData models.Reception) error {
+// AddProducts mocks base method.
+func (m *MockReceptionRepository) AddProducts(ctx context.Context, products []models.Product) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CloseReception", ctx, receptionData)
+	ret := m.ctrl.Call(m, "AddProducts", ctx, products)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// AddProducts indicates an expected call of AddProducts.
+func (mr *MockReceptionRepositoryMockRecorder) AddProducts(ctx, products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProducts", reflect.TypeOf((*MockReceptionRepository)(nil).AddProducts), ctx, products)
+}
+
+// AutoCloseReception mocks base method.
+func (m *MockReceptionRepository) AutoCloseReception(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport, entry models.AuditEntry) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoCloseReception", ctx, receptionData, report, entry)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoCloseReception indicates an expected call of AutoCloseReception.
+func (mr *MockReceptionRepositoryMockRecorder) AutoCloseReception(ctx, receptionData, report, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoCloseReception", reflect.TypeOf((*MockReceptionRepository)(nil).AutoCloseReception), ctx, receptionData, report, entry)
+}
+
+// ChangeReceptionStatus mocks base method.
+func (m *MockReceptionRepository) ChangeReceptionStatus(ctx context.Context, receptionId uuid.UUID, from, to models.Status, entry models.AuditEntry) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReceptionStatus", ctx, receptionId, from, to, entry)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeReceptionStatus indicates an expected call of ChangeReceptionStatus.
+func (mr *MockReceptionRepositoryMockRecorder) ChangeReceptionStatus(ctx, receptionId, from, to, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReceptionStatus", reflect.TypeOf((*MockReceptionRepository)(nil).ChangeReceptionStatus), ctx, receptionId, from, to, entry)
+}
+
+// CloseReception mocks base method.
+func (m *MockReceptionRepository) CloseReception(ctx context.Context, receptionData models.Reception) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseReception", ctx, receptionData)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // CloseReception indicates an expected call of CloseReception.
 func (mr *MockReceptionRepositoryMockRecorder) CloseReception(ctx, receptionData interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseReception", reflect.TypeOf((*MockReceptionRepository)(nil).CloseReception), ctx, receptionData)
 }
 
+// CloseReceptionWithReport mocks base method.
+func (m *MockReceptionRepository) CloseReceptionWithReport(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseReceptionWithReport", ctx, receptionData, report)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseReceptionWithReport indicates an expected call of CloseReceptionWithReport.
+func (mr *MockReceptionRepositoryMockRecorder) CloseReceptionWithReport(ctx, receptionData, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseReceptionWithReport", reflect.TypeOf((*MockReceptionRepository)(nil).CloseReceptionWithReport), ctx, receptionData, report)
+}
+
 // CreateReception mocks base method.
 func (m *MockReceptionRepository) CreateReception(ctx context.Context, receptionData models.Reception) error {
 	m.ctrl.T.Helper()
@@ -78,6 +139,50 @@ func (mr *MockReceptionRepositoryMockRecorder) CreateReception(ctx, receptionDat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockReceptionRepository)(nil).CreateReception), ctx, receptionData)
 }
 
+// CreateReceptionOutsideSchedule mocks base method.
+func (m *MockReceptionRepository) CreateReceptionOutsideSchedule(ctx context.Context, receptionData models.Reception, entry models.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReceptionOutsideSchedule", ctx, receptionData, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReceptionOutsideSchedule indicates an expected call of CreateReceptionOutsideSchedule.
+func (mr *MockReceptionRepositoryMockRecorder) CreateReceptionOutsideSchedule(ctx, receptionData, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReceptionOutsideSchedule", reflect.TypeOf((*MockReceptionRepository)(nil).CreateReceptionOutsideSchedule), ctx, receptionData, entry)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockReceptionRepository) DeleteProduct(ctx context.Context, receptionId, productId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, receptionId, productId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockReceptionRepositoryMockRecorder) DeleteProduct(ctx, receptionId, productId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockReceptionRepository)(nil).DeleteProduct), ctx, receptionId, productId)
+}
+
+// GetDiscrepancyReport mocks base method.
+func (m *MockReceptionRepository) GetDiscrepancyReport(ctx context.Context, receptionId uuid.UUID) (models.DiscrepancyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscrepancyReport", ctx, receptionId)
+	ret0, _ := ret[0].(models.DiscrepancyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscrepancyReport indicates an expected call of GetDiscrepancyReport.
+func (mr *MockReceptionRepositoryMockRecorder) GetDiscrepancyReport(ctx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscrepancyReport", reflect.TypeOf((*MockReceptionRepository)(nil).GetDiscrepancyReport), ctx, receptionId)
+}
+
 // GetOpenReception mocks base method.
 func (m *MockReceptionRepository) GetOpenReception(ctx context.Context, pvzId uuid.UUID) (models.Reception, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +198,141 @@ func (mr *MockReceptionRepositoryMockRecorder) GetOpenReception(ctx, pvzId inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReception", reflect.TypeOf((*MockReceptionRepository)(nil).GetOpenReception), ctx, pvzId)
 }
 
+// GetPvzLoad mocks base method.
+func (m *MockReceptionRepository) GetPvzLoad(ctx context.Context, pvzId uuid.UUID) (models.PvzLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzLoad", ctx, pvzId)
+	ret0, _ := ret[0].(models.PvzLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzLoad indicates an expected call of GetPvzLoad.
+func (mr *MockReceptionRepositoryMockRecorder) GetPvzLoad(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzLoad", reflect.TypeOf((*MockReceptionRepository)(nil).GetPvzLoad), ctx, pvzId)
+}
+
+// GetPvzSchedule mocks base method.
+func (m *MockReceptionRepository) GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzSchedule", ctx, pvzId)
+	ret0, _ := ret[0].(models.PvzSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzSchedule indicates an expected call of GetPvzSchedule.
+func (mr *MockReceptionRepositoryMockRecorder) GetPvzSchedule(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzSchedule", reflect.TypeOf((*MockReceptionRepository)(nil).GetPvzSchedule), ctx, pvzId)
+}
+
+// GetReceivedBarcodes mocks base method.
+func (m *MockReceptionRepository) GetReceivedBarcodes(ctx context.Context, receptionId uuid.UUID, barcodes []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedBarcodes", ctx, receptionId, barcodes)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedBarcodes indicates an expected call of GetReceivedBarcodes.
+func (mr *MockReceptionRepositoryMockRecorder) GetReceivedBarcodes(ctx, receptionId, barcodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedBarcodes", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceivedBarcodes), ctx, receptionId, barcodes)
+}
+
+// GetReception mocks base method.
+func (m *MockReceptionRepository) GetReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReception", ctx, receptionId)
+	ret0, _ := ret[0].(models.ReceptionProducts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReception indicates an expected call of GetReception.
+func (mr *MockReceptionRepositoryMockRecorder) GetReception(ctx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReception", reflect.TypeOf((*MockReceptionRepository)(nil).GetReception), ctx, receptionId)
+}
+
+// GetReceptionHistory mocks base method.
+func (m *MockReceptionRepository) GetReceptionHistory(ctx context.Context, receptionId uuid.UUID) ([]models.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionHistory", ctx, receptionId)
+	ret0, _ := ret[0].([]models.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionHistory indicates an expected call of GetReceptionHistory.
+func (mr *MockReceptionRepositoryMockRecorder) GetReceptionHistory(ctx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionHistory", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionHistory), ctx, receptionId)
+}
+
+// GetReceptionManifest mocks base method.
+func (m *MockReceptionRepository) GetReceptionManifest(ctx context.Context, reception models.Reception) (models.Manifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionManifest", ctx, reception)
+	ret0, _ := ret[0].(models.Manifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionManifest indicates an expected call of GetReceptionManifest.
+func (mr *MockReceptionRepositoryMockRecorder) GetReceptionManifest(ctx, reception interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionManifest", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionManifest), ctx, reception)
+}
+
+// IsCategoryActive mocks base method.
+func (m *MockReceptionRepository) IsCategoryActive(ctx context.Context, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCategoryActive", ctx, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCategoryActive indicates an expected call of IsCategoryActive.
+func (mr *MockReceptionRepositoryMockRecorder) IsCategoryActive(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCategoryActive", reflect.TypeOf((*MockReceptionRepository)(nil).IsCategoryActive), ctx, code)
+}
+
+// ListReceptions mocks base method.
+func (m *MockReceptionRepository) ListReceptions(ctx context.Context, filter models.ReceptionFilter) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReceptions", ctx, filter)
+	ret0, _ := ret[0].([]models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReceptions indicates an expected call of ListReceptions.
+func (mr *MockReceptionRepositoryMockRecorder) ListReceptions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReceptions", reflect.TypeOf((*MockReceptionRepository)(nil).ListReceptions), ctx, filter)
+}
+
+// ListStaleReceptions mocks base method.
+func (m *MockReceptionRepository) ListStaleReceptions(ctx context.Context, idleSince time.Time) ([]models.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStaleReceptions", ctx, idleSince)
+	ret0, _ := ret[0].([]models.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStaleReceptions indicates an expected call of ListStaleReceptions.
+func (mr *MockReceptionRepositoryMockRecorder) ListStaleReceptions(ctx, idleSince interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStaleReceptions", reflect.TypeOf((*MockReceptionRepository)(nil).ListStaleReceptions), ctx, idleSince)
+}
+
 // RemoveProduct mocks base method.
 func (m *MockReceptionRepository) RemoveProduct(ctx context.Context, receptionId uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -106,3 +346,48 @@ func (mr *MockReceptionRepositoryMockRecorder) RemoveProduct(ctx, receptionId in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProduct", reflect.TypeOf((*MockReceptionRepository)(nil).RemoveProduct), ctx, receptionId)
 }
+
+// ReopenReception mocks base method.
+func (m *MockReceptionRepository) ReopenReception(ctx context.Context, receptionId uuid.UUID, entry models.AuditEntry) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenReception", ctx, receptionId, entry)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenReception indicates an expected call of ReopenReception.
+func (mr *MockReceptionRepositoryMockRecorder) ReopenReception(ctx, receptionId, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenReception", reflect.TypeOf((*MockReceptionRepository)(nil).ReopenReception), ctx, receptionId, entry)
+}
+
+// ReplaceManifest mocks base method.
+func (m *MockReceptionRepository) ReplaceManifest(ctx context.Context, manifest models.Manifest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceManifest", ctx, manifest)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceManifest indicates an expected call of ReplaceManifest.
+func (mr *MockReceptionRepositoryMockRecorder) ReplaceManifest(ctx, manifest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceManifest", reflect.TypeOf((*MockReceptionRepository)(nil).ReplaceManifest), ctx, manifest)
+}
+
+// SearchProducts mocks base method.
+func (m *MockReceptionRepository) SearchProducts(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProducts", ctx, barcode)
+	ret0, _ := ret[0].([]models.ProductLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockReceptionRepositoryMockRecorder) SearchProducts(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockReceptionRepository)(nil).SearchProducts), ctx, barcode)
+}
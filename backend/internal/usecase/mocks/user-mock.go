@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase\user-usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "pvz/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserAdminRepository is a mock of UserAdminRepository interface.
+type MockUserAdminRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAdminRepositoryMockRecorder
+}
+
+// MockUserAdminRepositoryMockRecorder is the mock recorder for MockUserAdminRepository.
+type MockUserAdminRepositoryMockRecorder struct {
+	mock *MockUserAdminRepository
+}
+
+// NewMockUserAdminRepository creates a new mock instance.
+func NewMockUserAdminRepository(ctrl *gomock.Controller) *MockUserAdminRepository {
+	mock := &MockUserAdminRepository{ctrl: ctrl}
+	mock.recorder = &MockUserAdminRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAdminRepository) EXPECT() *MockUserAdminRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListUsers mocks base method.
+func (m *MockUserAdminRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserAdminRepositoryMockRecorder) ListUsers(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserAdminRepository)(nil).ListUsers), ctx, filter)
+}
+
+// ResetUserPassword mocks base method.
+func (m *MockUserAdminRepository) ResetUserPassword(ctx context.Context, userId, password string, entry models.AuditEntry) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserPassword", ctx, userId, password, entry)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserPassword indicates an expected call of ResetUserPassword.
+func (mr *MockUserAdminRepositoryMockRecorder) ResetUserPassword(ctx, userId, password, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPassword", reflect.TypeOf((*MockUserAdminRepository)(nil).ResetUserPassword), ctx, userId, password, entry)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserAdminRepository) UpdateUserRole(ctx context.Context, userId, role string, entry models.AuditEntry) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, userId, role, entry)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserAdminRepositoryMockRecorder) UpdateUserRole(ctx, userId, role, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserAdminRepository)(nil).UpdateUserRole), ctx, userId, role, entry)
+}
+
+// UpdateUserStatus mocks base method.
+func (m *MockUserAdminRepository) UpdateUserStatus(ctx context.Context, userId string, status models.UserStatus, entry models.AuditEntry) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, userId, status, entry)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockUserAdminRepositoryMockRecorder) UpdateUserStatus(ctx, userId, status, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockUserAdminRepository)(nil).UpdateUserStatus), ctx, userId, status, entry)
+}
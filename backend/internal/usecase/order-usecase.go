@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+const PickupCodeTTL = 15 * time.Minute
+
+var (
+	ProductNotFound    = errors.New("product not found")
+	OrderNotFound      = errors.New("order not found")
+	OrderAlreadyIssued = errors.New("order is already issued")
+	InvalidPickupCode  = errors.New("invalid or expired pickup code")
+	// ProductNotOrderable - заказ оформляется только на товар, который лежит на складе закрытой или проверенной приёмки
+	ProductNotOrderable = errors.New("product can not be ordered")
+)
+
+// ProductNotOrderableError - товар уже выдан, отправлен обратно или его приёмка ещё не закрыта либо отменена
+type ProductNotOrderableError struct {
+	ProductStatus   models.ProductStatus
+	ReceptionStatus models.Status
+}
+
+func (e *ProductNotOrderableError) Error() string {
+	return fmt.Sprintf("%s: product is %s, reception is %s", ProductNotOrderable, e.ProductStatus, e.ReceptionStatus)
+}
+
+func (e *ProductNotOrderableError) Unwrap() error {
+	return ProductNotOrderable
+}
+
+type OrderRepository interface {
+	GetOrderProduct(ctx context.Context, productId uuid.UUID) (models.ProductLocation, error)
+	CreateOrder(ctx context.Context, order models.Order) error
+	GetOrder(ctx context.Context, orderId uuid.UUID) (models.Order, error)
+	GetClientOrders(ctx context.Context, clientId string, status models.OrderStatus) ([]models.Order, error)
+	SetPickupCode(ctx context.Context, orderId uuid.UUID, codeHash string, expiresAt time.Time) (bool, error)
+	IssueOrder(ctx context.Context, pvzId uuid.UUID, codeHash, issuedBy string, issuedAt time.Time) (models.Order, error)
+}
+
+type OrderService struct {
+	orderRepo OrderRepository
+	pvzAccess *PvzAccessChecker
+}
+
+func NewOrderService(orderRepo OrderRepository, pvzAccess *PvzAccessChecker) *OrderService {
+	return &OrderService{
+		orderRepo: orderRepo,
+		pvzAccess: pvzAccess,
+	}
+}
+
+// CreateOrder закрепляет принятый товар за клиентом. Заказ ждёт клиента на том ПВЗ, куда был принят товар.
+// Товар должен лежать на складе и относиться к закрытой или проверенной приёмке, как и при выдаче без заказа
+func (o *OrderService) CreateOrder(ctx context.Context, orderForm forms.CreateOrderForm) (models.Order, error) {
+	location, err := o.orderRepo.GetOrderProduct(ctx, orderForm.ProductId)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	if location.Product.Id == uuid.Nil {
+		return models.Order{}, ProductNotFound
+	}
+
+	pvzId := location.PvzId
+	principal, err := o.pvzAccess.Check(ctx, pvzId)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	if location.Product.Status != models.ProductStored || !location.ReceptionStatus.HoldsStock() {
+		logger.Error(ctx, fmt.Sprintf("Product %s is %s in %s reception and can not be ordered",
+			orderForm.ProductId, location.Product.Status, location.ReceptionStatus))
+		return models.Order{}, &ProductNotOrderableError{ProductStatus: location.Product.Status, ReceptionStatus: location.ReceptionStatus}
+	}
+
+	order := models.Order{
+		Id:        uuid.New(),
+		ClientId:  orderForm.ClientId.String(),
+		PvzId:     pvzId,
+		ProductId: orderForm.ProductId,
+		Status:    models.OrderWaiting,
+		CreatedAt: time.Now(),
+	}
+
+	if err = o.orderRepo.CreateOrder(ctx, order); err != nil {
+		return models.Order{}, err
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Order %s for client %s was created by user %s", order.Id, order.ClientId, principal.UserId))
+
+	return order, nil
+}
+
+func (o *OrderService) GetMyOrders(ctx context.Context) ([]models.Order, error) {
+	principal, ok := utils.GetPrincipal(ctx)
+	if !ok {
+		logger.Error(ctx, "Principal is missing in context")
+		return nil, errors.New("principal is missing in context")
+	}
+
+	return o.orderRepo.GetClientOrders(ctx, principal.UserId, models.OrderWaiting)
+}
+
+// CreatePickupCode выдаёт клиенту новый код для его ожидающего заказа, предыдущий код при этом сгорает
+func (o *OrderService) CreatePickupCode(ctx context.Context, orderId uuid.UUID) (models.PickupCode, error) {
+	principal, ok := utils.GetPrincipal(ctx)
+	if !ok {
+		logger.Error(ctx, "Principal is missing in context")
+		return models.PickupCode{}, errors.New("principal is missing in context")
+	}
+
+	order, err := o.orderRepo.GetOrder(ctx, orderId)
+	if err != nil {
+		return models.PickupCode{}, err
+	}
+
+	// чужой заказ неотличим от несуществующего
+	if order.Id == uuid.Nil || order.ClientId != principal.UserId {
+		return models.PickupCode{}, OrderNotFound
+	}
+
+	if order.Status != models.OrderWaiting {
+		return models.PickupCode{}, OrderAlreadyIssued
+	}
+
+	code, err := utils.GeneratePickupCode()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error generating pickup code: %s", err.Error()))
+		return models.PickupCode{}, err
+	}
+
+	expiresAt := time.Now().Add(PickupCodeTTL)
+	updated, err := o.orderRepo.SetPickupCode(ctx, order.Id, utils.HashPickupCode(code), expiresAt)
+	if err != nil {
+		return models.PickupCode{}, err
+	}
+
+	// заказ могли выдать между чтением и обновлением
+	if !updated {
+		return models.PickupCode{}, OrderAlreadyIssued
+	}
+
+	return models.PickupCode{
+		OrderId:   order.Id,
+		Code:      code,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (o *OrderService) IssueOrder(ctx context.Context, issueForm forms.IssueOrderForm) (models.Order, error) {
+	principal, err := o.pvzAccess.Check(ctx, issueForm.PvzId)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	order, err := o.orderRepo.IssueOrder(ctx, issueForm.PvzId, utils.HashPickupCode(issueForm.PickupCode), principal.UserId, time.Now())
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	if order.Id == uuid.Nil {
+		return models.Order{}, InvalidPickupCode
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Order %s was issued by user %s", order.Id, principal.UserId))
+
+	return order, nil
+}
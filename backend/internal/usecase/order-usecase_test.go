@@ -0,0 +1,273 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/delivery/forms"
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/usecase/mocks"
+	"pvz/internal/utils"
+)
+
+var (
+	client    = models.Principal{UserId: uuid.NewString(), Role: string(models.Client)}
+	clientCtx = utils.SetPrincipal(employeeCtx, client)
+)
+
+func TestOrderService_CreateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrderRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewOrderService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false))
+
+	pvzId := uuid.New()
+	form := forms.CreateOrderForm{ClientId: uuid.New(), ProductId: uuid.New()}
+	location := func(productStatus models.ProductStatus, receptionStatus models.Status) models.ProductLocation {
+		return models.ProductLocation{
+			Product:         models.Product{Id: form.ProductId, Status: productStatus},
+			PvzId:           pvzId,
+			ReceptionStatus: receptionStatus,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(location(models.ProductStored, models.Closed), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "product of verified reception",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(location(models.ProductStored, models.Verified), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "product not found",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(models.ProductLocation{}, nil)
+			},
+			wantErr: usecase.ProductNotFound,
+		},
+		{
+			name: "employee is not assigned to pvz",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(location(models.ProductStored, models.InProgress), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+			},
+			wantErr: usecase.PvzAccessDenied,
+		},
+		{
+			name: "product is already issued",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(location(models.ProductIssued, models.Closed), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ProductNotOrderable,
+		},
+		{
+			name: "product was sent back",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(location(models.ProductSentBack, models.Verified), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ProductNotOrderable,
+		},
+		{
+			name: "reception is in progress",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(location(models.ProductStored, models.InProgress), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ProductNotOrderable,
+		},
+		{
+			name: "reception is cancelled",
+			mock: func() {
+				mockRepo.EXPECT().GetOrderProduct(gomock.Any(), form.ProductId).Return(location(models.ProductStored, models.Cancelled), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ProductNotOrderable,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.CreateOrder(employeeCtx, form)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, pvzId, got.PvzId)
+				assert.Equal(t, form.ClientId.String(), got.ClientId)
+				assert.Equal(t, models.OrderWaiting, got.Status)
+			}
+		})
+	}
+}
+
+func TestOrderService_GetMyOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrderRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewOrderService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false))
+
+	orders := []models.Order{{Id: uuid.New(), ClientId: client.UserId}}
+	mockRepo.EXPECT().GetClientOrders(gomock.Any(), client.UserId, models.OrderWaiting).Return(orders, nil)
+
+	got, err := service.GetMyOrders(clientCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, orders, got)
+}
+
+func TestOrderService_CreatePickupCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrderRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewOrderService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false))
+
+	orderId := uuid.New()
+	waiting := models.Order{Id: orderId, ClientId: client.UserId, Status: models.OrderWaiting}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockRepo.EXPECT().GetOrder(gomock.Any(), orderId).Return(waiting, nil)
+				mockRepo.EXPECT().SetPickupCode(gomock.Any(), orderId, gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name: "order of another client",
+			mock: func() {
+				mockRepo.EXPECT().GetOrder(gomock.Any(), orderId).
+					Return(models.Order{Id: orderId, ClientId: uuid.NewString(), Status: models.OrderWaiting}, nil)
+			},
+			wantErr: usecase.OrderNotFound,
+		},
+		{
+			name: "order not found",
+			mock: func() {
+				mockRepo.EXPECT().GetOrder(gomock.Any(), orderId).Return(models.Order{}, nil)
+			},
+			wantErr: usecase.OrderNotFound,
+		},
+		{
+			name: "order already issued",
+			mock: func() {
+				mockRepo.EXPECT().GetOrder(gomock.Any(), orderId).
+					Return(models.Order{Id: orderId, ClientId: client.UserId, Status: models.OrderIssued}, nil)
+			},
+			wantErr: usecase.OrderAlreadyIssued,
+		},
+		{
+			name: "order issued concurrently",
+			mock: func() {
+				mockRepo.EXPECT().GetOrder(gomock.Any(), orderId).Return(waiting, nil)
+				mockRepo.EXPECT().SetPickupCode(gomock.Any(), orderId, gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: usecase.OrderAlreadyIssued,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.CreatePickupCode(clientCtx, orderId)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Len(t, got.Code, 8)
+				assert.WithinDuration(t, time.Now().Add(usecase.PickupCodeTTL), got.ExpiresAt, time.Minute)
+			}
+		})
+	}
+}
+
+func TestOrderService_IssueOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOrderRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewOrderService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false))
+
+	form := forms.IssueOrderForm{PvzId: uuid.New(), PickupCode: "abcd2345"}
+	codeHash := utils.HashPickupCode("ABCD2345")
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, form.PvzId).Return(true, nil)
+				mockRepo.EXPECT().IssueOrder(gomock.Any(), form.PvzId, codeHash, employee.UserId, gomock.Any()).
+					Return(models.Order{Id: uuid.New(), Status: models.OrderIssued}, nil)
+			},
+		},
+		{
+			name: "invalid pickup code",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, form.PvzId).Return(true, nil)
+				mockRepo.EXPECT().IssueOrder(gomock.Any(), form.PvzId, codeHash, employee.UserId, gomock.Any()).
+					Return(models.Order{}, nil)
+			},
+			wantErr: usecase.InvalidPickupCode,
+		},
+		{
+			name: "employee is not assigned to pvz",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, form.PvzId).Return(false, nil)
+			},
+			wantErr: usecase.PvzAccessDenied,
+		},
+		{
+			name: "repository error",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, form.PvzId).Return(true, nil)
+				mockRepo.EXPECT().IssueOrder(gomock.Any(), form.PvzId, codeHash, employee.UserId, gomock.Any()).
+					Return(models.Order{}, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.IssueOrder(employeeCtx, form)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, models.OrderIssued, got.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+	"pvz/pkg/logger"
+)
+
+var (
+	// ReceptionNotClosed - товар из незакрытой или отменённой приёмки не числится на складе
+	ReceptionNotClosed = errors.New("reception is not closed yet")
+	ProductNotInStock  = errors.New("product is not in stock")
+	ProductNotIssued   = errors.New("product was not issued")
+	// ProductHasOrder - товар ждёт клиента по заказу и выдаётся только по коду получения
+	ProductHasOrder = errors.New("product is reserved by order")
+)
+
+type ProductRepository interface {
+	GetProduct(ctx context.Context, productId uuid.UUID) (models.ProductLocation, error)
+	HasWaitingOrder(ctx context.Context, productId uuid.UUID) (bool, error)
+	IssueProduct(ctx context.Context, productId uuid.UUID, issuedAt time.Time) (bool, error)
+	ReturnProduct(ctx context.Context, productId uuid.UUID, returnedAt time.Time) (bool, error)
+	SendBackProducts(ctx context.Context, pvzId uuid.UUID, receivedBefore, sentAt time.Time) ([]models.Product, error)
+}
+
+type ProductService struct {
+	productRepo   ProductRepository
+	pvzAccess     *PvzAccessChecker
+	storagePeriod time.Duration
+}
+
+// NewProductService - storagePeriod задаёт, сколько товар ждёт клиента до отправки обратно на склад
+func NewProductService(productRepo ProductRepository, pvzAccess *PvzAccessChecker, storagePeriod time.Duration) *ProductService {
+	return &ProductService{
+		productRepo:   productRepo,
+		pvzAccess:     pvzAccess,
+		storagePeriod: storagePeriod,
+	}
+}
+
+// IssueProduct выдаёт клиенту товар без заказа. Товар должен лежать на складе и относиться к закрытой приёмке
+func (ps *ProductService) IssueProduct(ctx context.Context, productId uuid.UUID) (models.Product, error) {
+	location, principal, err := ps.getProduct(ctx, productId)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	if location.Product.Status != models.ProductStored {
+		logger.Error(ctx, fmt.Sprintf("Product %s is %s and can not be issued", productId, location.Product.Status))
+		return models.Product{}, ProductNotInStock
+	}
+
+	hasOrder, err := ps.productRepo.HasWaitingOrder(ctx, productId)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	if hasOrder {
+		logger.Error(ctx, fmt.Sprintf("Product %s is reserved by order", productId))
+		return models.Product{}, ProductHasOrder
+	}
+
+	issuedAt := time.Now()
+	isIssued, err := ps.productRepo.IssueProduct(ctx, productId, issuedAt)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	// товар могли выдать между чтением и обновлением
+	if !isIssued {
+		return models.Product{}, ProductNotInStock
+	}
+
+	product := location.Product
+	product.Status = models.ProductIssued
+	product.IssuedAt = issuedAt
+
+	logger.Info(ctx, fmt.Sprintf("Product %s was issued by user %s", productId, principal.UserId))
+
+	return product, nil
+}
+
+// ReturnProduct принимает от клиента выданный ранее товар. Возврат лежит на складе до отправки обратно
+func (ps *ProductService) ReturnProduct(ctx context.Context, productId uuid.UUID) (models.Product, error) {
+	location, principal, err := ps.getProduct(ctx, productId)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	if location.Product.Status != models.ProductIssued {
+		logger.Error(ctx, fmt.Sprintf("Product %s is %s and can not be returned", productId, location.Product.Status))
+		return models.Product{}, ProductNotIssued
+	}
+
+	returnedAt := time.Now()
+	isReturned, err := ps.productRepo.ReturnProduct(ctx, productId, returnedAt)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	if !isReturned {
+		return models.Product{}, ProductNotIssued
+	}
+
+	product := location.Product
+	product.Status = models.ProductReturned
+	product.ReturnedAt = returnedAt
+
+	logger.Info(ctx, fmt.Sprintf("Return of product %s was accepted by user %s", productId, principal.UserId))
+
+	return product, nil
+}
+
+// SendBackProducts отправляет обратно на склад возвраты и товары, которые не забрали за срок хранения
+func (ps *ProductService) SendBackProducts(ctx context.Context, pvzId uuid.UUID) ([]models.Product, error) {
+	principal, err := ps.pvzAccess.Check(ctx, pvzId)
+	if err != nil {
+		return nil, err
+	}
+
+	sentAt := time.Now()
+	products, err := ps.productRepo.SendBackProducts(ctx, pvzId, sentAt.Add(-ps.storagePeriod), sentAt)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%d products of pvz %s were sent back by user %s", len(products), pvzId, principal.UserId))
+
+	return products, nil
+}
+
+// getProduct находит товар, проверяет доступ сотрудника к его ПВЗ и то, что приёмка товара закрыта
+func (ps *ProductService) getProduct(ctx context.Context, productId uuid.UUID) (models.ProductLocation, models.Principal, error) {
+	location, err := ps.productRepo.GetProduct(ctx, productId)
+	if err != nil {
+		return models.ProductLocation{}, models.Principal{}, err
+	}
+
+	if location.Product.Id == uuid.Nil {
+		return models.ProductLocation{}, models.Principal{}, ProductNotFound
+	}
+
+	principal, err := ps.pvzAccess.Check(ctx, location.PvzId)
+	if err != nil {
+		return models.ProductLocation{}, models.Principal{}, err
+	}
+
+	if !location.ReceptionStatus.HoldsStock() {
+		logger.Error(ctx, fmt.Sprintf("Reception %s of product %s is %s", location.Product.ReceptionId, productId, location.ReceptionStatus))
+		return models.ProductLocation{}, models.Principal{}, ReceptionNotClosed
+	}
+
+	return location, principal, nil
+}
@@ -0,0 +1,210 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/usecase/mocks"
+)
+
+const storagePeriod = 7 * 24 * time.Hour
+
+func TestProductService_IssueProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProductRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewProductService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), storagePeriod)
+
+	pvzId := uuid.New()
+	productId := uuid.New()
+	location := func(status models.ProductStatus, receptionStatus models.Status) models.ProductLocation {
+		return models.ProductLocation{
+			Product:         models.Product{Id: productId, Status: status},
+			PvzId:           pvzId,
+			ReceptionStatus: receptionStatus,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductStored, models.Closed), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().HasWaitingOrder(gomock.Any(), productId).Return(false, nil)
+				mockRepo.EXPECT().IssueProduct(gomock.Any(), productId, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name: "product of verified reception",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductStored, models.Verified), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().HasWaitingOrder(gomock.Any(), productId).Return(false, nil)
+				mockRepo.EXPECT().IssueProduct(gomock.Any(), productId, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name: "product not found",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(models.ProductLocation{}, nil)
+			},
+			wantErr: usecase.ProductNotFound,
+		},
+		{
+			name: "employee is not assigned to pvz",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductStored, models.Closed), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+			},
+			wantErr: usecase.PvzAccessDenied,
+		},
+		{
+			name: "reception is still open",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductStored, models.InProgress), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ReceptionNotClosed,
+		},
+		{
+			name: "reception is cancelled",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductStored, models.Cancelled), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ReceptionNotClosed,
+		},
+		{
+			name: "already issued",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductIssued, models.Closed), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ProductNotInStock,
+		},
+		{
+			name: "product is reserved by order",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductStored, models.Closed), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().HasWaitingOrder(gomock.Any(), productId).Return(true, nil)
+			},
+			wantErr: usecase.ProductHasOrder,
+		},
+		{
+			name: "issued concurrently",
+			mock: func() {
+				mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(location(models.ProductStored, models.Closed), nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().HasWaitingOrder(gomock.Any(), productId).Return(false, nil)
+				mockRepo.EXPECT().IssueProduct(gomock.Any(), productId, gomock.Any()).Return(false, nil)
+			},
+			wantErr: usecase.ProductNotInStock,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.IssueProduct(employeeCtx, productId)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, models.ProductIssued, got.Status)
+				assert.False(t, got.IssuedAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestProductService_ReturnProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProductRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewProductService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), storagePeriod)
+
+	pvzId := uuid.New()
+	productId := uuid.New()
+	issued := models.ProductLocation{
+		Product:         models.Product{Id: productId, Status: models.ProductIssued, IssuedAt: time.Now()},
+		PvzId:           pvzId,
+		ReceptionStatus: models.Closed,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(issued, nil)
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().ReturnProduct(gomock.Any(), productId, gomock.Any()).Return(true, nil)
+
+		got, err := service.ReturnProduct(employeeCtx, productId)
+		assert.NoError(t, err)
+		assert.Equal(t, models.ProductReturned, got.Status)
+		assert.Equal(t, issued.Product.IssuedAt, got.IssuedAt)
+		assert.False(t, got.ReturnedAt.IsZero())
+	})
+
+	t.Run("product was not issued", func(t *testing.T) {
+		stored := issued
+		stored.Product.Status = models.ProductStored
+		mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(stored, nil)
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+
+		_, err := service.ReturnProduct(employeeCtx, productId)
+		assert.ErrorIs(t, err, usecase.ProductNotIssued)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetProduct(gomock.Any(), productId).Return(issued, nil)
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().ReturnProduct(gomock.Any(), productId, gomock.Any()).Return(false, errors.New("db error"))
+
+		_, err := service.ReturnProduct(employeeCtx, productId)
+		assert.Error(t, err)
+	})
+}
+
+func TestProductService_SendBackProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProductRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewProductService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), storagePeriod)
+
+	pvzId := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().SendBackProducts(gomock.Any(), pvzId, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, _ uuid.UUID, receivedBefore, sentAt time.Time) ([]models.Product, error) {
+				assert.Equal(t, storagePeriod, sentAt.Sub(receivedBefore))
+				return []models.Product{{Id: uuid.New(), Status: models.ProductSentBack}}, nil
+			})
+
+		products, err := service.SendBackProducts(employeeCtx, pvzId)
+		assert.NoError(t, err)
+		assert.Len(t, products, 1)
+	})
+
+	t.Run("employee is not assigned to pvz", func(t *testing.T) {
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+
+		_, err := service.SendBackProducts(employeeCtx, pvzId)
+		assert.ErrorIs(t, err, usecase.PvzAccessDenied)
+	})
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+type AssignmentRepository interface {
+	IsEmployeeAssigned(ctx context.Context, userId string, pvzId uuid.UUID) (bool, error)
+}
+
+// PvzAccessChecker проверяет, что пользователь из контекста закреплён за ПВЗ.
+// Один на все сервисы, работающие с товаром конкретного ПВЗ
+type PvzAccessChecker struct {
+	assignmentRepo AssignmentRepository
+	allowDummy     bool
+}
+
+// NewPvzAccessChecker - allowDummy допускает к любому ПВЗ пользователей с dummy-токеном: за ними нет записи в user_pvz.
+// Включается только в режиме test, чтобы сквозные тесты могли работать с dummy-токенами
+func NewPvzAccessChecker(assignmentRepo AssignmentRepository, allowDummy bool) *PvzAccessChecker {
+	return &PvzAccessChecker{
+		assignmentRepo: assignmentRepo,
+		allowDummy:     allowDummy,
+	}
+}
+
+// Check возвращает пользователя из контекста или PvzAccessDenied, если он не закреплён за ПВЗ
+func (c *PvzAccessChecker) Check(ctx context.Context, pvzId uuid.UUID) (models.Principal, error) {
+	principal, ok := utils.GetPrincipal(ctx)
+	if !ok {
+		logger.Error(ctx, "Principal is missing in context")
+		return models.Principal{}, PvzAccessDenied
+	}
+
+	if principal.Dummy && c.allowDummy {
+		return principal, nil
+	}
+
+	isAssigned, err := c.assignmentRepo.IsEmployeeAssigned(ctx, principal.UserId, pvzId)
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	if !isAssigned {
+		logger.Error(ctx, fmt.Sprintf("User %s is not assigned to pvz %s", principal.UserId, pvzId))
+		return models.Principal{}, PvzAccessDenied
+	}
+
+	return principal, nil
+}
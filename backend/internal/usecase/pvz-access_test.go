@@ -0,0 +1,65 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/usecase/mocks"
+	"pvz/internal/utils"
+)
+
+func TestPvzAccessChecker_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	checker := usecase.NewPvzAccessChecker(mockAssignments, false)
+	pvzId := uuid.New()
+
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+	principal, err := checker.Check(employeeCtx, pvzId)
+	assert.NoError(t, err)
+	assert.Equal(t, employee, principal)
+
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+	_, err = checker.Check(employeeCtx, pvzId)
+	assert.ErrorIs(t, err, usecase.PvzAccessDenied)
+
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, errors.New("db error"))
+	_, err = checker.Check(employeeCtx, pvzId)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, usecase.PvzAccessDenied)
+
+	principal, err = checker.Check(context.Background(), pvzId)
+	assert.ErrorIs(t, err, usecase.PvzAccessDenied, "principal is missing")
+	assert.Equal(t, models.Principal{}, principal)
+}
+
+func TestPvzAccessChecker_Check_Dummy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	pvzId := uuid.New()
+	dummy := models.Principal{UserId: uuid.NewString(), Role: string(models.Employee), Dummy: true}
+	dummyCtx := utils.SetPrincipal(context.Background(), dummy)
+
+	principal, err := usecase.NewPvzAccessChecker(mockAssignments, true).Check(dummyCtx, pvzId)
+	assert.NoError(t, err, "test mode lets dummy principals into any pvz")
+	assert.Equal(t, dummy, principal)
+
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), dummy.UserId, pvzId).Return(false, nil)
+	_, err = usecase.NewPvzAccessChecker(mockAssignments, false).Check(dummyCtx, pvzId)
+	assert.ErrorIs(t, err, usecase.PvzAccessDenied)
+
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+	_, err = usecase.NewPvzAccessChecker(mockAssignments, true).Check(employeeCtx, pvzId)
+	assert.ErrorIs(t, err, usecase.PvzAccessDenied, "real users are still checked in test mode")
+}
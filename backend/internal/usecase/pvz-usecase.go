@@ -2,32 +2,88 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
 
 	"pvz/internal/delivery/forms"
 	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+var (
+	EmployeeOrPvzNotFound = errors.New("employee or pvz not found")
+	EmployeeNotAssigned   = errors.New("employee is not assigned to pvz")
+	PvzNotFound           = errors.New("pvz not found")
+	PvzDecommissioned     = errors.New("pvz is already decommissioned")
+	PvzHasOpenReception   = errors.New("pvz has an open reception")
+	CityNotAvailable      = errors.New("city is unknown or disabled")
+	InvalidPvzDetails     = errors.New("invalid pvz details")
+	InvalidNearbySearch   = errors.New("invalid nearby search")
+	InvalidSchedule       = errors.New("invalid schedule")
+	InvalidThreshold      = errors.New("invalid utilization threshold")
+)
+
+const (
+	// радиус поиска ближайших ПВЗ по умолчанию и максимальный, в метрах
+	defaultNearbyRadius = 5000
+	maxNearbyRadius     = 50000
+	nearbyPvzLimit      = 50
+
+	// порог загрузки ПВЗ по умолчанию: доля занятой вместимости
+	defaultUtilizationThreshold = 0.9
+	maxUtilizationThreshold     = 10
 )
 
 type PvzRepository interface {
 	CreatePvz(ctx context.Context, pvzData models.Pvz) error
 	GetPvzInfo(ctx context.Context, form forms.GetPvzInfoForm) ([]models.PvzInfo, error)
 	GetPvzList(ctx context.Context) ([]models.Pvz, error)
+	AssignEmployee(ctx context.Context, employee models.PvzEmployee, roles []string) (models.PvzEmployee, error)
+	UnassignEmployee(ctx context.Context, userId string, pvzId uuid.UUID) (bool, error)
+	IsCityAvailable(ctx context.Context, city string) (bool, error)
+	GetPvz(ctx context.Context, pvzId uuid.UUID) (models.Pvz, error)
+	UpdatePvz(ctx context.Context, pvzId uuid.UUID, form forms.UpdatePvzForm) (models.Pvz, error)
+	DecommissionPvz(ctx context.Context, pvzId uuid.UUID, decommissionedAt time.Time) (bool, error)
+	GetNearbyPvz(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error)
+	GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error)
+	ReplacePvzSchedule(ctx context.Context, schedule models.PvzSchedule) error
+	GetOverloadedPvz(ctx context.Context, threshold float64) ([]models.PvzLoad, error)
 }
 
 type PvzService struct {
 	pvzRepo PvzRepository
+	policy  *models.Policy
 }
 
-func NewPvzService(pvzRepo PvzRepository) *PvzService {
+func NewPvzService(pvzRepo PvzRepository, policy *models.Policy) *PvzService {
 	return &PvzService{
 		pvzRepo: pvzRepo,
+		policy:  policy,
 	}
 }
 
 func (p *PvzService) CreatePvz(ctx context.Context, pvzForm forms.PvzForm) (models.Pvz, error) {
+	if err := validatePvzDetails(pvzForm.Address, pvzForm.Location, pvzForm.OpeningHours, pvzForm.Phone, pvzForm.Capacity); err != nil {
+		return models.Pvz{}, err
+	}
+
+	if err := p.checkCity(ctx, pvzForm.City); err != nil {
+		return models.Pvz{}, err
+	}
+
 	pvzData := models.Pvz{
 		Id:               pvzForm.Id,
 		RegistrationDate: pvzForm.RegistrationDate,
 		City:             pvzForm.City,
+		Address:          pvzForm.Address,
+		Location:         pvzForm.Location.ToGeoPoint(),
+		OpeningHours:     pvzForm.OpeningHours,
+		Phone:            pvzForm.Phone,
+		Capacity:         pvzForm.Capacity,
 	}
 
 	err := p.pvzRepo.CreatePvz(ctx, pvzData)
@@ -35,6 +91,9 @@ func (p *PvzService) CreatePvz(ctx context.Context, pvzForm forms.PvzForm) (mode
 		return models.Pvz{}, err
 	}
 
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Pvz %s was created by user %s", pvzData.Id, principal.UserId))
+
 	return pvzData, nil
 }
 
@@ -55,3 +114,225 @@ func (p *PvzService) GetPvzList(ctx context.Context) ([]models.Pvz, error) {
 
 	return res, nil
 }
+
+func (p *PvzService) AssignEmployee(ctx context.Context, pvzId uuid.UUID, userId uuid.UUID) (models.PvzEmployee, error) {
+	// закрепить можно пользователя любой роли, которой разрешено проводить приёмки
+	employee, err := p.pvzRepo.AssignEmployee(ctx, models.PvzEmployee{
+		UserId:     userId.String(),
+		PvzId:      pvzId,
+		AssignedAt: time.Now().UTC(),
+	}, p.policy.RolesWith(models.ReceptionCreate))
+	if err != nil {
+		return models.PvzEmployee{}, err
+	}
+
+	if employee.UserId == "" {
+		return models.PvzEmployee{}, EmployeeOrPvzNotFound
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Employee %s was assigned to pvz %s by user %s", userId, pvzId, principal.UserId))
+
+	return employee, nil
+}
+
+func (p *PvzService) UnassignEmployee(ctx context.Context, pvzId uuid.UUID, userId uuid.UUID) error {
+	isRemoved, err := p.pvzRepo.UnassignEmployee(ctx, userId.String(), pvzId)
+	if err != nil {
+		return err
+	}
+
+	if !isRemoved {
+		return EmployeeNotAssigned
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Employee %s was unassigned from pvz %s by user %s", userId, pvzId, principal.UserId))
+
+	return nil
+}
+
+func (p *PvzService) GetPvz(ctx context.Context, pvzId uuid.UUID) (models.Pvz, error) {
+	pvz, err := p.pvzRepo.GetPvz(ctx, pvzId)
+	if err != nil {
+		return models.Pvz{}, err
+	}
+
+	if pvz.Id == uuid.Nil {
+		return models.Pvz{}, PvzNotFound
+	}
+
+	return pvz, nil
+}
+
+func (p *PvzService) UpdatePvz(ctx context.Context, pvzId uuid.UUID, form forms.UpdatePvzForm) (models.Pvz, error) {
+	if form.IsEmpty() {
+		return p.GetPvz(ctx, pvzId)
+	}
+
+	err := validatePvzDetails(deref(form.Address), form.Location, deref(form.OpeningHours), deref(form.Phone),
+		deref(form.Capacity))
+	if err != nil {
+		return models.Pvz{}, err
+	}
+
+	if form.City != nil {
+		if err = p.checkCity(ctx, *form.City); err != nil {
+			return models.Pvz{}, err
+		}
+	}
+
+	pvz, err := p.pvzRepo.UpdatePvz(ctx, pvzId, form)
+	if err != nil {
+		return models.Pvz{}, err
+	}
+
+	if pvz.Id == uuid.Nil {
+		return models.Pvz{}, PvzNotFound
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Pvz %s was updated by user %s", pvzId, principal.UserId))
+
+	return pvz, nil
+}
+
+// DecommissionPvz выводит ПВЗ из эксплуатации: новые приёмки запрещаются, история сохраняется
+func (p *PvzService) DecommissionPvz(ctx context.Context, pvzId uuid.UUID) error {
+	pvz, err := p.GetPvz(ctx, pvzId)
+	if err != nil {
+		return err
+	}
+
+	if !pvz.DecommissionedAt.IsZero() {
+		return PvzDecommissioned
+	}
+
+	isDecommissioned, err := p.pvzRepo.DecommissionPvz(ctx, pvzId, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	// ПВЗ существует и не списан, значит помешала незакрытая приёмка
+	if !isDecommissioned {
+		return PvzHasOpenReception
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Pvz %s was decommissioned by user %s", pvzId, principal.UserId))
+
+	return nil
+}
+
+// GetNearbyPvz ищет действующие ПВЗ с координатами, ближайшие идут первыми
+func (p *PvzService) GetNearbyPvz(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error) {
+	if form.Radius == 0 {
+		form.Radius = defaultNearbyRadius
+	}
+
+	if err := utils.ValidateLocation(form.Latitude, form.Longitude); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, fmt.Errorf("%w: %v", InvalidNearbySearch, err)
+	}
+
+	if !(form.Radius > 0 && form.Radius <= maxNearbyRadius) {
+		logger.Error(ctx, fmt.Sprintf("Radius %.0f is out of range", form.Radius))
+		return nil, fmt.Errorf("%w: radius must be between 0 and %d meters", InvalidNearbySearch, maxNearbyRadius)
+	}
+
+	form.Limit = nearbyPvzLimit
+
+	return p.pvzRepo.GetNearbyPvz(ctx, form)
+}
+
+// GetOverloadedPvz возвращает ПВЗ, загруженные не меньше чем на threshold, самые загруженные идут первыми.
+// Порог больше 1 позволяет найти уже переполненные ПВЗ
+func (p *PvzService) GetOverloadedPvz(ctx context.Context, threshold float64) ([]models.PvzLoad, error) {
+	if threshold == 0 {
+		threshold = defaultUtilizationThreshold
+	}
+
+	if !(threshold > 0 && threshold <= maxUtilizationThreshold) {
+		logger.Error(ctx, fmt.Sprintf("Utilization threshold %.2f is out of range", threshold))
+		return nil, fmt.Errorf("%w: threshold must be between 0 and %d", InvalidThreshold, maxUtilizationThreshold)
+	}
+
+	return p.pvzRepo.GetOverloadedPvz(ctx, threshold)
+}
+
+// GetSchedule возвращает расписание ПВЗ; пустое расписание означает круглосуточную работу
+func (p *PvzService) GetSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error) {
+	if _, err := p.GetPvz(ctx, pvzId); err != nil {
+		return models.PvzSchedule{}, err
+	}
+
+	return p.pvzRepo.GetPvzSchedule(ctx, pvzId)
+}
+
+// UpdateSchedule целиком заменяет недельное расписание и исключения ПВЗ
+func (p *PvzService) UpdateSchedule(ctx context.Context, pvzId uuid.UUID, form forms.PvzScheduleForm) (models.PvzSchedule, error) {
+	schedule, err := forms.ToPvzSchedule(pvzId, form)
+	if err != nil {
+		return models.PvzSchedule{}, fmt.Errorf("%w: %v", InvalidSchedule, err)
+	}
+
+	if err = utils.ValidateSchedule(schedule); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Invalid schedule for pvz %s: %s", pvzId, err.Error()))
+		return models.PvzSchedule{}, fmt.Errorf("%w: %v", InvalidSchedule, err)
+	}
+
+	if _, err = p.GetPvz(ctx, pvzId); err != nil {
+		return models.PvzSchedule{}, err
+	}
+
+	if err = p.pvzRepo.ReplacePvzSchedule(ctx, schedule); err != nil {
+		return models.PvzSchedule{}, err
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Schedule of pvz %s was updated by user %s", pvzId, principal.UserId))
+
+	return schedule, nil
+}
+
+// checkCity пропускает только включенные города из справочника
+func (p *PvzService) checkCity(ctx context.Context, city string) error {
+	isAvailable, err := p.pvzRepo.IsCityAvailable(ctx, city)
+	if err != nil {
+		return err
+	}
+
+	if !isAvailable {
+		logger.Error(ctx, fmt.Sprintf("City %s is not available", city))
+		return CityNotAvailable
+	}
+
+	return nil
+}
+
+func validatePvzDetails(address string, location *forms.LocationForm, openingHours string, phone string, capacity int) error {
+	errs := []error{
+		utils.ValidateAddress(address),
+		utils.ValidateOpeningHours(openingHours),
+		utils.ValidatePhone(phone),
+		utils.ValidateCapacity(capacity),
+	}
+	if location != nil {
+		errs = append(errs, utils.ValidateLocation(location.Latitude, location.Longitude))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: %v", InvalidPvzDetails, err)
+	}
+
+	return nil
+}
+
+func deref[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+
+	return *value
+}
@@ -24,7 +24,7 @@ func TestPvzService_CreatePvz(t *testing.T) {
 	regDate := time.Now().Truncate(time.Millisecond)
 
 	mockRepo := mocks.NewMockPvzRepository(ctrl)
-	service := usecase.NewPvzService(mockRepo)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
 
 	tests := []struct {
 		name    string
@@ -41,6 +41,7 @@ func TestPvzService_CreatePvz(t *testing.T) {
 				City:             "Москва",
 			},
 			mock: func() {
+				mockRepo.EXPECT().IsCityAvailable(gomock.Any(), "Москва").Return(true, nil)
 				mockRepo.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			want: models.Pvz{
@@ -58,11 +59,61 @@ func TestPvzService_CreatePvz(t *testing.T) {
 				City:             "Казань",
 			},
 			mock: func() {
+				mockRepo.EXPECT().IsCityAvailable(gomock.Any(), "Казань").Return(true, nil)
 				mockRepo.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
 			},
 			want:    models.Pvz{},
 			wantErr: true,
 		},
+		{
+			name: "disabled city",
+			input: forms.PvzForm{
+				Id:               pvzId,
+				RegistrationDate: regDate,
+				City:             "Томск",
+			},
+			mock: func() {
+				mockRepo.EXPECT().IsCityAvailable(gomock.Any(), "Томск").Return(false, nil)
+			},
+			want:    models.Pvz{},
+			wantErr: true,
+		},
+		{
+			name: "with address and location",
+			input: forms.PvzForm{
+				Id:               pvzId,
+				RegistrationDate: regDate,
+				City:             "Москва",
+				Address:          "ул. Тверская, д. 1",
+				Location:         &forms.LocationForm{Latitude: 55.7578, Longitude: 37.6117},
+				Phone:            "+74951234567",
+			},
+			mock: func() {
+				mockRepo.EXPECT().IsCityAvailable(gomock.Any(), "Москва").Return(true, nil)
+				mockRepo.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: models.Pvz{
+				Id:               pvzId,
+				RegistrationDate: regDate,
+				City:             "Москва",
+				Address:          "ул. Тверская, д. 1",
+				Location:         &models.GeoPoint{Latitude: 55.7578, Longitude: 37.6117},
+				Phone:            "+74951234567",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid location",
+			input: forms.PvzForm{
+				Id:               pvzId,
+				RegistrationDate: regDate,
+				City:             "Москва",
+				Location:         &forms.LocationForm{Latitude: 155.7578, Longitude: 37.6117},
+			},
+			mock:    func() {},
+			want:    models.Pvz{},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -82,7 +133,7 @@ func TestPvzService_GetPvzInfo(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockPvzRepository(ctrl)
-	service := usecase.NewPvzService(mockRepo)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
 
 	startDate := time.Now().Truncate(time.Millisecond)
 	endDate := time.Now().Truncate(time.Millisecond)
@@ -165,7 +216,7 @@ func TestPvzService_GetPvzList(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockPvzRepository(ctrl)
-	service := usecase.NewPvzService(mockRepo)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
 
 	pvzIdFirst := uuid.New()
 	pvzIdSecond := uuid.New()
@@ -211,3 +262,331 @@ func TestPvzService_GetPvzList(t *testing.T) {
 		})
 	}
 }
+
+func TestPvzService_AssignEmployee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	pvzId := uuid.New()
+	userId := uuid.New()
+	assignedAt := time.Now().UTC()
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockRepo.EXPECT().AssignEmployee(gomock.Any(), gomock.Any(), []string{"employee"}).DoAndReturn(func(ctx context.Context, employee models.PvzEmployee, roles []string) (models.PvzEmployee, error) {
+					assert.Equal(t, userId.String(), employee.UserId)
+					assert.Equal(t, pvzId, employee.PvzId)
+					employee.AssignedAt = assignedAt
+					return employee, nil
+				})
+			},
+		},
+		{
+			name: "employee or pvz not found",
+			mock: func() {
+				mockRepo.EXPECT().AssignEmployee(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.PvzEmployee{}, nil)
+			},
+			wantErr: usecase.EmployeeOrPvzNotFound,
+		},
+		{
+			name: "repository error",
+			mock: func() {
+				mockRepo.EXPECT().AssignEmployee(gomock.Any(), gomock.Any(), gomock.Any()).Return(models.PvzEmployee{}, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.AssignEmployee(context.Background(), pvzId, userId)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, models.PvzEmployee{UserId: userId.String(), PvzId: pvzId, AssignedAt: assignedAt}, got)
+			}
+		})
+	}
+}
+
+func TestPvzService_AssignEmployee_Policy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy, err := models.NewPolicy(map[string][]string{
+		"employee":        {"reception:create"},
+		"senior_employee": {"reception:create", "reception:close"},
+		"auditor":         {"pvz:read"},
+	})
+	assert.NoError(t, err)
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, policy)
+	pvzId := uuid.New()
+	userId := uuid.New()
+
+	mockRepo.EXPECT().AssignEmployee(gomock.Any(), gomock.Any(), []string{"employee", "senior_employee"}).
+		DoAndReturn(func(ctx context.Context, employee models.PvzEmployee, roles []string) (models.PvzEmployee, error) {
+			return employee, nil
+		})
+
+	_, err = service.AssignEmployee(context.Background(), pvzId, userId)
+	assert.NoError(t, err, "assignable roles come from the injected policy")
+}
+
+func TestPvzService_UnassignEmployee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	pvzId := uuid.New()
+	userId := uuid.New()
+
+	mockRepo.EXPECT().UnassignEmployee(gomock.Any(), userId.String(), pvzId).Return(true, nil)
+	assert.NoError(t, service.UnassignEmployee(context.Background(), pvzId, userId))
+
+	mockRepo.EXPECT().UnassignEmployee(gomock.Any(), userId.String(), pvzId).Return(false, nil)
+	assert.ErrorIs(t, service.UnassignEmployee(context.Background(), pvzId, userId), usecase.EmployeeNotAssigned)
+
+	mockRepo.EXPECT().UnassignEmployee(gomock.Any(), userId.String(), pvzId).Return(false, errors.New("db error"))
+	assert.Error(t, service.UnassignEmployee(context.Background(), pvzId, userId))
+}
+
+func TestPvzService_GetPvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	pvz := models.Pvz{Id: uuid.New(), RegistrationDate: time.Now().UTC(), City: "Москва"}
+
+	mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+	got, err := service.GetPvz(context.Background(), pvz.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, pvz, got)
+
+	mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(models.Pvz{}, nil)
+	_, err = service.GetPvz(context.Background(), pvz.Id)
+	assert.ErrorIs(t, err, usecase.PvzNotFound)
+}
+
+func TestPvzService_UpdatePvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	city := "Казань"
+	pvz := models.Pvz{Id: uuid.New(), RegistrationDate: time.Now().UTC(), City: city}
+
+	mockRepo.EXPECT().IsCityAvailable(gomock.Any(), city).Return(true, nil).Times(2)
+	mockRepo.EXPECT().UpdatePvz(gomock.Any(), pvz.Id, forms.UpdatePvzForm{City: &city}).Return(pvz, nil)
+	got, err := service.UpdatePvz(context.Background(), pvz.Id, forms.UpdatePvzForm{City: &city})
+	assert.NoError(t, err)
+	assert.Equal(t, pvz, got)
+
+	mockRepo.EXPECT().UpdatePvz(gomock.Any(), pvz.Id, forms.UpdatePvzForm{City: &city}).Return(models.Pvz{}, nil)
+	_, err = service.UpdatePvz(context.Background(), pvz.Id, forms.UpdatePvzForm{City: &city})
+	assert.ErrorIs(t, err, usecase.PvzNotFound)
+
+	// без города справочник не проверяется
+	phone := "+78432123456"
+	mockRepo.EXPECT().UpdatePvz(gomock.Any(), pvz.Id, forms.UpdatePvzForm{Phone: &phone}).Return(pvz, nil)
+	_, err = service.UpdatePvz(context.Background(), pvz.Id, forms.UpdatePvzForm{Phone: &phone})
+	assert.NoError(t, err)
+
+	invalidPhone := "88432123456"
+	_, err = service.UpdatePvz(context.Background(), pvz.Id, forms.UpdatePvzForm{Phone: &invalidPhone})
+	assert.ErrorIs(t, err, usecase.InvalidPvzDetails)
+
+	disabledCity := "Томск"
+	mockRepo.EXPECT().IsCityAvailable(gomock.Any(), disabledCity).Return(false, nil)
+	_, err = service.UpdatePvz(context.Background(), pvz.Id, forms.UpdatePvzForm{City: &disabledCity})
+	assert.ErrorIs(t, err, usecase.CityNotAvailable)
+
+	// пустой PATCH ничего не меняет и возвращает текущее состояние
+	mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+	got, err = service.UpdatePvz(context.Background(), pvz.Id, forms.UpdatePvzForm{})
+	assert.NoError(t, err)
+	assert.Equal(t, pvz, got)
+}
+
+func TestPvzService_DecommissionPvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	pvz := models.Pvz{Id: uuid.New(), RegistrationDate: time.Now().UTC(), City: "Москва"}
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		mock    func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+				mockRepo.EXPECT().DecommissionPvz(gomock.Any(), pvz.Id, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name: "not found",
+			mock: func() {
+				mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(models.Pvz{}, nil)
+			},
+			wantErr: usecase.PvzNotFound,
+		},
+		{
+			name: "already decommissioned",
+			mock: func() {
+				decommissioned := pvz
+				decommissioned.DecommissionedAt = time.Now().UTC()
+				mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(decommissioned, nil)
+			},
+			wantErr: usecase.PvzDecommissioned,
+		},
+		{
+			name: "open reception",
+			mock: func() {
+				mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+				mockRepo.EXPECT().DecommissionPvz(gomock.Any(), pvz.Id, gomock.Any()).Return(false, nil)
+			},
+			wantErr: usecase.PvzHasOpenReception,
+		},
+		{
+			name: "repository error",
+			mock: func() {
+				mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+				mockRepo.EXPECT().DecommissionPvz(gomock.Any(), pvz.Id, gomock.Any()).Return(false, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			err := service.DecommissionPvz(context.Background(), pvz.Id)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPvzService_GetNearbyPvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	nearby := []models.NearbyPvz{{Pvz: models.Pvz{Id: uuid.New(), City: "Москва"}, Distance: 412.5}}
+
+	// без радиуса берётся радиус по умолчанию
+	mockRepo.EXPECT().GetNearbyPvz(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, form forms.NearbyPvzForm) ([]models.NearbyPvz, error) {
+			assert.Equal(t, float64(5000), form.Radius)
+			assert.Positive(t, form.Limit)
+			return nearby, nil
+		})
+	got, err := service.GetNearbyPvz(context.Background(), forms.NearbyPvzForm{Latitude: 55.7558, Longitude: 37.6173})
+	assert.NoError(t, err)
+	assert.Equal(t, nearby, got)
+
+	_, err = service.GetNearbyPvz(context.Background(), forms.NearbyPvzForm{Latitude: 95, Longitude: 37.6173})
+	assert.ErrorIs(t, err, usecase.InvalidNearbySearch)
+
+	_, err = service.GetNearbyPvz(context.Background(), forms.NearbyPvzForm{Latitude: 55.7558, Longitude: 37.6173, Radius: 100000})
+	assert.ErrorIs(t, err, usecase.InvalidNearbySearch)
+}
+
+func TestPvzService_GetOverloadedPvz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	loads := []models.PvzLoad{{Pvz: models.Pvz{Id: uuid.New(), City: "Москва", Capacity: 100}, OnHand: 120}}
+
+	// без порога берётся порог по умолчанию
+	mockRepo.EXPECT().GetOverloadedPvz(gomock.Any(), 0.9).Return(loads, nil)
+	got, err := service.GetOverloadedPvz(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, loads, got)
+
+	mockRepo.EXPECT().GetOverloadedPvz(gomock.Any(), 1.0).Return(nil, errors.New("db error"))
+	_, err = service.GetOverloadedPvz(context.Background(), 1)
+	assert.Error(t, err)
+
+	_, err = service.GetOverloadedPvz(context.Background(), -0.5)
+	assert.ErrorIs(t, err, usecase.InvalidThreshold)
+
+	_, err = service.GetOverloadedPvz(context.Background(), 50)
+	assert.ErrorIs(t, err, usecase.InvalidThreshold)
+}
+
+func TestPvzService_UpdateSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPvzRepository(ctrl)
+	service := usecase.NewPvzService(mockRepo, models.DefaultPolicy())
+
+	pvz := models.Pvz{Id: uuid.New(), City: "Москва"}
+	form := forms.PvzScheduleForm{
+		Timezone:   "Europe/Moscow",
+		Week:       []forms.WorkingHoursForm{{Weekday: 1, OpensAt: "09:00", ClosesAt: "21:00"}},
+		Exceptions: []forms.ScheduleExceptionForm{{Date: "2026-01-01", Description: "Новый год"}},
+	}
+
+	mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+	mockRepo.EXPECT().ReplacePvzSchedule(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, schedule models.PvzSchedule) error {
+			assert.Equal(t, pvz.Id, schedule.PvzId)
+			assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), schedule.Exceptions[0].Date)
+			return nil
+		})
+	got, err := service.UpdateSchedule(context.Background(), pvz.Id, form)
+	assert.NoError(t, err)
+	assert.True(t, got.IsConfigured())
+
+	invalidDate := form
+	invalidDate.Exceptions = []forms.ScheduleExceptionForm{{Date: "01.01.2026"}}
+	_, err = service.UpdateSchedule(context.Background(), pvz.Id, invalidDate)
+	assert.ErrorIs(t, err, usecase.InvalidSchedule)
+
+	invalidTimezone := form
+	invalidTimezone.Timezone = "Moscow"
+	_, err = service.UpdateSchedule(context.Background(), pvz.Id, invalidTimezone)
+	assert.ErrorIs(t, err, usecase.InvalidSchedule)
+
+	mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(models.Pvz{}, nil)
+	_, err = service.UpdateSchedule(context.Background(), pvz.Id, form)
+	assert.ErrorIs(t, err, usecase.PvzNotFound)
+
+	mockRepo.EXPECT().GetPvz(gomock.Any(), pvz.Id).Return(pvz, nil)
+	mockRepo.EXPECT().GetPvzSchedule(gomock.Any(), pvz.Id).Return(models.PvzSchedule{}, nil)
+	got, err = service.GetSchedule(context.Background(), pvz.Id)
+	assert.NoError(t, err)
+	assert.False(t, got.IsConfigured())
+}
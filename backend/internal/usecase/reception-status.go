@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"pvz/internal/models"
+)
+
+// IllegalStatusTransition - приёмку нельзя перевести из текущего статуса в запрошенный
+var IllegalStatusTransition = errors.New("illegal reception status transition")
+
+// StatusTransitionError - запрошенный переход статуса приёмки, которого нет в receptionTransitions
+type StatusTransitionError struct {
+	From models.Status
+	To   models.Status
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", IllegalStatusTransition, e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return IllegalStatusTransition
+}
+
+// receptionTransitions - разрешённые переходы статусов приёмки. Отменённая и проверенная приёмки больше не меняются
+var receptionTransitions = map[models.Status][]models.Status{
+	models.InProgress: {models.Closed, models.Cancelled},
+	models.Closed:     {models.InProgress, models.Verified},
+}
+
+func checkTransition(from, to models.Status) error {
+	if !slices.Contains(receptionTransitions[from], to) {
+		return &StatusTransitionError{From: from, To: to}
+	}
+
+	return nil
+}
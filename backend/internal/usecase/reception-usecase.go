@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,30 +12,100 @@ import (
 	"pvz/config"
 	"pvz/internal/delivery/forms"
 	"pvz/internal/models"
+	"pvz/internal/utils"
 	"pvz/pkg/logger"
 )
 
-var ReceptionNotOpened = errors.New("reception is not opened")
+var (
+	ReceptionNotOpened = errors.New("reception is not opened")
+	ReceptionNotFound  = errors.New("reception not found")
+	PvzAccessDenied    = errors.New("user is not assigned to pvz")
+	// CategoryNotAvailable - тип товара отсутствует в справочнике категорий или отключен
+	CategoryNotAvailable = errors.New("category is unknown or inactive")
+	// PvzClosed - по расписанию ПВЗ сейчас не работает
+	PvzClosed = errors.New("pvz is closed by schedule")
+	// PvzOverCapacity - склад ПВЗ заполнен, а политика вместимости запрещает принимать товар сверх нормы
+	PvzOverCapacity = errors.New("pvz is over capacity")
+	InvalidBatch    = errors.New("invalid product batch")
+	InvalidProduct  = errors.New("invalid product")
+	// DuplicateBarcode - товар с таким штрихкодом уже принят в эту приёмку
+	DuplicateBarcode = errors.New("barcode is already received in this reception")
+	InvalidManifest  = errors.New("invalid manifest")
+	// DiscrepancyReportNotFound - к приёмке не было манифеста или она ещё не закрыта
+	DiscrepancyReportNotFound = errors.New("reception has no discrepancy report")
+	InvalidReason             = errors.New("invalid reason")
+	// ReceptionNotReopenable - у ПВЗ уже есть открытая приёмка или он выведен из эксплуатации
+	ReceptionNotReopenable = errors.New("reception can not be reopened")
+)
+
+const (
+	// maxBatchSize - сколько товаров можно принять одним пакетом
+	maxBatchSize = 500
+	// maxManifestSize - сколько товаров можно ожидать в одной приёмке
+	maxManifestSize = 5000
+)
+
+// BatchItemsError - пакет товаров отклонён целиком, Items содержит причины по номерам позиций
+type BatchItemsError struct {
+	Items map[int]error
+}
+
+func (e *BatchItemsError) Error() string {
+	return fmt.Sprintf("%s: %d items are rejected", InvalidBatch, len(e.Items))
+}
+
+func (e *BatchItemsError) Unwrap() error {
+	return InvalidBatch
+}
 
 type ReceptionRepository interface {
 	CreateReception(ctx context.Context, receptionData models.Reception) error
+	CreateReceptionOutsideSchedule(ctx context.Context, receptionData models.Reception, entry models.AuditEntry) error
 	AddProduct(ctx context.Context, product models.Product) error
+	AddProducts(ctx context.Context, products []models.Product) error
 	GetOpenReception(ctx context.Context, pvzId uuid.UUID) (models.Reception, error)
 	RemoveProduct(ctx context.Context, receptionId uuid.UUID) error
-	CloseReception(ctx context.Context, receptionData models.Reception) error
+	DeleteProduct(ctx context.Context, receptionId uuid.UUID, productId uuid.UUID) (bool, error)
+	CloseReception(ctx context.Context, receptionData models.Reception) (bool, error)
+	IsCategoryActive(ctx context.Context, code string) (bool, error)
+	GetPvzSchedule(ctx context.Context, pvzId uuid.UUID) (models.PvzSchedule, error)
+	GetPvzLoad(ctx context.Context, pvzId uuid.UUID) (models.PvzLoad, error)
+	ListReceptions(ctx context.Context, filter models.ReceptionFilter) ([]models.Reception, error)
+	ListStaleReceptions(ctx context.Context, idleSince time.Time) ([]models.Reception, error)
+	GetReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error)
+	GetReceivedBarcodes(ctx context.Context, receptionId uuid.UUID, barcodes []string) ([]string, error)
+	SearchProducts(ctx context.Context, barcode string) ([]models.ProductLocation, error)
+	ReplaceManifest(ctx context.Context, manifest models.Manifest) (bool, error)
+	GetReceptionManifest(ctx context.Context, reception models.Reception) (models.Manifest, error)
+	CloseReceptionWithReport(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport) (bool, error)
+	AutoCloseReception(ctx context.Context, receptionData models.Reception, report models.DiscrepancyReport, entry models.AuditEntry) (bool, error)
+	GetDiscrepancyReport(ctx context.Context, receptionId uuid.UUID) (models.DiscrepancyReport, error)
+	ReopenReception(ctx context.Context, receptionId uuid.UUID, entry models.AuditEntry) (bool, error)
+	ChangeReceptionStatus(ctx context.Context, receptionId uuid.UUID, from, to models.Status, entry models.AuditEntry) (bool, error)
+	GetReceptionHistory(ctx context.Context, receptionId uuid.UUID) ([]models.AuditEntry, error)
 }
 
 type ReceptionService struct {
-	receptionRepo ReceptionRepository
+	receptionRepo  ReceptionRepository
+	pvzAccess      *PvzAccessChecker
+	capacityPolicy string
 }
 
-func NewReceptionService(receptionRepo ReceptionRepository) *ReceptionService {
+// NewReceptionService - capacityPolicy принимает значения config.CapacityWarn или config.CapacityReject
+func NewReceptionService(receptionRepo ReceptionRepository, pvzAccess *PvzAccessChecker, capacityPolicy string) *ReceptionService {
 	return &ReceptionService{
-		receptionRepo: receptionRepo,
+		receptionRepo:  receptionRepo,
+		pvzAccess:      pvzAccess,
+		capacityPolicy: capacityPolicy,
 	}
 }
 
 func (rc *ReceptionService) CreateReception(ctx context.Context, receptionForm forms.ReceptionForm) (models.Reception, error) {
+	principal, err := rc.pvzAccess.Check(ctx, receptionForm.PvzId)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
 	formattedStr := time.Now().Format(config.TimeStampLayout)
 	dateTime, err := time.Parse(config.TimeStampLayout, formattedStr)
 	if err != nil {
@@ -49,15 +120,80 @@ func (rc *ReceptionService) CreateReception(ctx context.Context, receptionForm f
 		Status:   models.InProgress,
 	}
 
-	err = rc.receptionRepo.CreateReception(ctx, reception)
+	schedule, err := rc.receptionRepo.GetPvzSchedule(ctx, receptionForm.PvzId)
 	if err != nil {
 		return models.Reception{}, err
 	}
 
+	isOpen, err := schedule.IsOpenAt(time.Now())
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Unable to check schedule of pvz %s: %s", receptionForm.PvzId, err.Error()))
+		return models.Reception{}, err
+	}
+
+	// вне часов работы приёмку можно открыть только явно и с причиной: она помечается для модератора
+	// и попадает в журнал приёмки вместе с тем, кто её открыл
+	if isOpen {
+		err = rc.receptionRepo.CreateReception(ctx, reception)
+		if err != nil {
+			return models.Reception{}, err
+		}
+
+		logger.Info(ctx, fmt.Sprintf("Reception %s was opened by user %s", reception.Id, principal.UserId))
+		return reception, nil
+	}
+
+	if !receptionForm.ScheduleOverride {
+		logger.Error(ctx, fmt.Sprintf("Pvz %s is closed by schedule", receptionForm.PvzId))
+		return models.Reception{}, PvzClosed
+	}
+
+	if err = utils.ValidateReason(receptionForm.OverrideReason); err != nil {
+		logger.Error(ctx, err.Error())
+		return models.Reception{}, fmt.Errorf("%w: %v", InvalidReason, err)
+	}
+
+	reception.OutsideSchedule = true
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   principal.UserId,
+		Action:    models.AuditReceptionScheduleOverride,
+		TargetId:  reception.Id.String(),
+		Details:   map[string]string{"reason": receptionForm.OverrideReason, "pvzId": reception.PvzId.String()},
+		CreatedAt: time.Now(),
+	}
+
+	err = rc.receptionRepo.CreateReceptionOutsideSchedule(ctx, reception, entry)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Reception %s was opened outside working hours by user %s and needs moderator review: %s",
+		reception.Id, principal.UserId, receptionForm.OverrideReason))
+
 	return reception, nil
 }
 
 func (rc *ReceptionService) AddProduct(ctx context.Context, productForm forms.ProductForm) (models.Product, error) {
+	if _, err := rc.pvzAccess.Check(ctx, productForm.PvzId); err != nil {
+		return models.Product{}, err
+	}
+
+	if err := validateProductIdentifiers(productForm.Barcode, productForm.ExternalOrderId); err != nil {
+		logger.Error(ctx, err.Error())
+		return models.Product{}, err
+	}
+
+	isActive, err := rc.receptionRepo.IsCategoryActive(ctx, productForm.Type)
+	if err != nil {
+		return models.Product{}, err
+	}
+
+	if !isActive {
+		logger.Error(ctx, fmt.Sprintf("Category %s is not available", productForm.Type))
+		return models.Product{}, CategoryNotAvailable
+	}
+
 	formattedStr := time.Now().Format(config.TimeStampLayout)
 	dateTime, err := time.Parse(config.TimeStampLayout, formattedStr)
 	if err != nil {
@@ -66,10 +202,13 @@ func (rc *ReceptionService) AddProduct(ctx context.Context, productForm forms.Pr
 	}
 
 	product := models.Product{
-		Id:          uuid.New(),
-		DateTime:    dateTime,
-		ProductType: productForm.Type,
-		ReceptionId: uuid.UUID{},
+		Id:              uuid.New(),
+		DateTime:        dateTime,
+		ProductType:     productForm.Type,
+		ReceptionId:     uuid.UUID{},
+		Barcode:         productForm.Barcode,
+		ExternalOrderId: productForm.ExternalOrderId,
+		Status:          models.ProductStored,
 	}
 
 	reception, err := rc.receptionRepo.GetOpenReception(ctx, productForm.PvzId)
@@ -83,15 +222,157 @@ func (rc *ReceptionService) AddProduct(ctx context.Context, productForm forms.Pr
 
 	product.ReceptionId = reception.Id
 
+	if product.Barcode != "" {
+		received, err := rc.receptionRepo.GetReceivedBarcodes(ctx, reception.Id, []string{product.Barcode})
+		if err != nil {
+			return models.Product{}, err
+		}
+
+		if len(received) > 0 {
+			logger.Error(ctx, fmt.Sprintf("Barcode %s is already received in reception %s", product.Barcode, reception.Id))
+			return models.Product{}, DuplicateBarcode
+		}
+	}
+
+	load, err := rc.checkCapacity(ctx, productForm.PvzId, 1)
+	if err != nil {
+		return models.Product{}, err
+	}
+	product.OverCapacity = load.ExceedsCapacity(1)
+
 	err = rc.receptionRepo.AddProduct(ctx, product)
 	if err != nil {
 		return models.Product{}, err
 	}
 
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Product %s was added to reception %s by user %s", product.Id, reception.Id, principal.UserId))
+
 	return product, nil
 }
 
+// AddProducts принимает пакет товаров в открытую приёмку. Пакет принимается целиком или отклоняется целиком
+func (rc *ReceptionService) AddProducts(ctx context.Context, form forms.BatchProductForm) ([]models.Product, error) {
+	if len(form.Items) == 0 || len(form.Items) > maxBatchSize {
+		logger.Error(ctx, fmt.Sprintf("Batch of %d products is out of range", len(form.Items)))
+		return nil, fmt.Errorf("%w: batch must contain from 1 to %d items", InvalidBatch, maxBatchSize)
+	}
+
+	if _, err := rc.pvzAccess.Check(ctx, form.PvzId); err != nil {
+		return nil, err
+	}
+
+	itemErrors := make(map[int]error)
+	isActive := make(map[string]bool)
+	// barcodeItems - номер первой позиции пакета с этим штрихкодом
+	barcodeItems := make(map[string]int)
+	for i, item := range form.Items {
+		if _, checked := isActive[item.Type]; !checked {
+			active, err := rc.receptionRepo.IsCategoryActive(ctx, item.Type)
+			if err != nil {
+				return nil, err
+			}
+			isActive[item.Type] = active
+		}
+
+		if !isActive[item.Type] {
+			itemErrors[i] = CategoryNotAvailable
+			continue
+		}
+
+		if err := validateProductIdentifiers(item.Barcode, item.ExternalOrderId); err != nil {
+			itemErrors[i] = err
+			continue
+		}
+
+		if item.Barcode == "" {
+			continue
+		}
+
+		if first, ok := barcodeItems[item.Barcode]; ok {
+			itemErrors[i] = fmt.Errorf("%w: barcode repeats item %d", DuplicateBarcode, first)
+			continue
+		}
+		barcodeItems[item.Barcode] = i
+	}
+
+	if len(itemErrors) > 0 {
+		logger.Error(ctx, fmt.Sprintf("Batch for pvz %s has %d invalid items", form.PvzId, len(itemErrors)))
+		return nil, &BatchItemsError{Items: itemErrors}
+	}
+
+	reception, err := rc.receptionRepo.GetOpenReception(ctx, form.PvzId)
+	if err != nil {
+		return nil, err
+	}
+
+	if reception.Id == uuid.Nil {
+		return nil, ReceptionNotOpened
+	}
+
+	if len(barcodeItems) > 0 {
+		barcodes := make([]string, 0, len(barcodeItems))
+		for barcode := range barcodeItems {
+			barcodes = append(barcodes, barcode)
+		}
+
+		received, err := rc.receptionRepo.GetReceivedBarcodes(ctx, reception.Id, barcodes)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, barcode := range received {
+			itemErrors[barcodeItems[barcode]] = DuplicateBarcode
+		}
+
+		if len(itemErrors) > 0 {
+			logger.Error(ctx, fmt.Sprintf("Batch for reception %s has %d already received barcodes", reception.Id, len(itemErrors)))
+			return nil, &BatchItemsError{Items: itemErrors}
+		}
+	}
+
+	load, err := rc.checkCapacity(ctx, form.PvzId, len(form.Items))
+	if err != nil {
+		return nil, err
+	}
+
+	formattedStr := time.Now().Format(config.TimeStampLayout)
+	dateTime, err := time.Parse(config.TimeStampLayout, formattedStr)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error parsing time: %s", err.Error()))
+		return nil, err
+	}
+
+	products := make([]models.Product, 0, len(form.Items))
+	for i, item := range form.Items {
+		products = append(products, models.Product{
+			Id: uuid.New(),
+			// время приёма различается на миллисекунду, чтобы удаление последнего товара сохраняло порядок пакета
+			DateTime:        dateTime.Add(time.Duration(i) * time.Millisecond),
+			ProductType:     item.Type,
+			ReceptionId:     reception.Id,
+			Barcode:         item.Barcode,
+			ExternalOrderId: item.ExternalOrderId,
+			OverCapacity:    load.ExceedsCapacity(i + 1),
+			Status:          models.ProductStored,
+		})
+	}
+
+	if err = rc.receptionRepo.AddProducts(ctx, products); err != nil {
+		return nil, err
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("%d products were added to reception %s by user %s", len(products), reception.Id, principal.UserId))
+
+	return products, nil
+}
+
 func (rc *ReceptionService) RemoveProduct(ctx context.Context, pvzId uuid.UUID) error {
+	if _, err := rc.pvzAccess.Check(ctx, pvzId); err != nil {
+		return err
+	}
+
 	reception, err := rc.receptionRepo.GetOpenReception(ctx, pvzId)
 	if err != nil {
 		return err
@@ -106,10 +387,45 @@ func (rc *ReceptionService) RemoveProduct(ctx context.Context, pvzId uuid.UUID)
 		return err
 	}
 
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Last product of reception %s was removed by user %s", reception.Id, principal.UserId))
+
+	return nil
+}
+
+// RemoveProductById удаляет из открытой приёмки конкретный товар, например повторно отсканированный
+func (rc *ReceptionService) RemoveProductById(ctx context.Context, receptionId uuid.UUID, productId uuid.UUID) error {
+	reception, err := rc.getAssignedReception(ctx, receptionId)
+	if err != nil {
+		return err
+	}
+
+	if reception.Reception.Status != models.InProgress {
+		logger.Error(ctx, fmt.Sprintf("Reception %s is already closed", receptionId))
+		return ReceptionNotOpened
+	}
+
+	isDeleted, err := rc.receptionRepo.DeleteProduct(ctx, receptionId, productId)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		logger.Error(ctx, fmt.Sprintf("Product %s was not found in open reception %s", productId, receptionId))
+		return ProductNotFound
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Product %s of reception %s was removed by user %s", productId, receptionId, principal.UserId))
+
 	return nil
 }
 
 func (rc *ReceptionService) CloseReception(ctx context.Context, pvzId uuid.UUID) (models.Reception, error) {
+	if _, err := rc.pvzAccess.Check(ctx, pvzId); err != nil {
+		return models.Reception{}, err
+	}
+
 	reception, err := rc.receptionRepo.GetOpenReception(ctx, pvzId)
 	if err != nil {
 		return models.Reception{}, err
@@ -119,10 +435,402 @@ func (rc *ReceptionService) CloseReception(ctx context.Context, pvzId uuid.UUID)
 		return models.Reception{}, ReceptionNotOpened
 	}
 
-	err = rc.receptionRepo.CloseReception(ctx, reception)
+	report, err := rc.closeReception(ctx, reception)
 	if err != nil {
 		return models.Reception{}, err
 	}
 
+	principal, _ := utils.GetPrincipal(ctx)
+	if report.ManifestId == uuid.Nil {
+		logger.Info(ctx, fmt.Sprintf("Reception %s was closed by user %s", reception.Id, principal.UserId))
+		return reception, nil
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Reception %s was closed by user %s against manifest %s: %d shortages, %d surpluses, %d wrong category",
+		reception.Id, principal.UserId, report.ManifestId, report.Count(models.Shortage), report.Count(models.Surplus),
+		report.Count(models.WrongCategory)))
+
 	return reception, nil
 }
+
+// CloseStaleReceptions закрывает открытые приёмки, простаивающие дольше idleTimeout, с пометкой AutoClosed.
+// Ошибка закрытия одной приёмки не мешает закрыть остальные
+func (rc *ReceptionService) CloseStaleReceptions(ctx context.Context, idleTimeout time.Duration) ([]models.Reception, error) {
+	stale, err := rc.receptionRepo.ListStaleReceptions(ctx, time.Now().Add(-idleTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make([]models.Reception, 0, len(stale))
+	var errs []error
+	for _, reception := range stale {
+		reception.AutoClosed = true
+		report, err := rc.closeReception(ctx, reception)
+		if err != nil {
+			logger.Error(ctx, fmt.Sprintf("unable to auto-close reception %s: %v", reception.Id, err))
+			errs = append(errs, err)
+			continue
+		}
+
+		logger.Info(ctx, fmt.Sprintf("Reception %s of pvz %s was closed automatically after %s of inactivity, %d discrepancies",
+			reception.Id, reception.PvzId, idleTimeout, len(report.Items)))
+		closed = append(closed, reception)
+	}
+
+	return closed, errors.Join(errs...)
+}
+
+// closeReception закрывает приёмку. Если для ПВЗ загружен манифест или приёмка уже сверялась с ним
+// до переоткрытия, отчёт о расхождениях строится заново и сохраняется вместе с закрытием.
+// Автоматическое закрытие записывается в журнал приёмки от имени системы
+func (rc *ReceptionService) closeReception(ctx context.Context, reception models.Reception) (models.DiscrepancyReport, error) {
+	if err := checkTransition(reception.Status, models.Closed); err != nil {
+		return models.DiscrepancyReport{}, err
+	}
+
+	manifest, err := rc.receptionRepo.GetReceptionManifest(ctx, reception)
+	if err != nil {
+		return models.DiscrepancyReport{}, err
+	}
+
+	var report models.DiscrepancyReport
+	if manifest.Id != uuid.Nil {
+		received, err := rc.receptionRepo.GetReception(ctx, reception.Id)
+		if err != nil {
+			return models.DiscrepancyReport{}, err
+		}
+
+		report = manifest.Compare(reception.Id, received.Products)
+	}
+
+	var isClosed bool
+	switch {
+	case reception.AutoClosed:
+		entry := models.AuditEntry{
+			Id:        uuid.New(),
+			ActorId:   models.SystemActorId,
+			Action:    models.AuditReceptionAutoClose,
+			TargetId:  reception.Id.String(),
+			Details:   map[string]string{"pvzId": reception.PvzId.String(), "discrepancies": strconv.Itoa(len(report.Items))},
+			CreatedAt: time.Now(),
+		}
+		isClosed, err = rc.receptionRepo.AutoCloseReception(ctx, reception, report, entry)
+	case manifest.Id == uuid.Nil:
+		isClosed, err = rc.receptionRepo.CloseReception(ctx, reception)
+	default:
+		isClosed, err = rc.receptionRepo.CloseReceptionWithReport(ctx, reception, report)
+	}
+
+	if err != nil {
+		return models.DiscrepancyReport{}, err
+	}
+
+	// приёмку успели отменить или закрыть между чтением и обновлением
+	if !isClosed {
+		return models.DiscrepancyReport{}, &StatusTransitionError{From: reception.Status, To: models.Closed}
+	}
+
+	return report, nil
+}
+
+// ReopenReception возвращает в работу закрытую по ошибке приёмку. Кто и почему её открыл, записывается в журнал
+func (rc *ReceptionService) ReopenReception(ctx context.Context, receptionId uuid.UUID, form forms.ReopenReceptionForm) (models.Reception, error) {
+	if err := utils.ValidateReason(form.Reason); err != nil {
+		logger.Error(ctx, err.Error())
+		return models.Reception{}, fmt.Errorf("%w: %v", InvalidReason, err)
+	}
+
+	principal, ok := utils.GetPrincipal(ctx)
+	if !ok {
+		logger.Error(ctx, "Principal is missing in context")
+		return models.Reception{}, errors.New("principal is missing in context")
+	}
+
+	received, err := rc.GetReception(ctx, receptionId)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	reception := received.Reception
+	if err = checkTransition(reception.Status, models.InProgress); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Reception %s is %s and can not be reopened", receptionId, reception.Status))
+		return models.Reception{}, err
+	}
+
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   principal.UserId,
+		Action:    models.AuditReceptionReopen,
+		TargetId:  receptionId.String(),
+		Details:   map[string]string{"reason": form.Reason, "pvzId": reception.PvzId.String()},
+		CreatedAt: time.Now(),
+	}
+
+	isReopened, err := rc.receptionRepo.ReopenReception(ctx, receptionId, entry)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	if !isReopened {
+		return models.Reception{}, ReceptionNotReopenable
+	}
+
+	reception.Status = models.InProgress
+	logger.Info(ctx, fmt.Sprintf("Reception %s was reopened by user %s: %s", receptionId, principal.UserId, form.Reason))
+
+	return reception, nil
+}
+
+// CancelReception отменяет открытую по ошибке приёмку, её товары перестают числиться на складе
+func (rc *ReceptionService) CancelReception(ctx context.Context, receptionId uuid.UUID, form forms.CancelReceptionForm) (models.Reception, error) {
+	if err := utils.ValidateReason(form.Reason); err != nil {
+		logger.Error(ctx, err.Error())
+		return models.Reception{}, fmt.Errorf("%w: %v", InvalidReason, err)
+	}
+
+	received, err := rc.getAssignedReception(ctx, receptionId)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	return rc.changeStatus(ctx, received.Reception, models.Cancelled, models.AuditReceptionCancel, form.Reason)
+}
+
+// VerifyReception отмечает, что модератор проверил закрытую приёмку
+func (rc *ReceptionService) VerifyReception(ctx context.Context, receptionId uuid.UUID) (models.Reception, error) {
+	received, err := rc.GetReception(ctx, receptionId)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	return rc.changeStatus(ctx, received.Reception, models.Verified, models.AuditReceptionVerify, "")
+}
+
+// changeStatus переводит приёмку в статус to, если такой переход разрешён, и пишет запись в журнал
+func (rc *ReceptionService) changeStatus(ctx context.Context, reception models.Reception, to models.Status, action models.AuditAction,
+	reason string) (models.Reception, error) {
+	principal, ok := utils.GetPrincipal(ctx)
+	if !ok {
+		logger.Error(ctx, "Principal is missing in context")
+		return models.Reception{}, errors.New("principal is missing in context")
+	}
+
+	if err := checkTransition(reception.Status, to); err != nil {
+		logger.Error(ctx, fmt.Sprintf("Reception %s can not be moved from %s to %s", reception.Id, reception.Status, to))
+		return models.Reception{}, err
+	}
+
+	details := map[string]string{"pvzId": reception.PvzId.String()}
+	if reason != "" {
+		details["reason"] = reason
+	}
+
+	entry := models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   principal.UserId,
+		Action:    action,
+		TargetId:  reception.Id.String(),
+		Details:   details,
+		CreatedAt: time.Now(),
+	}
+
+	isChanged, err := rc.receptionRepo.ChangeReceptionStatus(ctx, reception.Id, reception.Status, to, entry)
+	if err != nil {
+		return models.Reception{}, err
+	}
+
+	// статус успели поменять между чтением и обновлением
+	if !isChanged {
+		return models.Reception{}, &StatusTransitionError{From: reception.Status, To: to}
+	}
+
+	logger.Info(ctx, fmt.Sprintf("Reception %s was moved from %s to %s by user %s", reception.Id, reception.Status, to, principal.UserId))
+	reception.Status = to
+
+	return reception, nil
+}
+
+// GetReceptionHistory возвращает приёмку вместе с журналом смены её статуса
+func (rc *ReceptionService) GetReceptionHistory(ctx context.Context, receptionId uuid.UUID) (models.ReceptionHistory, error) {
+	received, err := rc.GetReception(ctx, receptionId)
+	if err != nil {
+		return models.ReceptionHistory{}, err
+	}
+
+	events, err := rc.receptionRepo.GetReceptionHistory(ctx, receptionId)
+	if err != nil {
+		return models.ReceptionHistory{}, err
+	}
+
+	return models.ReceptionHistory{Reception: received.Reception, Events: events}, nil
+}
+
+// ReplaceManifest задаёт ожидаемую поставку для следующей приёмки ПВЗ вместо ранее загруженной
+func (rc *ReceptionService) ReplaceManifest(ctx context.Context, pvzId uuid.UUID, form forms.ManifestForm) (models.Manifest, error) {
+	if len(form.Items) == 0 || len(form.Items) > maxManifestSize {
+		logger.Error(ctx, fmt.Sprintf("Manifest of %d items is out of range", len(form.Items)))
+		return models.Manifest{}, fmt.Errorf("%w: manifest must contain from 1 to %d items", InvalidManifest, maxManifestSize)
+	}
+
+	manifest := models.Manifest{
+		Id:        uuid.New(),
+		PvzId:     pvzId,
+		CreatedAt: time.Now(),
+		Items:     make([]models.ManifestItem, 0, len(form.Items)),
+	}
+
+	barcodeItems := make(map[string]int)
+	for i, item := range form.Items {
+		if err := utils.ValidateBarcode(item.Barcode); err != nil {
+			logger.Error(ctx, err.Error())
+			return models.Manifest{}, fmt.Errorf("%w: item %d: %v", InvalidManifest, i, err)
+		}
+
+		if first, ok := barcodeItems[item.Barcode]; ok {
+			return models.Manifest{}, fmt.Errorf("%w: item %d: barcode repeats item %d", InvalidManifest, i, first)
+		}
+		barcodeItems[item.Barcode] = i
+	}
+
+	// категории проверяются после штрихкодов, чтобы не ходить в базу за заведомо некорректным манифестом
+	isActive := make(map[string]bool)
+	for i, item := range form.Items {
+		if _, checked := isActive[item.Type]; !checked {
+			active, err := rc.receptionRepo.IsCategoryActive(ctx, item.Type)
+			if err != nil {
+				return models.Manifest{}, err
+			}
+			isActive[item.Type] = active
+		}
+
+		if !isActive[item.Type] {
+			logger.Error(ctx, fmt.Sprintf("Category %s is not available", item.Type))
+			return models.Manifest{}, fmt.Errorf("%w: item %d: %v", InvalidManifest, i, CategoryNotAvailable)
+		}
+
+		manifest.Items = append(manifest.Items, models.ManifestItem{Barcode: item.Barcode, ProductType: item.Type})
+	}
+
+	isReplaced, err := rc.receptionRepo.ReplaceManifest(ctx, manifest)
+	if err != nil {
+		return models.Manifest{}, err
+	}
+
+	if !isReplaced {
+		return models.Manifest{}, PvzNotFound
+	}
+
+	principal, _ := utils.GetPrincipal(ctx)
+	logger.Info(ctx, fmt.Sprintf("Manifest %s of %d items for pvz %s was uploaded by user %s", manifest.Id, len(manifest.Items), pvzId, principal.UserId))
+
+	return manifest, nil
+}
+
+// GetDiscrepancyReport возвращает расхождения закрытой приёмки с её манифестом
+func (rc *ReceptionService) GetDiscrepancyReport(ctx context.Context, receptionId uuid.UUID) (models.DiscrepancyReport, error) {
+	report, err := rc.receptionRepo.GetDiscrepancyReport(ctx, receptionId)
+	if err != nil {
+		return models.DiscrepancyReport{}, err
+	}
+
+	if report.ManifestId == uuid.Nil {
+		return models.DiscrepancyReport{}, DiscrepancyReportNotFound
+	}
+
+	return report, nil
+}
+
+func (rc *ReceptionService) ListReceptions(ctx context.Context, filter models.ReceptionFilter) ([]models.Reception, error) {
+	return rc.receptionRepo.ListReceptions(ctx, filter)
+}
+
+func (rc *ReceptionService) GetReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error) {
+	reception, err := rc.receptionRepo.GetReception(ctx, receptionId)
+	if err != nil {
+		return models.ReceptionProducts{}, err
+	}
+
+	if reception.Reception.Id == uuid.Nil {
+		return models.ReceptionProducts{}, ReceptionNotFound
+	}
+
+	return reception, nil
+}
+
+// getAssignedReception возвращает приёмку ПВЗ, за которым закреплён пользователь. Приёмка чужого ПВЗ
+// неотличима от несуществующей, чтобы по ответам нельзя было перебрать идентификаторы приёмок
+func (rc *ReceptionService) getAssignedReception(ctx context.Context, receptionId uuid.UUID) (models.ReceptionProducts, error) {
+	reception, err := rc.GetReception(ctx, receptionId)
+	if err != nil {
+		return models.ReceptionProducts{}, err
+	}
+
+	if _, err = rc.pvzAccess.Check(ctx, reception.Reception.PvzId); err != nil {
+		if errors.Is(err, PvzAccessDenied) {
+			return models.ReceptionProducts{}, ReceptionNotFound
+		}
+		return models.ReceptionProducts{}, err
+	}
+
+	return reception, nil
+}
+
+// GetCurrentReception возвращает открытую приёмку ПВЗ вместе с уже принятыми товарами
+func (rc *ReceptionService) GetCurrentReception(ctx context.Context, pvzId uuid.UUID) (models.ReceptionProducts, error) {
+	reception, err := rc.receptionRepo.GetOpenReception(ctx, pvzId)
+	if err != nil {
+		return models.ReceptionProducts{}, err
+	}
+
+	if reception.Id == uuid.Nil {
+		return models.ReceptionProducts{}, ReceptionNotOpened
+	}
+
+	return rc.GetReception(ctx, reception.Id)
+}
+
+// SearchProducts показывает, в каких ПВЗ и приёмках был принят товар со штрихкодом
+func (rc *ReceptionService) SearchProducts(ctx context.Context, barcode string) ([]models.ProductLocation, error) {
+	if err := utils.ValidateBarcode(barcode); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, fmt.Errorf("%w: %v", InvalidProduct, err)
+	}
+
+	return rc.receptionRepo.SearchProducts(ctx, barcode)
+}
+
+// checkCapacity проверяет, поместятся ли count товаров на склад ПВЗ. При политике reject лишние товары не принимаются,
+// при warn принимаются с пометкой
+func (rc *ReceptionService) checkCapacity(ctx context.Context, pvzId uuid.UUID, count int) (models.PvzLoad, error) {
+	load, err := rc.receptionRepo.GetPvzLoad(ctx, pvzId)
+	if err != nil {
+		return models.PvzLoad{}, err
+	}
+
+	if !load.ExceedsCapacity(count) {
+		return load, nil
+	}
+
+	if rc.capacityPolicy == config.CapacityReject {
+		logger.Error(ctx, fmt.Sprintf("Pvz %s is full: %d of %d items on hand, %d more requested", pvzId, load.OnHand, load.Pvz.Capacity, count))
+		return models.PvzLoad{}, PvzOverCapacity
+	}
+
+	logger.Warn(ctx, fmt.Sprintf("Pvz %s is over capacity: %d of %d items on hand, %d more accepted", pvzId, load.OnHand, load.Pvz.Capacity, count))
+	return load, nil
+}
+
+// validateProductIdentifiers - штрихкод и номер заказа необязательны, но переданные значения должны быть корректными
+func validateProductIdentifiers(barcode, externalOrderId string) error {
+	if barcode != "" {
+		if err := utils.ValidateBarcode(barcode); err != nil {
+			return fmt.Errorf("%w: %v", InvalidProduct, err)
+		}
+	}
+
+	if err := utils.ValidateExternalOrderId(externalOrderId); err != nil {
+		return fmt.Errorf("%w: %v", InvalidProduct, err)
+	}
+
+	return nil
+}
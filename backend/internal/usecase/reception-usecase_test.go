@@ -10,10 +10,17 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"pvz/config"
 	"pvz/internal/delivery/forms"
 	"pvz/internal/models"
 	"pvz/internal/usecase"
 	"pvz/internal/usecase/mocks"
+	"pvz/internal/utils"
+)
+
+var (
+	employee    = models.Principal{UserId: "employee-id", Role: string(models.Employee)}
+	employeeCtx = utils.SetPrincipal(context.Background(), employee)
 )
 
 func TestReceptionService_CreateReception(t *testing.T) {
@@ -21,42 +28,96 @@ func TestReceptionService_CreateReception(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockReceptionRepository(ctrl)
-	service := usecase.NewReceptionService(mockRepo)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
 
-	form := forms.ReceptionForm{
-		PvzId: uuid.New(),
-	}
+	pvzId := uuid.New()
+	// расписание без рабочих дней: ПВЗ закрыт всегда
+	closedSchedule := models.PvzSchedule{PvzId: pvzId, Timezone: "Europe/Moscow"}
+	dbErr := errors.New("db error")
 
 	tests := []struct {
-		name    string
-		mock    func()
-		want    models.Reception
-		wantErr bool
+		name     string
+		override bool
+		reason   string
+		mock     func()
+		want     models.Reception
+		wantErr  error
 	}{
 		{
 			name: "success",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().GetPvzSchedule(gomock.Any(), pvzId).Return(models.PvzSchedule{}, nil)
 				mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil)
 			},
-			want:    models.Reception{Status: models.InProgress},
-			wantErr: false,
+			want: models.Reception{Status: models.InProgress},
 		},
 		{
 			name: "repository error",
 			mock: func() {
-				mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().GetPvzSchedule(gomock.Any(), pvzId).Return(models.PvzSchedule{}, nil)
+				mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(dbErr)
 			},
 			want:    models.Reception{},
-			wantErr: true,
+			wantErr: dbErr,
+		},
+		{
+			name: "employee is not assigned to pvz",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+			},
+			want:    models.Reception{},
+			wantErr: usecase.PvzAccessDenied,
+		},
+		{
+			name: "pvz is closed",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().GetPvzSchedule(gomock.Any(), pvzId).Return(closedSchedule, nil)
+			},
+			want:    models.Reception{},
+			wantErr: usecase.PvzClosed,
+		},
+		{
+			name:     "pvz is closed, override without reason",
+			override: true,
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().GetPvzSchedule(gomock.Any(), pvzId).Return(closedSchedule, nil)
+			},
+			want:    models.Reception{},
+			wantErr: usecase.InvalidReason,
+		},
+		{
+			name:     "pvz is closed, reception is flagged and audited for review",
+			override: true,
+			reason:   "поздняя поставка",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().GetPvzSchedule(gomock.Any(), pvzId).Return(closedSchedule, nil)
+				mockRepo.EXPECT().CreateReceptionOutsideSchedule(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, reception models.Reception, entry models.AuditEntry) error {
+						assert.True(t, reception.OutsideSchedule)
+						assert.Equal(t, models.AuditReceptionScheduleOverride, entry.Action)
+						assert.Equal(t, employee.UserId, entry.ActorId)
+						assert.Equal(t, reception.Id.String(), entry.TargetId)
+						assert.Equal(t, map[string]string{"reason": "поздняя поставка", "pvzId": pvzId.String()}, entry.Details)
+						return nil
+					})
+			},
+			want: models.Reception{Status: models.InProgress, OutsideSchedule: true},
 		},
 	}
 
 	for _, tt := range tests {
 		tt.mock()
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := service.CreateReception(context.Background(), form)
-			assert.Equal(t, tt.wantErr, err != nil)
+			got, err := service.CreateReception(employeeCtx, forms.ReceptionForm{PvzId: pvzId, ScheduleOverride: tt.override, OverrideReason: tt.reason})
+			assert.ErrorIs(t, err, tt.wantErr)
 			assert.Equal(t, tt.want.Status, got.Status)
+			assert.Equal(t, tt.want.OutsideSchedule, got.OutsideSchedule)
 		})
 	}
 }
@@ -66,7 +127,8 @@ func TestReceptionService_AddProduct(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockReceptionRepository(ctrl)
-	service := usecase.NewReceptionService(mockRepo)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
 
 	form := forms.ProductForm{
 		PvzId: uuid.New(),
@@ -82,12 +144,15 @@ func TestReceptionService_AddProduct(t *testing.T) {
 		{
 			name: "success",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().IsCategoryActive(gomock.Any(), form.Type).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(models.Reception{
 					Id:       uuid.New(),
 					Status:   models.InProgress,
 					PvzId:    uuid.New(),
 					DateTime: time.Now(),
 				}, nil)
+				mockRepo.EXPECT().GetPvzLoad(gomock.Any(), form.PvzId).Return(models.PvzLoad{}, nil)
 				mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			want:    models.Product{ProductType: "Electronics"},
@@ -96,6 +161,8 @@ func TestReceptionService_AddProduct(t *testing.T) {
 		{
 			name: "reception not opened",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().IsCategoryActive(gomock.Any(), form.Type).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(models.Reception{}, nil)
 			},
 			want:    models.Product{},
@@ -104,32 +171,154 @@ func TestReceptionService_AddProduct(t *testing.T) {
 		{
 			name: "repository error",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().IsCategoryActive(gomock.Any(), form.Type).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(models.Reception{
 					Id: uuid.New(),
 				}, nil)
+				mockRepo.EXPECT().GetPvzLoad(gomock.Any(), form.PvzId).Return(models.PvzLoad{}, nil)
 				mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
 			},
 			want:    models.Product{},
 			wantErr: true,
 		},
+		{
+			name: "inactive category",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().IsCategoryActive(gomock.Any(), form.Type).Return(false, nil)
+			},
+			want:    models.Product{},
+			wantErr: true,
+		},
+		{
+			name: "employee is not assigned to pvz",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, form.PvzId).Return(false, nil)
+			},
+			want:    models.Product{},
+			wantErr: true,
+		},
+		{
+			name: "assignment check error",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, form.PvzId).Return(false, errors.New("db error"))
+			},
+			want:    models.Product{},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		tt.mock()
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := service.AddProduct(context.Background(), form)
+			got, err := service.AddProduct(employeeCtx, form)
 			assert.Equal(t, tt.wantErr, err != nil)
 			assert.Equal(t, tt.want.ProductType, got.ProductType)
 		})
 	}
 }
 
+func TestReceptionService_AddProduct_Capacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	form := forms.ProductForm{
+		PvzId: uuid.New(),
+		Type:  "Electronics",
+	}
+	full := models.PvzLoad{Pvz: models.Pvz{Id: form.PvzId, Capacity: 10}, OnHand: 10}
+
+	tests := []struct {
+		name             string
+		policy           string
+		load             models.PvzLoad
+		wantOverCapacity bool
+		wantErr          error
+	}{
+		{name: "unlimited capacity", policy: config.CapacityReject, load: models.PvzLoad{OnHand: 500}},
+		{name: "last free place", policy: config.CapacityReject, load: models.PvzLoad{Pvz: full.Pvz, OnHand: 9}},
+		{name: "full pvz with warn policy", policy: config.CapacityWarn, load: full, wantOverCapacity: true},
+		{name: "full pvz with reject policy", policy: config.CapacityReject, load: full, wantErr: usecase.PvzOverCapacity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+			service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), tt.policy)
+
+			mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, form.PvzId).Return(true, nil)
+			mockRepo.EXPECT().IsCategoryActive(gomock.Any(), form.Type).Return(true, nil)
+			mockRepo.EXPECT().GetOpenReception(gomock.Any(), form.PvzId).Return(models.Reception{Id: uuid.New()}, nil)
+			mockRepo.EXPECT().GetPvzLoad(gomock.Any(), form.PvzId).Return(tt.load, nil)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, product models.Product) {
+						assert.Equal(t, tt.wantOverCapacity, product.OverCapacity)
+					}).Return(nil)
+			}
+
+			got, err := service.AddProduct(employeeCtx, form)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantOverCapacity, got.OverCapacity)
+		})
+	}
+}
+
+func TestReceptionService_AddProduct_Barcode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	pvzId := uuid.New()
+	reception := models.Reception{Id: uuid.New(), PvzId: pvzId, Status: models.InProgress}
+	form := forms.ProductForm{PvzId: pvzId, Type: "обувь", Barcode: "4600000000017", ExternalOrderId: "WB-1"}
+
+	t.Run("product is linked to order", func(t *testing.T) {
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), form.Type).Return(true, nil)
+		mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(reception, nil)
+		mockRepo.EXPECT().GetReceivedBarcodes(gomock.Any(), reception.Id, []string{form.Barcode}).Return([]string{}, nil)
+		mockRepo.EXPECT().GetPvzLoad(gomock.Any(), pvzId).Return(models.PvzLoad{}, nil)
+		mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any()).Return(nil)
+
+		product, err := service.AddProduct(employeeCtx, form)
+		assert.NoError(t, err)
+		assert.Equal(t, form.Barcode, product.Barcode)
+		assert.Equal(t, form.ExternalOrderId, product.ExternalOrderId)
+		assert.Equal(t, models.ProductStored, product.Status)
+	})
+
+	t.Run("barcode is already received", func(t *testing.T) {
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), form.Type).Return(true, nil)
+		mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(reception, nil)
+		mockRepo.EXPECT().GetReceivedBarcodes(gomock.Any(), reception.Id, []string{form.Barcode}).Return([]string{form.Barcode}, nil)
+
+		_, err := service.AddProduct(employeeCtx, form)
+		assert.ErrorIs(t, err, usecase.DuplicateBarcode)
+	})
+
+	t.Run("invalid barcode", func(t *testing.T) {
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+
+		_, err := service.AddProduct(employeeCtx, forms.ProductForm{PvzId: pvzId, Type: "обувь", Barcode: "460 000"})
+		assert.ErrorIs(t, err, usecase.InvalidProduct)
+	})
+}
+
 func TestReceptionService_RemoveProduct(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockReceptionRepository(ctrl)
-	service := usecase.NewReceptionService(mockRepo)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
 
 	pvzId := uuid.New()
 
@@ -141,6 +330,7 @@ func TestReceptionService_RemoveProduct(t *testing.T) {
 		{
 			name: "success",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{
 					Id: uuid.New(),
 				}, nil)
@@ -151,6 +341,7 @@ func TestReceptionService_RemoveProduct(t *testing.T) {
 		{
 			name: "reception not opened",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{}, nil)
 			},
 			wantErr: true,
@@ -158,6 +349,7 @@ func TestReceptionService_RemoveProduct(t *testing.T) {
 		{
 			name: "repository error",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{
 					Id: uuid.New(),
 				}, nil)
@@ -165,12 +357,19 @@ func TestReceptionService_RemoveProduct(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "employee is not assigned to pvz",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		tt.mock()
 		t.Run(tt.name, func(t *testing.T) {
-			err := service.RemoveProduct(context.Background(), pvzId)
+			err := service.RemoveProduct(employeeCtx, pvzId)
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
@@ -181,7 +380,8 @@ func TestReceptionService_CloseReception(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockReceptionRepository(ctrl)
-	service := usecase.NewReceptionService(mockRepo)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
 
 	receptionId := uuid.New()
 	dateTime := time.Now().Truncate(time.Millisecond)
@@ -197,13 +397,15 @@ func TestReceptionService_CloseReception(t *testing.T) {
 		{
 			name: "success",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{
 					Id:       receptionId,
 					DateTime: dateTime,
 					PvzId:    pvzId,
 					Status:   models.InProgress,
 				}, nil)
-				mockRepo.EXPECT().CloseReception(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetReceptionManifest(gomock.Any(), gomock.Any()).Return(models.Manifest{}, nil)
+				mockRepo.EXPECT().CloseReception(gomock.Any(), gomock.Any()).Return(true, nil)
 			},
 			want: models.Reception{
 				Id:       receptionId,
@@ -216,6 +418,7 @@ func TestReceptionService_CloseReception(t *testing.T) {
 		{
 			name: "reception not opened",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{}, nil)
 			},
 			want:    models.Reception{},
@@ -224,10 +427,37 @@ func TestReceptionService_CloseReception(t *testing.T) {
 		{
 			name: "repository error",
 			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
 				mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{
-					Id: uuid.New(),
+					Id:     uuid.New(),
+					PvzId:  pvzId,
+					Status: models.InProgress,
+				}, nil)
+				mockRepo.EXPECT().GetReceptionManifest(gomock.Any(), gomock.Any()).Return(models.Manifest{}, nil)
+				mockRepo.EXPECT().CloseReception(gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+			},
+			want:    models.Reception{},
+			wantErr: true,
+		},
+		{
+			name: "reception was cancelled while closing",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{
+					Id:     uuid.New(),
+					PvzId:  pvzId,
+					Status: models.InProgress,
 				}, nil)
-				mockRepo.EXPECT().CloseReception(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+				mockRepo.EXPECT().GetReceptionManifest(gomock.Any(), gomock.Any()).Return(models.Manifest{}, nil)
+				mockRepo.EXPECT().CloseReception(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			want:    models.Reception{},
+			wantErr: true,
+		},
+		{
+			name: "employee is not assigned to pvz",
+			mock: func() {
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
 			},
 			want:    models.Reception{},
 			wantErr: true,
@@ -237,9 +467,590 @@ func TestReceptionService_CloseReception(t *testing.T) {
 	for _, tt := range tests {
 		tt.mock()
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := service.CloseReception(context.Background(), pvzId)
+			got, err := service.CloseReception(employeeCtx, pvzId)
 			assert.Equal(t, tt.wantErr, err != nil)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestReceptionService_CloseReception_Manifest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	pvzId := uuid.New()
+	reception := models.Reception{Id: uuid.New(), PvzId: pvzId, Status: models.InProgress}
+	manifest := models.Manifest{
+		Id:    uuid.New(),
+		PvzId: pvzId,
+		Items: []models.ManifestItem{{Barcode: "A-1", ProductType: "обувь"}, {Barcode: "A-2", ProductType: "одежда"}},
+	}
+	products := []models.Product{{Id: uuid.New(), Barcode: "A-1", ProductType: "одежда", ReceptionId: reception.Id}}
+
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+	mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(reception, nil)
+	mockRepo.EXPECT().GetReceptionManifest(gomock.Any(), reception).Return(manifest, nil)
+	mockRepo.EXPECT().GetReception(gomock.Any(), reception.Id).Return(models.ReceptionProducts{Reception: reception, Products: products}, nil)
+	mockRepo.EXPECT().CloseReceptionWithReport(gomock.Any(), reception, manifest.Compare(reception.Id, products)).Return(true, nil)
+
+	got, err := service.CloseReception(employeeCtx, pvzId)
+	assert.NoError(t, err)
+	assert.Equal(t, reception, got)
+}
+
+func TestReceptionService_CloseStaleReceptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	stale := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.InProgress}
+	withManifest := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.InProgress}
+	failing := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.InProgress}
+	manifest := models.Manifest{Id: uuid.New(), PvzId: withManifest.PvzId, Items: []models.ManifestItem{{Barcode: "A-1", ProductType: "обувь"}}}
+
+	autoClosed := func(reception models.Reception) models.Reception {
+		reception.AutoClosed = true
+		return reception
+	}
+
+	mockRepo.EXPECT().ListStaleReceptions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, idleSince time.Time) ([]models.Reception, error) {
+			assert.WithinDuration(t, time.Now().Add(-12*time.Hour), idleSince, time.Minute)
+			return []models.Reception{stale, withManifest, failing}, nil
+		})
+	// закрытие пишется в журнал приёмки от имени системы
+	systemEntry := func(reception models.Reception, discrepancies string) func(context.Context, models.Reception, models.DiscrepancyReport, models.AuditEntry) (bool, error) {
+		return func(_ context.Context, _ models.Reception, _ models.DiscrepancyReport, entry models.AuditEntry) (bool, error) {
+			assert.Equal(t, models.SystemActorId, entry.ActorId)
+			assert.Equal(t, models.AuditReceptionAutoClose, entry.Action)
+			assert.Equal(t, reception.Id.String(), entry.TargetId)
+			assert.Equal(t, map[string]string{"pvzId": reception.PvzId.String(), "discrepancies": discrepancies}, entry.Details)
+			return true, nil
+		}
+	}
+
+	mockRepo.EXPECT().GetReceptionManifest(gomock.Any(), autoClosed(stale)).Return(models.Manifest{}, nil)
+	mockRepo.EXPECT().AutoCloseReception(gomock.Any(), autoClosed(stale), models.DiscrepancyReport{}, gomock.Any()).
+		DoAndReturn(systemEntry(stale, "0"))
+	mockRepo.EXPECT().GetReceptionManifest(gomock.Any(), autoClosed(withManifest)).Return(manifest, nil)
+	mockRepo.EXPECT().GetReception(gomock.Any(), withManifest.Id).Return(models.ReceptionProducts{Reception: withManifest}, nil)
+	mockRepo.EXPECT().AutoCloseReception(gomock.Any(), autoClosed(withManifest), manifest.Compare(withManifest.Id, nil), gomock.Any()).
+		DoAndReturn(systemEntry(withManifest, "1"))
+	mockRepo.EXPECT().GetReceptionManifest(gomock.Any(), autoClosed(failing)).Return(models.Manifest{}, nil)
+	mockRepo.EXPECT().AutoCloseReception(gomock.Any(), autoClosed(failing), models.DiscrepancyReport{}, gomock.Any()).Return(false, nil)
+
+	closed, err := service.CloseStaleReceptions(context.Background(), 12*time.Hour)
+	assert.ErrorIs(t, err, usecase.IllegalStatusTransition, "reception was cancelled before the job reached it")
+	assert.Equal(t, []models.Reception{autoClosed(stale), autoClosed(withManifest)}, closed)
+
+	mockRepo.EXPECT().ListStaleReceptions(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, err = service.CloseStaleReceptions(context.Background(), 12*time.Hour)
+	assert.Error(t, err)
+}
+
+func TestReceptionService_CancelReception(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	open := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.InProgress}
+	form := forms.CancelReceptionForm{Reason: "открыта по ошибке"}
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, open.PvzId).Return(true, nil)
+	mockRepo.EXPECT().ChangeReceptionStatus(gomock.Any(), open.Id, models.InProgress, models.Cancelled, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _, _ models.Status, entry models.AuditEntry) (bool, error) {
+			assert.Equal(t, employee.UserId, entry.ActorId)
+			assert.Equal(t, models.AuditReceptionCancel, entry.Action)
+			assert.Equal(t, form.Reason, entry.Details["reason"])
+			return true, nil
+		})
+
+	got, err := service.CancelReception(employeeCtx, open.Id, form)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Cancelled, got.Status)
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, open.PvzId).Return(true, nil)
+	mockRepo.EXPECT().ChangeReceptionStatus(gomock.Any(), open.Id, models.InProgress, models.Cancelled, gomock.Any()).Return(false, nil)
+	_, err = service.CancelReception(employeeCtx, open.Id, form)
+	assert.ErrorIs(t, err, usecase.IllegalStatusTransition, "reception was closed concurrently")
+
+	closed := models.Reception{Id: uuid.New(), PvzId: open.PvzId, Status: models.Closed}
+	mockRepo.EXPECT().GetReception(gomock.Any(), closed.Id).Return(models.ReceptionProducts{Reception: closed}, nil)
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, closed.PvzId).Return(true, nil)
+	_, err = service.CancelReception(employeeCtx, closed.Id, form)
+	var transitionErr *usecase.StatusTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, &usecase.StatusTransitionError{From: models.Closed, To: models.Cancelled}, transitionErr)
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, open.PvzId).Return(false, nil)
+	_, err = service.CancelReception(employeeCtx, open.Id, form)
+	assert.ErrorIs(t, err, usecase.ReceptionNotFound, "reception of another pvz looks like a missing one")
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, open.PvzId).Return(false, errors.New("db error"))
+	_, err = service.CancelReception(employeeCtx, open.Id, form)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, usecase.ReceptionNotFound)
+
+	_, err = service.CancelReception(employeeCtx, open.Id, forms.CancelReceptionForm{})
+	assert.ErrorIs(t, err, usecase.InvalidReason)
+}
+
+func TestReceptionService_VerifyReception(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	closed := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.Closed}
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), closed.Id).Return(models.ReceptionProducts{Reception: closed}, nil)
+	mockRepo.EXPECT().ChangeReceptionStatus(gomock.Any(), closed.Id, models.Closed, models.Verified, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _, _ models.Status, entry models.AuditEntry) (bool, error) {
+			assert.Equal(t, moderator.UserId, entry.ActorId)
+			assert.Equal(t, models.AuditReceptionVerify, entry.Action)
+			return true, nil
+		})
+
+	got, err := service.VerifyReception(moderatorCtx, closed.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Verified, got.Status)
+
+	for _, status := range []models.Status{models.InProgress, models.Cancelled, models.Verified} {
+		reception := models.Reception{Id: uuid.New(), PvzId: closed.PvzId, Status: status}
+		mockRepo.EXPECT().GetReception(gomock.Any(), reception.Id).Return(models.ReceptionProducts{Reception: reception}, nil)
+		_, err = service.VerifyReception(moderatorCtx, reception.Id)
+		assert.ErrorIs(t, err, usecase.IllegalStatusTransition, status)
+	}
+
+	missingId := uuid.New()
+	mockRepo.EXPECT().GetReception(gomock.Any(), missingId).Return(models.ReceptionProducts{}, nil)
+	_, err = service.VerifyReception(moderatorCtx, missingId)
+	assert.ErrorIs(t, err, usecase.ReceptionNotFound)
+}
+
+func TestReceptionService_ReplaceManifest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+	pvzId := uuid.New()
+
+	form := forms.ManifestForm{Items: []forms.ManifestItemForm{
+		{Barcode: "A-1", Type: "обувь"},
+		{Barcode: "A-2", Type: "обувь"},
+	}}
+
+	mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "обувь").Return(true, nil)
+	mockRepo.EXPECT().ReplaceManifest(gomock.Any(), gomock.Any()).Return(true, nil)
+
+	got, err := service.ReplaceManifest(employeeCtx, pvzId, form)
+	assert.NoError(t, err)
+	assert.Equal(t, pvzId, got.PvzId)
+	assert.NotEqual(t, uuid.Nil, got.Id)
+	assert.Equal(t, []models.ManifestItem{{Barcode: "A-1", ProductType: "обувь"}, {Barcode: "A-2", ProductType: "обувь"}}, got.Items)
+
+	mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "обувь").Return(true, nil)
+	mockRepo.EXPECT().ReplaceManifest(gomock.Any(), gomock.Any()).Return(false, nil)
+	_, err = service.ReplaceManifest(employeeCtx, pvzId, form)
+	assert.ErrorIs(t, err, usecase.PvzNotFound)
+
+	_, err = service.ReplaceManifest(employeeCtx, pvzId, forms.ManifestForm{})
+	assert.ErrorIs(t, err, usecase.InvalidManifest, "empty manifest")
+
+	_, err = service.ReplaceManifest(employeeCtx, pvzId, forms.ManifestForm{Items: []forms.ManifestItemForm{
+		{Barcode: "A-1", Type: "обувь"},
+		{Barcode: "A-1", Type: "одежда"},
+	}})
+	assert.ErrorIs(t, err, usecase.InvalidManifest, "repeated barcode")
+
+	_, err = service.ReplaceManifest(employeeCtx, pvzId, forms.ManifestForm{Items: []forms.ManifestItemForm{{Type: "обувь"}}})
+	assert.ErrorIs(t, err, usecase.InvalidManifest, "missing barcode")
+
+	mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "мебель").Return(false, nil)
+	_, err = service.ReplaceManifest(employeeCtx, pvzId, forms.ManifestForm{Items: []forms.ManifestItemForm{{Barcode: "A-1", Type: "мебель"}}})
+	assert.ErrorIs(t, err, usecase.InvalidManifest, "unknown category")
+}
+
+func TestReceptionService_GetDiscrepancyReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+	receptionId := uuid.New()
+
+	report := models.DiscrepancyReport{
+		ReceptionId: receptionId,
+		ManifestId:  uuid.New(),
+		Items:       []models.Discrepancy{{Kind: models.Shortage, Barcode: "A-1", ExpectedType: "обувь"}},
+	}
+	mockRepo.EXPECT().GetDiscrepancyReport(gomock.Any(), receptionId).Return(report, nil)
+
+	got, err := service.GetDiscrepancyReport(employeeCtx, receptionId)
+	assert.NoError(t, err)
+	assert.Equal(t, report, got)
+
+	mockRepo.EXPECT().GetDiscrepancyReport(gomock.Any(), receptionId).Return(models.DiscrepancyReport{}, nil)
+	_, err = service.GetDiscrepancyReport(employeeCtx, receptionId)
+	assert.ErrorIs(t, err, usecase.DiscrepancyReportNotFound)
+}
+
+func TestReceptionService_AccessDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+	pvzId := uuid.New()
+
+	_, err := service.CloseReception(context.Background(), pvzId)
+	assert.ErrorIs(t, err, usecase.PvzAccessDenied, "request without principal")
+
+	mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+	_, err = service.CreateReception(employeeCtx, forms.ReceptionForm{PvzId: pvzId})
+	assert.ErrorIs(t, err, usecase.PvzAccessDenied)
+}
+
+func TestReceptionService_GetReception(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	pvzId := uuid.New()
+	reception := models.ReceptionProducts{
+		Reception: models.Reception{Id: uuid.New(), PvzId: pvzId, Status: models.InProgress},
+		Products:  []models.Product{{Id: uuid.New(), ProductType: "обувь"}},
+	}
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), reception.Reception.Id).Return(reception, nil)
+	got, err := service.GetReception(context.Background(), reception.Reception.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, reception, got)
+
+	missingId := uuid.New()
+	mockRepo.EXPECT().GetReception(gomock.Any(), missingId).Return(models.ReceptionProducts{}, nil)
+	_, err = service.GetReception(context.Background(), missingId)
+	assert.ErrorIs(t, err, usecase.ReceptionNotFound)
+
+	mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(reception.Reception, nil)
+	mockRepo.EXPECT().GetReception(gomock.Any(), reception.Reception.Id).Return(reception, nil)
+	got, err = service.GetCurrentReception(context.Background(), pvzId)
+	assert.NoError(t, err)
+	assert.Equal(t, reception, got)
+
+	mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{}, nil)
+	_, err = service.GetCurrentReception(context.Background(), pvzId)
+	assert.ErrorIs(t, err, usecase.ReceptionNotOpened)
+}
+
+func TestReceptionService_RemoveProductById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	pvzId := uuid.New()
+	productId := uuid.New()
+	open := models.Reception{Id: uuid.New(), PvzId: pvzId, Status: models.InProgress}
+	closed := models.Reception{Id: uuid.New(), PvzId: pvzId, Status: models.Closed}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		id      uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "success",
+			id:   open.Id,
+			mock: func() {
+				mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().DeleteProduct(gomock.Any(), open.Id, productId).Return(true, nil)
+			},
+		},
+		{
+			name: "reception not found",
+			id:   open.Id,
+			mock: func() {
+				mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{}, nil)
+			},
+			wantErr: usecase.ReceptionNotFound,
+		},
+		{
+			// ответ тот же, что и для несуществующей приёмки: перебором нельзя узнать приёмки чужих ПВЗ
+			name: "employee is not assigned to pvz",
+			id:   open.Id,
+			mock: func() {
+				mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(false, nil)
+			},
+			wantErr: usecase.ReceptionNotFound,
+		},
+		{
+			name: "reception is closed",
+			id:   closed.Id,
+			mock: func() {
+				mockRepo.EXPECT().GetReception(gomock.Any(), closed.Id).Return(models.ReceptionProducts{Reception: closed}, nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+			},
+			wantErr: usecase.ReceptionNotOpened,
+		},
+		{
+			name: "product not in reception",
+			id:   open.Id,
+			mock: func() {
+				mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+				mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+				mockRepo.EXPECT().DeleteProduct(gomock.Any(), open.Id, productId).Return(false, nil)
+			},
+			wantErr: usecase.ProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			err := service.RemoveProductById(employeeCtx, tt.id, productId)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReceptionService_AddProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+
+	pvzId := uuid.New()
+	reception := models.Reception{Id: uuid.New(), PvzId: pvzId, Status: models.InProgress}
+	form := forms.BatchProductForm{
+		PvzId: pvzId,
+		Items: []forms.BatchProductItemForm{{Type: "обувь"}, {Type: "одежда"}, {Type: "обувь"}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+		service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		// каждая категория проверяется один раз
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "обувь").Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "одежда").Return(true, nil)
+		mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(reception, nil)
+		mockRepo.EXPECT().GetPvzLoad(gomock.Any(), pvzId).Return(models.PvzLoad{Pvz: models.Pvz{Capacity: 10}, OnHand: 8}, nil)
+		mockRepo.EXPECT().AddProducts(gomock.Any(), gomock.Len(3)).Return(nil)
+
+		products, err := service.AddProducts(employeeCtx, form)
+		assert.NoError(t, err)
+		assert.Len(t, products, 3)
+		assert.Equal(t, []bool{false, false, true}, []bool{products[0].OverCapacity, products[1].OverCapacity, products[2].OverCapacity})
+		assert.True(t, products[0].DateTime.Before(products[2].DateTime))
+		for _, product := range products {
+			assert.Equal(t, reception.Id, product.ReceptionId)
+		}
+	})
+
+	t.Run("inactive category rejects the whole batch", func(t *testing.T) {
+		mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+		service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "обувь").Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "одежда").Return(false, nil)
+
+		_, err := service.AddProducts(employeeCtx, form)
+		var itemsErr *usecase.BatchItemsError
+		assert.ErrorAs(t, err, &itemsErr)
+		assert.ErrorIs(t, err, usecase.InvalidBatch)
+		assert.Equal(t, map[int]error{1: usecase.CategoryNotAvailable}, itemsErr.Items)
+	})
+
+	t.Run("batch does not fit with reject policy", func(t *testing.T) {
+		mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+		service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityReject)
+
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+		mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(reception, nil)
+		mockRepo.EXPECT().GetPvzLoad(gomock.Any(), pvzId).Return(models.PvzLoad{Pvz: models.Pvz{Capacity: 10}, OnHand: 8}, nil)
+
+		_, err := service.AddProducts(employeeCtx, form)
+		assert.ErrorIs(t, err, usecase.PvzOverCapacity)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+		service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+		_, err := service.AddProducts(employeeCtx, forms.BatchProductForm{PvzId: pvzId})
+		assert.ErrorIs(t, err, usecase.InvalidBatch)
+	})
+
+	t.Run("no open reception", func(t *testing.T) {
+		mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+		service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+		mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(models.Reception{}, nil)
+
+		_, err := service.AddProducts(employeeCtx, form)
+		assert.ErrorIs(t, err, usecase.ReceptionNotOpened)
+	})
+
+	t.Run("barcode repeats inside batch", func(t *testing.T) {
+		mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+		service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "обувь").Return(true, nil)
+
+		_, err := service.AddProducts(employeeCtx, forms.BatchProductForm{
+			PvzId: pvzId,
+			Items: []forms.BatchProductItemForm{{Type: "обувь", Barcode: "4600000000017"}, {Type: "обувь"}, {Type: "обувь", Barcode: "4600000000017"}},
+		})
+		var itemsErr *usecase.BatchItemsError
+		assert.ErrorAs(t, err, &itemsErr)
+		assert.Len(t, itemsErr.Items, 1)
+		assert.ErrorIs(t, itemsErr.Items[2], usecase.DuplicateBarcode)
+	})
+
+	t.Run("barcode is already received", func(t *testing.T) {
+		mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+		service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+		mockAssignments.EXPECT().IsEmployeeAssigned(gomock.Any(), employee.UserId, pvzId).Return(true, nil)
+		mockRepo.EXPECT().IsCategoryActive(gomock.Any(), "обувь").Return(true, nil)
+		mockRepo.EXPECT().GetOpenReception(gomock.Any(), pvzId).Return(reception, nil)
+		mockRepo.EXPECT().GetReceivedBarcodes(gomock.Any(), reception.Id, gomock.Len(2)).Return([]string{"4600000000024"}, nil)
+
+		_, err := service.AddProducts(employeeCtx, forms.BatchProductForm{
+			PvzId: pvzId,
+			Items: []forms.BatchProductItemForm{{Type: "обувь", Barcode: "4600000000017"}, {Type: "обувь", Barcode: "4600000000024"}},
+		})
+		var itemsErr *usecase.BatchItemsError
+		assert.ErrorAs(t, err, &itemsErr)
+		assert.Equal(t, map[int]error{1: usecase.DuplicateBarcode}, itemsErr.Items)
+	})
+}
+
+func TestReceptionService_SearchProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	locations := []models.ProductLocation{{Product: models.Product{Barcode: "4600000000017"}, PvzId: uuid.New()}}
+	mockRepo.EXPECT().SearchProducts(gomock.Any(), "4600000000017").Return(locations, nil)
+
+	got, err := service.SearchProducts(employeeCtx, "4600000000017")
+	assert.NoError(t, err)
+	assert.Equal(t, locations, got)
+
+	_, err = service.SearchProducts(employeeCtx, "not a barcode")
+	assert.ErrorIs(t, err, usecase.InvalidProduct)
+}
+
+func TestReceptionService_ReopenReception(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	closed := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.Closed}
+	form := forms.ReopenReceptionForm{Reason: "закрыта раньше времени"}
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), closed.Id).Return(models.ReceptionProducts{Reception: closed}, nil)
+	mockRepo.EXPECT().ReopenReception(gomock.Any(), closed.Id, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, entry models.AuditEntry) (bool, error) {
+			assert.Equal(t, moderator.UserId, entry.ActorId)
+			assert.Equal(t, models.AuditReceptionReopen, entry.Action)
+			assert.Equal(t, closed.Id.String(), entry.TargetId)
+			assert.Equal(t, form.Reason, entry.Details["reason"])
+			return true, nil
+		})
+
+	got, err := service.ReopenReception(moderatorCtx, closed.Id, form)
+	assert.NoError(t, err)
+	assert.Equal(t, models.InProgress, got.Status)
+	assert.Equal(t, closed.Id, got.Id)
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), closed.Id).Return(models.ReceptionProducts{Reception: closed}, nil)
+	mockRepo.EXPECT().ReopenReception(gomock.Any(), closed.Id, gomock.Any()).Return(false, nil)
+	_, err = service.ReopenReception(moderatorCtx, closed.Id, form)
+	assert.ErrorIs(t, err, usecase.ReceptionNotReopenable, "pvz already has an open reception")
+
+	open := models.Reception{Id: uuid.New(), PvzId: closed.PvzId, Status: models.InProgress}
+	mockRepo.EXPECT().GetReception(gomock.Any(), open.Id).Return(models.ReceptionProducts{Reception: open}, nil)
+	_, err = service.ReopenReception(moderatorCtx, open.Id, form)
+	assert.ErrorIs(t, err, usecase.IllegalStatusTransition)
+
+	verified := models.Reception{Id: uuid.New(), PvzId: closed.PvzId, Status: models.Verified}
+	mockRepo.EXPECT().GetReception(gomock.Any(), verified.Id).Return(models.ReceptionProducts{Reception: verified}, nil)
+	_, err = service.ReopenReception(moderatorCtx, verified.Id, form)
+	assert.ErrorIs(t, err, usecase.IllegalStatusTransition)
+
+	missingId := uuid.New()
+	mockRepo.EXPECT().GetReception(gomock.Any(), missingId).Return(models.ReceptionProducts{}, nil)
+	_, err = service.ReopenReception(moderatorCtx, missingId, form)
+	assert.ErrorIs(t, err, usecase.ReceptionNotFound)
+
+	_, err = service.ReopenReception(moderatorCtx, closed.Id, forms.ReopenReceptionForm{})
+	assert.ErrorIs(t, err, usecase.InvalidReason)
+}
+
+func TestReceptionService_GetReceptionHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockAssignments := mocks.NewMockAssignmentRepository(ctrl)
+	service := usecase.NewReceptionService(mockRepo, usecase.NewPvzAccessChecker(mockAssignments, false), config.CapacityWarn)
+
+	reception := models.Reception{Id: uuid.New(), PvzId: uuid.New(), Status: models.InProgress}
+	events := []models.AuditEntry{{Id: uuid.New(), ActorId: moderator.UserId, Action: models.AuditReceptionReopen, TargetId: reception.Id.String()}}
+
+	mockRepo.EXPECT().GetReception(gomock.Any(), reception.Id).Return(models.ReceptionProducts{Reception: reception}, nil)
+	mockRepo.EXPECT().GetReceptionHistory(gomock.Any(), reception.Id).Return(events, nil)
+
+	got, err := service.GetReceptionHistory(employeeCtx, reception.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ReceptionHistory{Reception: reception, Events: events}, got)
+
+	missingId := uuid.New()
+	mockRepo.EXPECT().GetReception(gomock.Any(), missingId).Return(models.ReceptionProducts{}, nil)
+	_, err = service.GetReceptionHistory(employeeCtx, missingId)
+	assert.ErrorIs(t, err, usecase.ReceptionNotFound)
+}
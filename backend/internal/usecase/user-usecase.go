@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
+	"pvz/internal/utils"
+	"pvz/pkg/logger"
+)
+
+var CannotModifySelf = errors.New("moderator cannot modify own account")
+
+// UserAdminRepository меняет пользователя и пишет запись в журнал одной транзакцией,
+// отзывая refresh-токены пользователя. Если пользователя нет, возвращается пустой пользователь
+type UserAdminRepository interface {
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error)
+	UpdateUserStatus(ctx context.Context, userId string, status models.UserStatus, entry models.AuditEntry) (models.User, error)
+	UpdateUserRole(ctx context.Context, userId, role string, entry models.AuditEntry) (models.User, error)
+	ResetUserPassword(ctx context.Context, userId, password string, entry models.AuditEntry) (models.User, error)
+}
+
+type UserService struct {
+	userRepo UserAdminRepository
+	hasher   utils.PasswordHasher
+}
+
+func NewUserService(userRepo UserAdminRepository, hasher utils.PasswordHasher) *UserService {
+	return &UserService{
+		userRepo: userRepo,
+		hasher:   hasher,
+	}
+}
+
+func (u *UserService) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	return u.userRepo.ListUsers(ctx, filter)
+}
+
+// SetUserStatus деактивирует или возвращает пользователя. Токены деактивированного пользователя перестают действовать сразу
+func (u *UserService) SetUserStatus(ctx context.Context, userId string, status models.UserStatus) (models.User, error) {
+	action := models.AuditUserActivate
+	if status == models.UserDeactivated {
+		action = models.AuditUserDeactivate
+	}
+
+	entry, err := u.newAuditEntry(ctx, action, userId, nil)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return u.checkFound(u.userRepo.UpdateUserStatus(ctx, userId, status, entry))
+}
+
+// ChangeRole меняет роль пользователя. Выданные до смены токены со старой ролью перестают действовать
+func (u *UserService) ChangeRole(ctx context.Context, userId, role string) (models.User, error) {
+	entry, err := u.newAuditEntry(ctx, models.AuditUserRoleChange, userId, map[string]string{"role": role})
+	if err != nil {
+		return models.User{}, err
+	}
+
+	return u.checkFound(u.userRepo.UpdateUserRole(ctx, userId, role, entry))
+}
+
+// ResetPassword задаёт пользователю временный пароль и завершает его сессии. Пароль возвращается один раз
+func (u *UserService) ResetPassword(ctx context.Context, userId string) (string, error) {
+	entry, err := u.newAuditEntry(ctx, models.AuditUserPasswordReset, userId, nil)
+	if err != nil {
+		return "", err
+	}
+
+	password, err := utils.GenerateTemporaryPassword()
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error generating temporary password: %s", err.Error()))
+		return "", err
+	}
+
+	hashedPass, err := u.hasher.Hash(password)
+	if err != nil {
+		logger.Error(ctx, fmt.Sprintf("Error hashing password: %s", err.Error()))
+		return "", err
+	}
+
+	if _, err = u.checkFound(u.userRepo.ResetUserPassword(ctx, userId, hashedPass, entry)); err != nil {
+		return "", err
+	}
+
+	return password, nil
+}
+
+// newAuditEntry не даёт модератору менять собственный аккаунт, чтобы не остаться без модераторов
+func (u *UserService) newAuditEntry(ctx context.Context, action models.AuditAction, userId string, details map[string]string) (models.AuditEntry, error) {
+	principal, ok := utils.GetPrincipal(ctx)
+	if !ok {
+		logger.Error(ctx, "Principal is missing in context")
+		return models.AuditEntry{}, errors.New("principal is missing in context")
+	}
+
+	if principal.UserId == userId {
+		logger.Error(ctx, fmt.Sprintf("User %s tried to apply %s to own account", userId, action))
+		return models.AuditEntry{}, CannotModifySelf
+	}
+
+	if details == nil {
+		details = map[string]string{}
+	}
+
+	return models.AuditEntry{
+		Id:        uuid.New(),
+		ActorId:   principal.UserId,
+		Action:    action,
+		TargetId:  userId,
+		Details:   details,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (u *UserService) checkFound(user models.User, err error) (models.User, error) {
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if user.Id == "" {
+		return models.User{}, UserNotFound
+	}
+
+	return user, nil
+}
@@ -0,0 +1,154 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/models"
+	"pvz/internal/usecase"
+	"pvz/internal/usecase/mocks"
+	"pvz/internal/utils"
+)
+
+var (
+	moderator    = models.Principal{UserId: uuid.NewString(), Role: string(models.Moderator)}
+	moderatorCtx = utils.SetPrincipal(context.Background(), moderator)
+)
+
+// auditEntry проверяет, что запись журнала описывает действие модератора над нужным пользователем
+type auditEntry struct {
+	action   models.AuditAction
+	targetId string
+}
+
+func (a auditEntry) Matches(x interface{}) bool {
+	entry, ok := x.(models.AuditEntry)
+	if !ok {
+		return false
+	}
+
+	return entry.Id != uuid.Nil &&
+		entry.ActorId == moderator.UserId &&
+		entry.Action == a.action &&
+		entry.TargetId == a.targetId &&
+		!entry.CreatedAt.IsZero()
+}
+
+func (a auditEntry) String() string {
+	return "audit entry " + string(a.action) + " for " + a.targetId
+}
+
+func TestUserService_SetUserStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserAdminRepository(ctrl)
+	service := usecase.NewUserService(mockRepo, testHasher)
+
+	userId := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		userId  string
+		status  models.UserStatus
+		mock    func()
+		wantErr error
+	}{
+		{
+			name:   "deactivate",
+			userId: userId,
+			status: models.UserDeactivated,
+			mock: func() {
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), userId, models.UserDeactivated, auditEntry{models.AuditUserDeactivate, userId}).
+					Return(models.User{Id: userId, Status: models.UserDeactivated}, nil)
+			},
+		},
+		{
+			name:   "activate",
+			userId: userId,
+			status: models.UserActive,
+			mock: func() {
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), userId, models.UserActive, auditEntry{models.AuditUserActivate, userId}).
+					Return(models.User{Id: userId, Status: models.UserActive}, nil)
+			},
+		},
+		{
+			name:   "user not found",
+			userId: userId,
+			status: models.UserDeactivated,
+			mock: func() {
+				mockRepo.EXPECT().UpdateUserStatus(gomock.Any(), userId, models.UserDeactivated, gomock.Any()).
+					Return(models.User{}, nil)
+			},
+			wantErr: usecase.UserNotFound,
+		},
+		{
+			name:    "own account",
+			userId:  moderator.UserId,
+			status:  models.UserDeactivated,
+			mock:    func() {},
+			wantErr: usecase.CannotModifySelf,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.mock()
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.SetUserStatus(moderatorCtx, tt.userId, tt.status)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.status, got.Status)
+			}
+		})
+	}
+}
+
+func TestUserService_ChangeRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserAdminRepository(ctrl)
+	service := usecase.NewUserService(mockRepo, testHasher)
+
+	userId := uuid.NewString()
+	mockRepo.EXPECT().UpdateUserRole(gomock.Any(), userId, "moderator", auditEntry{models.AuditUserRoleChange, userId}).
+		Return(models.User{Id: userId, Role: "moderator"}, nil)
+
+	got, err := service.ChangeRole(moderatorCtx, userId, "moderator")
+	assert.NoError(t, err)
+	assert.Equal(t, "moderator", got.Role)
+}
+
+func TestUserService_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserAdminRepository(ctrl)
+	service := usecase.NewUserService(mockRepo, testHasher)
+
+	userId := uuid.NewString()
+
+	var storedHash string
+	mockRepo.EXPECT().ResetUserPassword(gomock.Any(), userId, gomock.Any(), auditEntry{models.AuditUserPasswordReset, userId}).
+		DoAndReturn(func(_ context.Context, _ string, password string, _ models.AuditEntry) (models.User, error) {
+			storedHash = password
+			return models.User{Id: userId}, nil
+		})
+
+	password, err := service.ResetPassword(moderatorCtx, userId)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, password)
+
+	isValid, err := utils.VerifyPassword(password, storedHash)
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+
+	mockRepo.EXPECT().ResetUserPassword(gomock.Any(), userId, gomock.Any(), gomock.Any()).Return(models.User{}, nil)
+
+	_, err = service.ResetPassword(moderatorCtx, userId)
+	assert.ErrorIs(t, err, usecase.UserNotFound)
+}
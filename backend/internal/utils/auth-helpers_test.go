@@ -35,41 +35,6 @@ func TestHashPassword(t *testing.T) {
 	}
 }
 
-func TestGenSalt(t *testing.T) {
-	tests := []struct {
-		name string
-	}{
-		{"test_salt_1"},
-		{"test_salt_2"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			salt := utils.GenSalt()
-
-			if len(salt) != 10 {
-				t.Errorf("expected salt of length 10, got %d", len(salt))
-			}
-
-			for _, char := range salt {
-				if !isValidSaltChar(char) {
-					t.Errorf("invalid character '%c' in salt", char)
-				}
-			}
-		})
-	}
-}
-
-func isValidSaltChar(c rune) bool {
-	const validChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	for _, validChar := range validChars {
-		if c == validChar {
-			return true
-		}
-	}
-	return false
-}
-
 func TestCheckPassword(t *testing.T) {
 	tests := []struct {
 		password     string
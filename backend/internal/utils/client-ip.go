@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIP возвращает адрес клиента из соединения. Заголовки X-Forwarded-For не учитываются:
+// сервис доступен напрямую, и клиент мог бы подставить в них любой адрес, обходя ограничения по IP
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
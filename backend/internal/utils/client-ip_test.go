@@ -0,0 +1,23 @@
+package utils_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"pvz/internal/utils"
+)
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest("POST", "/login", nil)
+	req.RemoteAddr = "10.0.0.7:53124"
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+
+	if ip := utils.ClientIP(req); ip != "10.0.0.7" {
+		t.Errorf("expected 10.0.0.7, got %s", ip)
+	}
+
+	req.RemoteAddr = "[::1]:8080"
+	if ip := utils.ClientIP(req); ip != "::1" {
+		t.Errorf("expected ::1, got %s", ip)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"pvz/internal/models"
 	"pvz/pkg/logger"
 )
 
@@ -13,3 +14,13 @@ func SetRequestId(ctx context.Context) context.Context {
 		logger.RequestID,
 		uuid.New().String())
 }
+
+func SetPrincipal(ctx context.Context, principal models.Principal) context.Context {
+	return context.WithValue(ctx, logger.PrincipalKey, principal)
+}
+
+func GetPrincipal(ctx context.Context) (models.Principal, bool) {
+	principal, ok := ctx.Value(logger.PrincipalKey).(models.Principal)
+
+	return principal, ok
+}
@@ -2,11 +2,13 @@ package utils_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"pvz/internal/models"
 	"pvz/internal/utils"
 	"pvz/pkg/logger"
 )
@@ -42,3 +44,16 @@ func TestSetRequestId(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPrincipal(t *testing.T) {
+	_, ok := utils.GetPrincipal(context.Background())
+	assert.False(t, ok, "Principal should be absent in empty context")
+
+	principal := models.Principal{UserId: uuid.NewString(), Email: "emp@example.com", Role: string(models.Employee)}
+	ctx := utils.SetPrincipal(context.Background(), principal)
+
+	got, ok := utils.GetPrincipal(ctx)
+	assert.True(t, ok, "Principal should be present in the context")
+	assert.Equal(t, principal, got)
+	assert.Equal(t, "employee:"+principal.UserId, ctx.Value(logger.PrincipalKey).(fmt.Stringer).String())
+}
@@ -0,0 +1,100 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pvz/internal/utils"
+)
+
+func TestPasswordHashers(t *testing.T) {
+	tests := []struct {
+		name   string
+		hasher utils.PasswordHasher
+		prefix string
+	}{
+		{
+			name:   "argon2id",
+			hasher: &utils.Argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+			prefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+		{
+			name:   "bcrypt",
+			hasher: &utils.BcryptHasher{Cost: 4},
+			prefix: "$2a$04$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.hasher.Hash("testPassword")
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(encoded, tt.prefix), encoded)
+			assert.False(t, utils.IsLegacyPasswordHash(encoded))
+
+			another, err := tt.hasher.Hash("testPassword")
+			assert.NoError(t, err)
+			assert.NotEqual(t, encoded, another, "salt should be random")
+
+			ok, err := tt.hasher.Verify("testPassword", encoded)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = tt.hasher.Verify("wrongPassword", encoded)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			ok, err = utils.VerifyPassword("testPassword", encoded)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			assert.False(t, tt.hasher.NeedsRehash(encoded))
+		})
+	}
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	weak := &utils.Argon2idHasher{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	strong := &utils.Argon2idHasher{Memory: 2048, Iterations: 2, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	bcryptHasher := &utils.BcryptHasher{Cost: 4}
+
+	argonHash, err := weak.Hash("testPassword")
+	assert.NoError(t, err)
+	bcryptHash, err := bcryptHasher.Hash("testPassword")
+	assert.NoError(t, err)
+
+	assert.True(t, strong.NeedsRehash(argonHash), "parameters changed")
+	assert.True(t, strong.NeedsRehash(bcryptHash), "algorithm changed")
+	assert.True(t, (&utils.BcryptHasher{Cost: 5}).NeedsRehash(bcryptHash), "cost changed")
+	assert.True(t, bcryptHasher.NeedsRehash(argonHash), "algorithm changed")
+	assert.True(t, weak.NeedsRehash(utils.HashPassword("testPassword", "salt")), "legacy hash")
+}
+
+func TestVerifyPassword_UnknownFormat(t *testing.T) {
+	tests := []string{
+		utils.HashPassword("testPassword", "randomSalt"),
+		"$argon2id$v=19$broken",
+		"$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$aGFzaA",
+		"$unknown$hash",
+	}
+
+	for _, encoded := range tests {
+		t.Run(encoded, func(t *testing.T) {
+			ok, err := utils.VerifyPassword("testPassword", encoded)
+			assert.Error(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestGenerateTemporaryPassword(t *testing.T) {
+	first, err := utils.GenerateTemporaryPassword()
+	assert.NoError(t, err)
+	assert.Len(t, first, 16)
+
+	second, err := utils.GenerateTemporaryPassword()
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
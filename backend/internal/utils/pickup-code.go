@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+const (
+	pickupCodeLength = 8
+	// без похожих друг на друга символов (0/O, 1/I), чтобы код было удобно продиктовать
+	pickupCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+func GeneratePickupCode() (string, error) {
+	alphabetLen := big.NewInt(int64(len(pickupCodeAlphabet)))
+
+	code := make([]byte, pickupCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", err
+		}
+		code[i] = pickupCodeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// HashPickupCode нормализует введённый код, поэтому регистр и пробелы при сканировании не важны
+func HashPickupCode(code string) string {
+	hash := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(code))))
+
+	return hex.EncodeToString(hash[:])
+}
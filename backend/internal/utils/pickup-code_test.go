@@ -0,0 +1,33 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"pvz/internal/utils"
+)
+
+func TestGeneratePickupCode(t *testing.T) {
+	code, err := utils.GeneratePickupCode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(code) != 8 {
+		t.Errorf("expected code of length 8, got %q", code)
+	}
+
+	if strings.ContainsAny(code, "01IO") {
+		t.Errorf("code contains ambiguous characters: %q", code)
+	}
+}
+
+func TestHashPickupCode(t *testing.T) {
+	if utils.HashPickupCode("ABCD2345") != utils.HashPickupCode(" abcd2345\n") {
+		t.Error("expected hash to ignore case and surrounding spaces")
+	}
+
+	if utils.HashPickupCode("ABCD2345") == utils.HashPickupCode("ABCD2346") {
+		t.Error("expected different codes to have different hashes")
+	}
+}
@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"pvz/internal/models"
+)
+
+var UnknownTokenKey = errors.New("unknown token key")
+
+// TokenKey - ключ подписи токенов. У ключей, оставленных только для проверки на время ротации, нет signKey
+type TokenKey struct {
+	Id        string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+type TokenKeySet struct {
+	active *TokenKey
+	keys   map[string]*TokenKey
+}
+
+var (
+	tokenKeysMu sync.RWMutex
+	tokenKeys   = NewHMACKeySet(JwtSecret)
+)
+
+func SetTokenKeys(keys *TokenKeySet) {
+	tokenKeysMu.Lock()
+	defer tokenKeysMu.Unlock()
+
+	tokenKeys = keys
+}
+
+func CurrentTokenKeys() *TokenKeySet {
+	tokenKeysMu.RLock()
+	defer tokenKeysMu.RUnlock()
+
+	return tokenKeys
+}
+
+// NewHMACKeySet - обратная совместимость с общим секретом JWT_SECRET, такие токены подписываются без kid
+func NewHMACKeySet(secret string) *TokenKeySet {
+	key := &TokenKey{
+		Method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+
+	return &TokenKeySet{
+		active: key,
+		keys:   map[string]*TokenKey{"": key},
+	}
+}
+
+// LoadTokenKeys читает из dir ключи вида <kid>.pem. Приватные ключи (RSA или Ed25519) могут подписывать,
+// публичные ключи только проверяют токены, выпущенные до ротации. Подписывает ключ activeKeyId
+func LoadTokenKeys(dir, activeKeyId string) (*TokenKeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no *.pem keys found in %s", dir)
+	}
+
+	set := &TokenKeySet{keys: make(map[string]*TokenKey, len(files))}
+	for _, file := range files {
+		keyId := strings.TrimSuffix(filepath.Base(file), ".pem")
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := parseTokenKey(keyId, data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load key %s: %w", file, err)
+		}
+
+		set.keys[keyId] = key
+	}
+
+	active, ok := set.keys[activeKeyId]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found in %s", activeKeyId, dir)
+	}
+
+	if active.signKey == nil {
+		return nil, fmt.Errorf("active key %q is not a private key", activeKeyId)
+	}
+
+	set.active = active
+	return set, nil
+}
+
+func parseTokenKey(keyId string, data []byte) (*TokenKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &TokenKey{Id: keyId}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodRS256, k, k.Public()
+	case ed25519.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case *rsa.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodRS256, k
+	case ed25519.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", parsed)
+	}
+
+	return key, nil
+}
+
+func (s *TokenKeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.active.Method, claims)
+	if s.active.Id != "" {
+		token.Header["kid"] = s.active.Id
+	}
+
+	return token.SignedString(s.active.signKey)
+}
+
+// keyFunc выбирает ключ по kid и не даёт подменить алгоритм: метод токена обязан совпадать с методом ключа
+func (s *TokenKeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	keyId, _ := token.Header["kid"].(string)
+
+	key, ok := s.keys[keyId]
+	if !ok {
+		return nil, UnknownTokenKey
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), keyId)
+	}
+
+	return key.verifyKey, nil
+}
+
+func (s *TokenKeySet) validMethods() []string {
+	methods := make(map[string]struct{})
+	for _, key := range s.keys {
+		methods[key.Method.Alg()] = struct{}{}
+	}
+
+	res := make([]string, 0, len(methods))
+	for method := range methods {
+		res = append(res, method)
+	}
+
+	return res
+}
+
+// JWKS возвращает публичные ключи набора. Общий HMAC-секрет не публикуется
+func (s *TokenKeySet) JWKS() models.JSONWebKeySet {
+	keyIds := make([]string, 0, len(s.keys))
+	for keyId := range s.keys {
+		keyIds = append(keyIds, keyId)
+	}
+	sort.Strings(keyIds)
+
+	set := models.JSONWebKeySet{Keys: []models.JSONWebKey{}}
+	for _, keyId := range keyIds {
+		if jwk, ok := toJSONWebKey(s.keys[keyId]); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+
+	return set
+}
+
+func toJSONWebKey(key *TokenKey) (models.JSONWebKey, bool) {
+	jwk := models.JSONWebKey{
+		Use: "sig",
+		Kid: key.Id,
+		Alg: key.Method.Alg(),
+	}
+
+	switch k := key.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	default:
+		return models.JSONWebKey{}, false
+	}
+
+	return jwk, true
+}
@@ -0,0 +1,192 @@
+package utils_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pvz/internal/models"
+	"pvz/internal/utils"
+)
+
+func writePrivateKey(t *testing.T, dir, keyId string, key interface{}) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, keyId+".pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+}
+
+func writePublicKey(t *testing.T, dir, keyId string, key interface{}) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, keyId+".pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+}
+
+func useTokenKeys(t *testing.T, keys *utils.TokenKeySet) {
+	original := utils.CurrentTokenKeys()
+	utils.SetTokenKeys(keys)
+	t.Cleanup(func() { utils.SetTokenKeys(original) })
+}
+
+func TestTokenKeys_SignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		key    interface{}
+		method string
+	}{
+		{name: "RS256", key: rsaKey, method: "RS256"},
+		{name: "EdDSA", key: edKey, method: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writePrivateKey(t, dir, "key-1", tt.key)
+
+			keys, err := utils.LoadTokenKeys(dir, "key-1")
+			require.NoError(t, err)
+			useTokenKeys(t, keys)
+
+			tokenString, issued, err := utils.GenerateToken(models.Principal{UserId: "user", Role: string(models.Employee)})
+			require.NoError(t, err)
+
+			token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, "key-1", token.Header["kid"])
+			assert.Equal(t, tt.method, token.Method.Alg())
+
+			principal, err := utils.ParseToken(tokenString)
+			require.NoError(t, err)
+			assert.Equal(t, issued.TokenId, principal.TokenId)
+			assert.Equal(t, "user", principal.UserId)
+		})
+	}
+}
+
+func TestTokenKeys_Rotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	oldDir := t.TempDir()
+	writePrivateKey(t, oldDir, "old", oldKey)
+	oldKeys, err := utils.LoadTokenKeys(oldDir, "old")
+	require.NoError(t, err)
+	useTokenKeys(t, oldKeys)
+
+	oldToken, _, err := utils.GenerateToken(models.Principal{UserId: "user", Role: string(models.Moderator)})
+	require.NoError(t, err)
+
+	// после ротации старый ключ остаётся только публичным
+	rotatedDir := t.TempDir()
+	writePublicKey(t, rotatedDir, "old", &oldKey.PublicKey)
+	writePrivateKey(t, rotatedDir, "new", newKey)
+	rotatedKeys, err := utils.LoadTokenKeys(rotatedDir, "new")
+	require.NoError(t, err)
+	utils.SetTokenKeys(rotatedKeys)
+
+	_, err = utils.ParseToken(oldToken)
+	assert.NoError(t, err, "tokens signed before rotation should stay valid")
+
+	newToken, _, err := utils.GenerateToken(models.Principal{UserId: "user", Role: string(models.Moderator)})
+	require.NoError(t, err)
+	_, err = utils.ParseToken(newToken)
+	assert.NoError(t, err)
+
+	// когда старый ключ удалён, его токены больше не принимаются
+	utils.SetTokenKeys(oldKeys)
+	_, err = utils.ParseToken(newToken)
+	assert.Error(t, err)
+
+	jwks := rotatedKeys.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "new", jwks.Keys[0].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(newKey.Public().(ed25519.PublicKey)), jwks.Keys[0].X)
+	assert.Equal(t, "old", jwks.Keys[1].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+	assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+	assert.Equal(t, "AQAB", jwks.Keys[1].E)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(oldKey.N.Bytes()), jwks.Keys[1].N)
+}
+
+func TestTokenKeys_RejectsForeignTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writePrivateKey(t, dir, "key-1", rsaKey)
+	keys, err := utils.LoadTokenKeys(dir, "key-1")
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{"sub": "user", "jti": "jti", "role": "moderator", "exp": float64(4102444800)}
+
+	// токен на общем секрете больше не принимается
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(utils.JwtSecret))
+	require.NoError(t, err)
+
+	// подмена алгоритма: HS256, подписанный публичным ключом, с kid настоящего ключа
+	publicDer, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	confused.Header["kid"] = "key-1"
+	confusedToken, err := confused.SignedString(publicDer)
+	require.NoError(t, err)
+
+	useTokenKeys(t, keys)
+	for _, tokenString := range []string{hmacToken, confusedToken} {
+		_, err = utils.ParseToken(tokenString)
+		assert.Error(t, err)
+	}
+}
+
+func TestLoadTokenKeys_Errors(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	emptyDir := t.TempDir()
+
+	publicOnlyDir := t.TempDir()
+	writePublicKey(t, publicOnlyDir, "key-1", &rsaKey.PublicKey)
+
+	brokenDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(brokenDir, "key-1.pem"), []byte("not a key"), 0600))
+
+	tests := []struct {
+		name        string
+		dir         string
+		activeKeyId string
+	}{
+		{name: "no keys", dir: emptyDir, activeKeyId: "key-1"},
+		{name: "active key is public", dir: publicOnlyDir, activeKeyId: "key-1"},
+		{name: "unknown active key", dir: publicOnlyDir, activeKeyId: "key-2"},
+		{name: "broken key", dir: brokenDir, activeKeyId: "key-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := utils.LoadTokenKeys(tt.dir, tt.activeKeyId)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHMACKeySet_JWKS(t *testing.T) {
+	assert.Empty(t, utils.NewHMACKeySet("secret").JWKS().Keys, "shared secret must never be published")
+}
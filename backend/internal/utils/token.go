@@ -1,47 +1,117 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"pvz/internal/models"
 )
 
-var JwtSecret = GetEnv("JWT_SECRET", "secret")
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
 
-func GenerateToken(role string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"role":        role,
-		"expire_date": time.Now().Add(24 * time.Hour).Unix(),
-	})
+	refreshTokenBytes = 32
+)
 
-	tokenString, err := token.SignedString([]byte(JwtSecret))
+var JwtSecret = GetEnv("JWT_SECRET", "secret")
 
-	return tokenString, err
+// GenerateToken подписывает access-токен для principal, заполняя идентификатор токена и время истечения.
+// Время выпуска и истечения передаются в стандартных claims iat и exp (RFC 7519)
+func GenerateToken(principal models.Principal) (string, models.Principal, error) {
+	issuedAt := time.Now()
+	principal.TokenId = uuid.NewString()
+	principal.IssuedAt = issuedAt.Truncate(time.Second)
+	principal.ExpiresAt = issuedAt.Add(AccessTokenTTL)
 
+	tokenString, err := CurrentTokenKeys().sign(jwt.MapClaims{
+		"sub":   principal.UserId,
+		"email": principal.Email,
+		"jti":   principal.TokenId,
+		"role":  principal.Role,
+		"iat":   issuedAt.Unix(),
+		"exp":   principal.ExpiresAt.Unix(),
+		"dummy": principal.Dummy,
+	})
+	if err != nil {
+		return "", models.Principal{}, err
+	}
+
+	return tokenString, principal, nil
 }
 
-var GetRole = func(tokenString string) (string, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(JwtSecret), nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+var ParseToken = func(tokenString string) (models.Principal, error) {
+	keys := CurrentTokenKeys()
+	// exp и iat проверяет сам парсер: просроченный токен или токен без exp отклоняются
+	token, err := jwt.Parse(tokenString, keys.keyFunc, jwt.WithValidMethods(keys.validMethods()),
+		jwt.WithExpirationRequired(), jwt.WithIssuedAt())
 	if err != nil {
-		return "", err
+		return models.Principal{}, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", errors.New("token claims error")
+		return models.Principal{}, errors.New("token claims error")
 	}
 
-	expireFloat, ok := claims["expire_date"].(float64)
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return models.Principal{}, errors.New("invalid exp format")
+	}
+
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return models.Principal{}, errors.New("invalid iat format")
+	}
+
+	tokenId, ok := claims["jti"].(string)
+	if !ok || tokenId == "" {
+		return models.Principal{}, errors.New("invalid jti format")
+	}
+
+	userId, ok := claims["sub"].(string)
+	if !ok || userId == "" {
+		return models.Principal{}, errors.New("invalid sub format")
+	}
+
+	role, ok := claims["role"].(string)
 	if !ok {
-		return "", errors.New("invalid expire_date format")
+		return models.Principal{}, errors.New("invalid role format")
 	}
 
-	if time.Now().After(time.Unix(int64(expireFloat), 0)) {
-		return "", errors.New("token expired")
+	email, _ := claims["email"].(string)
+	dummy, _ := claims["dummy"].(bool)
+
+	return models.Principal{
+		UserId:    userId,
+		Email:     email,
+		Role:      role,
+		TokenId:   tokenId,
+		IssuedAt:  issuedAt.Time,
+		ExpiresAt: expiresAt.Time,
+		Dummy:     dummy,
+	}, nil
+}
+
+// GenerateRefreshToken возвращает непрозрачный refresh-токен, в БД хранится только его хэш
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
 
-	return claims["role"].(string), nil
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func HashRefreshToken(refreshToken string) string {
+	hash := sha256.Sum256([]byte(refreshToken))
+
+	return hex.EncodeToString(hash[:])
 }
@@ -1,6 +1,7 @@
 package utils_test
 
 import (
+	"pvz/internal/models"
 	"pvz/internal/utils"
 	"testing"
 	"time"
@@ -8,7 +9,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func TestGenerateAndGetRole(t *testing.T) {
+func TestGenerateAndParseToken(t *testing.T) {
 	tests := []struct {
 		name         string
 		role         string
@@ -35,8 +36,69 @@ func TestGenerateAndGetRole(t *testing.T) {
 			modifyToken: func(_ string) string {
 				// Генерируем вручную токен с истекшей датой
 				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"sub":  "user",
+					"jti":  "expired",
+					"role": "manager",
+					"exp":  time.Now().Add(-time.Hour).Unix(),
+				})
+				signed, _ := token.SignedString([]byte(utils.JwtSecret))
+				return signed
+			},
+			shouldError: true,
+		},
+		{
+			name: "Token without sub",
+			role: "manager",
+			modifyToken: func(_ string) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"jti":  "jti",
+					"role": "manager",
+					"exp":  time.Now().Add(time.Hour).Unix(),
+				})
+				signed, _ := token.SignedString([]byte(utils.JwtSecret))
+				return signed
+			},
+			shouldError: true,
+		},
+		{
+			name: "Token without jti",
+			role: "manager",
+			modifyToken: func(_ string) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"sub":  "user",
+					"role": "manager",
+					"exp":  time.Now().Add(time.Hour).Unix(),
+				})
+				signed, _ := token.SignedString([]byte(utils.JwtSecret))
+				return signed
+			},
+			shouldError: true,
+		},
+		{
+			name: "Token with legacy expire_date instead of exp",
+			role: "manager",
+			modifyToken: func(_ string) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"sub":         "user",
+					"jti":         "jti",
 					"role":        "manager",
-					"expire_date": time.Now().Add(-time.Hour).Unix(),
+					"expire_date": time.Now().Add(time.Hour).Unix(),
+				})
+				signed, _ := token.SignedString([]byte(utils.JwtSecret))
+				return signed
+			},
+			shouldError: true,
+		},
+		{
+			name: "Token issued in the future",
+			role: "manager",
+			modifyToken: func(_ string) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"sub":  "user",
+					"jti":  "jti",
+					"role": "manager",
+					"iat":  time.Now().Add(time.Hour).Unix(),
+					"exp":  time.Now().Add(2 * time.Hour).Unix(),
 				})
 				signed, _ := token.SignedString([]byte(utils.JwtSecret))
 				return signed
@@ -47,14 +109,18 @@ func TestGenerateAndGetRole(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := utils.GenerateToken(tt.role)
+			token, generated, err := utils.GenerateToken(models.Principal{
+				UserId: "user",
+				Email:  "user@example.com",
+				Role:   tt.role,
+			})
 			if err != nil {
 				t.Fatalf("unexpected error during token generation: %v", err)
 			}
 
 			modifiedToken := tt.modifyToken(token)
 
-			role, err := utils.GetRole(modifiedToken)
+			principal, err := utils.ParseToken(modifiedToken)
 			if tt.shouldError {
 				if err == nil {
 					t.Errorf("expected error, got none")
@@ -63,10 +129,97 @@ func TestGenerateAndGetRole(t *testing.T) {
 				if err != nil {
 					t.Errorf("did not expect error, got: %v", err)
 				}
-				if role != tt.expectedRole {
-					t.Errorf("expected role %q, got %q", tt.expectedRole, role)
+				if principal.Role != tt.expectedRole {
+					t.Errorf("expected role %q, got %q", tt.expectedRole, principal.Role)
+				}
+				if principal.TokenId != generated.TokenId {
+					t.Errorf("expected jti %q, got %q", generated.TokenId, principal.TokenId)
+				}
+				if principal.UserId != "user" || principal.Email != "user@example.com" {
+					t.Errorf("expected identity user/user@example.com, got %s/%s", principal.UserId, principal.Email)
+				}
+				if principal.Dummy {
+					t.Errorf("expected regular token, got dummy")
 				}
 			}
 		})
 	}
 }
+
+func TestGenerateAndParseDummyToken(t *testing.T) {
+	token, _, err := utils.GenerateToken(models.Principal{UserId: "user", Role: "employee", Dummy: true})
+	if err != nil {
+		t.Fatalf("unexpected error during token generation: %v", err)
+	}
+
+	principal, err := utils.ParseToken(token)
+	if err != nil {
+		t.Fatalf("did not expect error, got: %v", err)
+	}
+	if !principal.Dummy {
+		t.Errorf("expected dummy flag to survive round trip")
+	}
+}
+
+func TestGenerateTokenRegisteredClaims(t *testing.T) {
+	token, generated, err := utils.GenerateToken(models.Principal{UserId: "user", Role: "employee"})
+	if err != nil {
+		t.Fatalf("unexpected error during token generation: %v", err)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err = jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		t.Fatalf("unexpected error during token parsing: %v", err)
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		t.Fatalf("expected exp claim, got %v (%v)", exp, err)
+	}
+	if exp.Unix() != generated.ExpiresAt.Unix() {
+		t.Errorf("expected exp %d, got %d", generated.ExpiresAt.Unix(), exp.Unix())
+	}
+
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat == nil {
+		t.Fatalf("expected iat claim, got %v (%v)", iat, err)
+	}
+	if got := exp.Sub(iat.Time); got != utils.AccessTokenTTL {
+		t.Errorf("expected exp - iat = %s, got %s", utils.AccessTokenTTL, got)
+	}
+	if !generated.IssuedAt.Equal(iat.Time) {
+		t.Errorf("expected iat %s, got %s", generated.IssuedAt, iat.Time)
+	}
+
+	parsed, err := utils.ParseToken(token)
+	if err != nil {
+		t.Fatalf("unexpected error during token parsing: %v", err)
+	}
+	if !parsed.IssuedAt.Equal(generated.IssuedAt) {
+		t.Errorf("expected parsed iat %s, got %s", generated.IssuedAt, parsed.IssuedAt)
+	}
+}
+
+func TestGenerateRefreshToken(t *testing.T) {
+	first, err := utils.GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, err := utils.GenerateRefreshToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first == "" || first == second {
+		t.Errorf("expected two different non-empty tokens, got %q and %q", first, second)
+	}
+
+	if utils.HashRefreshToken(first) != utils.HashRefreshToken(first) {
+		t.Errorf("expected hash to be deterministic")
+	}
+
+	if utils.HashRefreshToken(first) == first {
+		t.Errorf("expected hash to differ from the raw token")
+	}
+}
@@ -3,21 +3,38 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"pvz/internal/models"
 )
 
-var allowedCities = []string{"Москва", "Санкт-Петербург", "Казань"}
-var allowedTypes = []string{"электроника", "одежда", "обувь"}
+const (
+	maxCityNameLength       = 100
+	maxCategoryNameLength   = 100
+	defaultCategoryLanguage = "ru"
+	maxAddressLength        = 300
+	maxOpeningHoursLength   = 100
+	maxExceptionDescription = 200
+	maxPvzCapacity          = 1000000
+	maxExternalOrderId      = 100
+	maxReasonLength         = 500
+)
 
-func ValidateRole(role string) bool {
-	if role != string(models.Moderator) && role != string(models.Client) && role != string(models.Employee) {
-		return false
-	}
+var (
+	// phoneRegex - номер в формате E.164
+	phoneRegex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	clockRegex = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`)
+	// barcodeRegex - цифры EAN/UPC или буквенно-цифровой артикул
+	barcodeRegex = regexp.MustCompile(`^[0-9A-Za-z._-]{1,64}$`)
+)
 
-	return true
+// ValidateRole допускает любую роль, описанную в политике прав
+func ValidateRole(policy *models.Policy, role string) bool {
+	return policy.HasRole(role)
 }
 
 func ValidateEmail(email string) bool {
@@ -28,35 +45,185 @@ func ValidateEmail(email string) bool {
 	return re.MatchString(email)
 }
 
-func ValidateAll(email string, role string) error {
+func ValidateAll(policy *models.Policy, email string, role string) error {
 	if !ValidateEmail(email) {
 		return fmt.Errorf("email %s is not valid", email)
 	}
-	if !ValidateRole(role) {
+	if !ValidateRole(policy, role) {
 		return fmt.Errorf("role %s is not valid", role)
 	}
 
 	return nil
 }
 
-func ValidateCity(city string) error {
-	for _, valid := range allowedCities {
-		if city == valid {
-			return nil
+// ValidateCityName проверяет только форму названия, наличие города определяется справочником
+func ValidateCityName(name string) error {
+	return validateName("city name", name, maxCityNameLength)
+}
+
+func ValidateCategoryCode(code string) error {
+	return validateName("category code", code, maxCategoryNameLength)
+}
+
+// ValidateCategoryNames требует русское название, названия на других языках необязательны
+func ValidateCategoryNames(names map[string]string) error {
+	if _, ok := names[defaultCategoryLanguage]; !ok {
+		return fmt.Errorf("category name in %q is required", defaultCategoryLanguage)
+	}
+
+	for lang, name := range names {
+		if err := validateName(fmt.Sprintf("category name in %q", lang), name, maxCategoryNameLength); err != nil {
+			return err
 		}
 	}
 
-	return errors.New("invalid city")
+	return nil
+}
+
+func ValidateLocation(latitude, longitude float64) error {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return errors.New("latitude must be between -90 and 90")
+	}
+
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return errors.New("longitude must be between -180 and 180")
+	}
+
+	return nil
+}
+
+// ValidateAddress допускает пустой адрес, если он ещё не известен
+func ValidateAddress(address string) error {
+	if address == "" {
+		return nil
+	}
+
+	return validateName("address", address, maxAddressLength)
+}
+
+func ValidateOpeningHours(openingHours string) error {
+	if openingHours == "" {
+		return nil
+	}
+
+	return validateName("opening hours", openingHours, maxOpeningHoursLength)
+}
+
+func ValidatePhone(phone string) error {
+	if phone != "" && !phoneRegex.MatchString(phone) {
+		return fmt.Errorf("phone %s must be in E.164 format", phone)
+	}
+
+	return nil
+}
+
+// ValidateCapacity - нулевая вместимость означает, что ПВЗ не ограничен
+func ValidateCapacity(capacity int) error {
+	if capacity < 0 || capacity > maxPvzCapacity {
+		return fmt.Errorf("capacity must be between 0 and %d", maxPvzCapacity)
+	}
+
+	return nil
+}
+
+func ValidateBarcode(barcode string) error {
+	if !barcodeRegex.MatchString(barcode) {
+		return fmt.Errorf("barcode %q must be 1 to 64 latin letters, digits, dots, dashes or underscores", barcode)
+	}
+
+	return nil
 }
 
-func ValidateProductType(productType string) error {
-	for _, valid := range allowedTypes {
-		if productType == valid {
-			return nil
+// ValidateExternalOrderId допускает пустой номер для товаров без заказа
+func ValidateExternalOrderId(orderId string) error {
+	if orderId == "" {
+		return nil
+	}
+
+	return validateName("external order id", orderId, maxExternalOrderId)
+}
+
+// ValidateReason проверяет причину действия модератора, она попадает в журнал
+func ValidateReason(reason string) error {
+	return validateName("reason", reason, maxReasonLength)
+}
+
+// ValidateSchedule проверяет часовой пояс, дни недели без повторов и интервалы работы, в том числе ночные
+func ValidateSchedule(schedule models.PvzSchedule) error {
+	if schedule.Timezone == "" {
+		return errors.New("timezone is required")
+	}
+
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %s", schedule.Timezone)
+	}
+
+	weekdays := make(map[int]struct{}, len(schedule.Week))
+	for _, hours := range schedule.Week {
+		if hours.Weekday < 1 || hours.Weekday > 7 {
+			return fmt.Errorf("weekday %d must be between 1 and 7", hours.Weekday)
+		}
+
+		if _, ok := weekdays[hours.Weekday]; ok {
+			return fmt.Errorf("weekday %d is given twice", hours.Weekday)
+		}
+		weekdays[hours.Weekday] = struct{}{}
+
+		if err := validateWorkingInterval(hours.OpensAt, hours.ClosesAt); err != nil {
+			return fmt.Errorf("weekday %d: %v", hours.Weekday, err)
 		}
 	}
 
-	return errors.New("invalid product type")
+	dates := make(map[string]struct{}, len(schedule.Exceptions))
+	for _, exception := range schedule.Exceptions {
+		date := exception.Date.Format(models.DateLayout)
+		if _, ok := dates[date]; ok {
+			return fmt.Errorf("date %s is given twice", date)
+		}
+		dates[date] = struct{}{}
+
+		if utf8.RuneCountInString(exception.Description) > maxExceptionDescription {
+			return fmt.Errorf("date %s: description must be at most %d characters", date, maxExceptionDescription)
+		}
+
+		if exception.IsClosed() && exception.ClosesAt == "" {
+			continue
+		}
+
+		if err := validateWorkingInterval(exception.OpensAt, exception.ClosesAt); err != nil {
+			return fmt.Errorf("date %s: %v", date, err)
+		}
+	}
+
+	return nil
+}
+
+func validateWorkingInterval(opensAt, closesAt string) error {
+	if !clockRegex.MatchString(opensAt) || !clockRegex.MatchString(closesAt) {
+		return errors.New("opening and closing time must be in HH:MM format")
+	}
+
+	opens, _ := models.ParseClock(opensAt)
+	closes, _ := models.ParseClock(closesAt)
+
+	// закрытие раньше открытия - ночная смена до следующего дня
+	if opens == 24*60 || opens == closes {
+		return fmt.Errorf("opening time %s and closing time %s do not form a working interval", opensAt, closesAt)
+	}
+
+	return nil
+}
+
+func validateName(field, name string, maxLength int) error {
+	if strings.TrimSpace(name) != name || name == "" {
+		return fmt.Errorf("%s must be non-empty and without surrounding spaces", field)
+	}
+
+	if utf8.RuneCountInString(name) > maxLength {
+		return fmt.Errorf("%s must be at most %d characters", field, maxLength)
+	}
+
+	return nil
 }
 
 func ValidateTime(start time.Time, end time.Time) error {
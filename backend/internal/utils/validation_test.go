@@ -1,7 +1,9 @@
 package utils_test
 
 import (
+	"pvz/internal/models"
 	"pvz/internal/utils"
+	"strings"
 	"testing"
 	"time"
 )
@@ -40,48 +42,47 @@ func TestValidateRole(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := utils.ValidateRole(tt.role); got != tt.expected {
+			if got := utils.ValidateRole(models.DefaultPolicy(), tt.role); got != tt.expected {
 				t.Errorf("ValidateRole(%q) = %v, want %v", tt.role, got, tt.expected)
 			}
 		})
 	}
 }
 
-func TestValidateCity(t *testing.T) {
-	tests := []struct {
-		name      string
-		city      string
-		expectErr bool
-	}{
-		{"Valid city", "Казань", false},
-		{"Invalid city", "Томск", true},
+func TestValidateRole_CustomPolicy(t *testing.T) {
+	policy, err := models.NewPolicy(map[string][]string{
+		"moderator": {"pvz:create"},
+		"auditor":   {"pvz:read"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := utils.ValidateCity(tt.city)
-			if (err != nil) != tt.expectErr {
-				t.Errorf("ValidateCity(%q) error = %v, wantErr %v", tt.city, err, tt.expectErr)
-			}
-		})
+	if !utils.ValidateRole(policy, "auditor") {
+		t.Errorf("role from policy should be valid")
+	}
+	if utils.ValidateRole(policy, "employee") {
+		t.Errorf("role missing in policy should be invalid")
 	}
 }
 
-func TestValidateProductType(t *testing.T) {
+func TestValidateCityName(t *testing.T) {
 	tests := []struct {
 		name      string
-		ptype     string
+		city      string
 		expectErr bool
 	}{
-		{"Valid type", "одежда", false},
-		{"Invalid type", "мебель", true},
+		{"Valid city", "Томск", false},
+		{"Empty name", "", true},
+		{"Surrounding spaces", " Томск ", true},
+		{"Too long", strings.Repeat("я", 101), true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := utils.ValidateProductType(tt.ptype)
+			err := utils.ValidateCityName(tt.city)
 			if (err != nil) != tt.expectErr {
-				t.Errorf("ValidateProductType(%q) error = %v, wantErr %v", tt.ptype, err, tt.expectErr)
+				t.Errorf("ValidateCityName(%q) error = %v, wantErr %v", tt.city, err, tt.expectErr)
 			}
 		})
 	}
@@ -124,10 +125,158 @@ func TestValidateAll(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := utils.ValidateAll(tt.email, tt.role)
+			err := utils.ValidateAll(models.DefaultPolicy(), tt.email, tt.role)
 			if (err != nil) != tt.expectErr {
 				t.Errorf("ValidateAll(%q, %q) error = %v, wantErr %v", tt.email, tt.role, err, tt.expectErr)
 			}
 		})
 	}
 }
+
+func TestValidateCategory(t *testing.T) {
+	if err := utils.ValidateCategoryCode("книги"); err != nil {
+		t.Errorf("unexpected error for valid code: %v", err)
+	}
+	if err := utils.ValidateCategoryCode(""); err == nil {
+		t.Errorf("expected error for empty code")
+	}
+
+	tests := []struct {
+		name      string
+		names     map[string]string
+		expectErr bool
+	}{
+		{"Russian and English", map[string]string{"ru": "Книги", "en": "Books"}, false},
+		{"Without Russian", map[string]string{"en": "Books"}, true},
+		{"Empty translation", map[string]string{"ru": "Книги", "en": ""}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateCategoryNames(tt.names)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateCategoryNames(%v) error = %v, wantErr %v", tt.names, err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidatePvzDetails(t *testing.T) {
+	tests := []struct {
+		name      string
+		validate  func() error
+		expectErr bool
+	}{
+		{"Valid location", func() error { return utils.ValidateLocation(55.7558, 37.6173) }, false},
+		{"Latitude out of range", func() error { return utils.ValidateLocation(91, 37.6173) }, true},
+		{"Longitude out of range", func() error { return utils.ValidateLocation(55.7558, -181) }, true},
+		{"Valid phone", func() error { return utils.ValidatePhone("+74951234567") }, false},
+		{"Empty phone", func() error { return utils.ValidatePhone("") }, false},
+		{"Phone without plus", func() error { return utils.ValidatePhone("84951234567") }, true},
+		{"Valid address", func() error { return utils.ValidateAddress("ул. Тверская, д. 1") }, false},
+		{"Too long address", func() error { return utils.ValidateAddress(strings.Repeat("я", 301)) }, true},
+		{"Opening hours with spaces", func() error { return utils.ValidateOpeningHours(" 09:00-21:00") }, true},
+		{"Unlimited capacity", func() error { return utils.ValidateCapacity(0) }, false},
+		{"Negative capacity", func() error { return utils.ValidateCapacity(-1) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateProductIdentifiers(t *testing.T) {
+	tests := []struct {
+		name      string
+		validate  func() error
+		expectErr bool
+	}{
+		{"EAN-13 barcode", func() error { return utils.ValidateBarcode("4600000000017") }, false},
+		{"SKU", func() error { return utils.ValidateBarcode("SKU-123_a.1") }, false},
+		{"Empty barcode", func() error { return utils.ValidateBarcode("") }, true},
+		{"Barcode with space", func() error { return utils.ValidateBarcode("460 000") }, true},
+		{"Too long barcode", func() error { return utils.ValidateBarcode(strings.Repeat("1", 65)) }, true},
+		{"Empty order id", func() error { return utils.ValidateExternalOrderId("") }, false},
+		{"Order id", func() error { return utils.ValidateExternalOrderId("WB-2025-000123") }, false},
+		{"Order id with spaces", func() error { return utils.ValidateExternalOrderId(" 123") }, true},
+		{"Reason", func() error { return utils.ValidateReason("закрыта раньше времени") }, false},
+		{"Empty reason", func() error { return utils.ValidateReason("") }, true},
+		{"Too long reason", func() error { return utils.ValidateReason(strings.Repeat("a", 501)) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	holiday := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := models.PvzSchedule{
+		Timezone: "Europe/Moscow",
+		Week:     []models.WorkingHours{{Weekday: 1, OpensAt: "09:00", ClosesAt: "24:00"}},
+		Exceptions: []models.ScheduleException{
+			{Date: holiday},
+			{Date: holiday.AddDate(0, 0, 1), OpensAt: "10:00", ClosesAt: "16:00"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(s models.PvzSchedule) models.PvzSchedule
+		expectErr bool
+	}{
+		{"Valid schedule", func(s models.PvzSchedule) models.PvzSchedule { return s }, false},
+		{"Unknown timezone", func(s models.PvzSchedule) models.PvzSchedule { s.Timezone = "Moscow"; return s }, true},
+		{"Weekday out of range", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Week = []models.WorkingHours{{Weekday: 0, OpensAt: "09:00", ClosesAt: "18:00"}}
+			return s
+		}, true},
+		{"Duplicated weekday", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Week = append(s.Week, models.WorkingHours{Weekday: 1, OpensAt: "10:00", ClosesAt: "18:00"})
+			return s
+		}, true},
+		{"Overnight shift", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Week = []models.WorkingHours{{Weekday: 2, OpensAt: "21:00", ClosesAt: "09:00"}}
+			return s
+		}, false},
+		{"Opens and closes at the same time", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Week = []models.WorkingHours{{Weekday: 2, OpensAt: "09:00", ClosesAt: "09:00"}}
+			return s
+		}, true},
+		{"Opens at the end of the day", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Week = []models.WorkingHours{{Weekday: 2, OpensAt: "24:00", ClosesAt: "06:00"}}
+			return s
+		}, true},
+		{"Invalid clock", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Week = []models.WorkingHours{{Weekday: 2, OpensAt: "9:00", ClosesAt: "25:00"}}
+			return s
+		}, true},
+		{"Duplicated exception date", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Exceptions = append(s.Exceptions, models.ScheduleException{Date: holiday})
+			return s
+		}, true},
+		{"Exception with closing time only", func(s models.PvzSchedule) models.PvzSchedule {
+			s.Exceptions = []models.ScheduleException{{Date: holiday, ClosesAt: "16:00"}}
+			return s
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := utils.ValidateSchedule(tt.modify(valid))
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateSchedule() error = %v, wantErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"log"
 	"sync"
+	// в образе debian-slim нет базы часовых поясов, а она нужна для расписаний ПВЗ
+	_ "time/tzdata"
 
 	"pvz/config"
 	"pvz/internal"
@@ -27,7 +29,7 @@ func main() {
 
 	go func() {
 		defer wg.Done()
-		if err = grpc.RunGrpcServer(); err != nil {
+		if err = grpc.RunGrpcServer(cfg); err != nil {
 			log.Fatalf("failed to start PVZ gRPC Service: %v", err)
 		}
 	}()
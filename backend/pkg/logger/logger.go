@@ -15,7 +15,10 @@ var Log *logrus.Logger
 
 type ReqIdKey string
 
-const RequestID ReqIdKey = "requestID"
+const (
+	RequestID    ReqIdKey = "requestID"
+	PrincipalKey ReqIdKey = "principal"
+)
 
 func init() {
 	Log = logrus.New()
@@ -41,11 +44,17 @@ func Debug(ctx context.Context, args ...interface{}) {
 }
 
 func logWithContext(ctx context.Context) *logrus.Entry {
-	reqId, ok := ctx.Value(RequestID).(ReqIdKey)
+	reqId, ok := ctx.Value(RequestID).(string)
 	if !ok {
 		reqId = "unknownRequestID"
 	}
 
+	// principal кладется в контекст middleware авторизации, логгеру достаточно его строкового представления
+	principal := "anonymous"
+	if p, ok := ctx.Value(PrincipalKey).(fmt.Stringer); ok {
+		principal = p.String()
+	}
+
 	pc, _, _, ok := runtime.Caller(2)
 	if !ok {
 		return Log.WithFields(logrus.Fields{
@@ -90,6 +99,7 @@ func logWithContext(ctx context.Context) *logrus.Entry {
 
 	return Log.WithFields(logrus.Fields{
 		"requestID": reqId,
+		"principal": principal,
 		"package":   packageName,
 		"function":  funcName,
 	})
@@ -122,13 +132,15 @@ func (f *CustomFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	level := fmt.Sprintf("%s[%s]\033[0m", levelColor, strings.ToUpper(entry.Level.String()))
 	timestamp := time.Now().In(loc).Format("2006-01-02T15:04:05")
 	reqId := entry.Data["requestID"]
+	principal := entry.Data["principal"]
 	packageName := fmt.Sprintf("\033[33m[%s]\033[0m", entry.Data["package"])
 	funcName := fmt.Sprintf("\033[36m[%s]\033[0m", entry.Data["function"])
 
-	logMessage := fmt.Sprintf("%s[%s][%s]%s%s %s\n",
+	logMessage := fmt.Sprintf("%s[%s][%s][%s]%s%s %s\n",
 		level,
 		timestamp,
 		reqId,
+		principal,
 		packageName,
 		funcName,
 		entry.Message,
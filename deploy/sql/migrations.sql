@@ -6,6 +6,25 @@ CREATE TABLE IF NOT EXISTS "user" (
                                      role text not null
 );
 
+-- столбцы и ограничения, появившиеся после создания таблиц, добавляются через ALTER, чтобы миграция проходила и на существующей базе
+ALTER TABLE "user" ALTER COLUMN salt SET DEFAULT '';
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS status text not null default 'active' check (status in ('active', 'deactivated'));
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS password_changed_at timestamptz;
+
+
+CREATE TABLE IF NOT EXISTS city (
+                                      id uuid primary key,
+                                      name text unique not null,
+                                      enabled boolean not null default true,
+                                      created_at timestamptz not null default now()
+);
+
+INSERT INTO city (id, name) VALUES
+    ('7c1b0e2a-4f0d-4a8e-9d57-5b2f6f0c1a01', 'Москва'),
+    ('7c1b0e2a-4f0d-4a8e-9d57-5b2f6f0c1a02', 'Санкт-Петербург'),
+    ('7c1b0e2a-4f0d-4a8e-9d57-5b2f6f0c1a03', 'Казань')
+ON CONFLICT (name) DO NOTHING;
+
 
 CREATE TABLE IF NOT EXISTS pvz (
                                            id uuid primary key,
@@ -13,6 +32,61 @@ CREATE TABLE IF NOT EXISTS pvz (
                                            city text not null
 );
 
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS decommissioned_at timestamptz;
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS address text not null default '';
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS latitude double precision check (latitude between -90 and 90);
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS longitude double precision check (longitude between -180 and 180);
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS opening_hours text not null default '';
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS phone text not null default '';
+-- capacity - вместимость ПВЗ в товарах, 0 - без ограничения
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS capacity int not null default 0 check (capacity >= 0);
+
+-- город ПВЗ берётся из справочника, координаты задаются только парой
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'pvz_city_fkey') THEN
+        ALTER TABLE pvz ADD CONSTRAINT pvz_city_fkey FOREIGN KEY (city) REFERENCES city(name) ON UPDATE CASCADE;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'pvz_location_check') THEN
+        ALTER TABLE pvz ADD CONSTRAINT pvz_location_check CHECK ((latitude is null) = (longitude is null));
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS pvz_latitude_idx ON pvz (latitude) WHERE latitude IS NOT NULL AND decommissioned_at IS NULL;
+
+
+CREATE TABLE IF NOT EXISTS pvz_schedule (
+                                           pvz_id uuid primary key references pvz(id) on delete cascade,
+                                           timezone text not null
+);
+
+-- weekday по ISO: 1 - понедельник, 7 - воскресенье. Дня нет в таблице - ПВЗ в этот день закрыт
+CREATE TABLE IF NOT EXISTS pvz_working_hours (
+                                           pvz_id uuid not null references pvz_schedule(pvz_id) on delete cascade,
+                                           weekday smallint not null check (weekday between 1 and 7),
+                                           opens_at time not null,
+                                           closes_at time not null check (opens_at <> closes_at),
+                                           primary key (pvz_id, weekday)
+);
+
+-- праздники и сокращённые дни; без часов работы ПВЗ закрыт весь день
+CREATE TABLE IF NOT EXISTS pvz_schedule_exception (
+                                           pvz_id uuid not null references pvz_schedule(pvz_id) on delete cascade,
+                                           day date not null,
+                                           opens_at time,
+                                           closes_at time,
+                                           description text not null default '',
+                                           check ((opens_at is null) = (closes_at is null) and opens_at <> closes_at),
+                                           primary key (pvz_id, day)
+);
+
+-- время закрытия раньше времени открытия - ночная смена до следующего дня
+ALTER TABLE pvz_working_hours DROP CONSTRAINT IF EXISTS pvz_working_hours_check;
+ALTER TABLE pvz_working_hours ADD CONSTRAINT pvz_working_hours_check CHECK (opens_at <> closes_at);
+ALTER TABLE pvz_schedule_exception DROP CONSTRAINT IF EXISTS pvz_schedule_exception_check;
+ALTER TABLE pvz_schedule_exception ADD CONSTRAINT pvz_schedule_exception_check
+    CHECK ((opens_at is null) = (closes_at is null) and opens_at <> closes_at);
+
 
 CREATE TABLE IF NOT EXISTS reception (
                                         id uuid primary key,
@@ -21,6 +95,32 @@ CREATE TABLE IF NOT EXISTS reception (
                                         status text not null check (status in ('in_progress', 'close'))
 );
 
+ALTER TABLE reception ADD COLUMN IF NOT EXISTS outside_schedule boolean not null default false;
+ALTER TABLE reception ADD COLUMN IF NOT EXISTS auto_closed boolean not null default false;
+
+-- cancelled - приёмка открыта по ошибке, verified - закрытая приёмка проверена модератором
+ALTER TABLE reception DROP CONSTRAINT IF EXISTS reception_status_check;
+ALTER TABLE reception ADD CONSTRAINT reception_status_check CHECK (status in ('in_progress', 'close', 'cancelled', 'verified'));
+
+-- у ПВЗ не больше одной открытой приёмки, в том числе после переоткрытия закрытой
+CREATE UNIQUE INDEX IF NOT EXISTS reception_in_progress_pvz_idx ON reception (pvz_id) WHERE status = 'in_progress';
+
+
+CREATE TABLE IF NOT EXISTS category (
+                                      id uuid primary key,
+                                      code text unique not null,
+                                      names jsonb not null default '{}',
+                                      active boolean not null default true,
+                                      parent_id uuid references category(id),
+                                      created_at timestamptz not null default now()
+);
+
+INSERT INTO category (id, code, names) VALUES
+    ('3f6a2d1e-8b4c-4f7a-9e21-6c0d5b7a1e01', 'электроника', '{"ru": "Электроника", "en": "Electronics"}'),
+    ('3f6a2d1e-8b4c-4f7a-9e21-6c0d5b7a1e02', 'одежда', '{"ru": "Одежда", "en": "Clothes"}'),
+    ('3f6a2d1e-8b4c-4f7a-9e21-6c0d5b7a1e03', 'обувь', '{"ru": "Обувь", "en": "Shoes"}')
+ON CONFLICT (code) DO NOTHING;
+
 
 CREATE TABLE IF NOT EXISTS product (
                                       id uuid primary key,
@@ -28,3 +128,134 @@ CREATE TABLE IF NOT EXISTS product (
                                       type text not null check (type in ('электроника', 'одежда', 'обувь')),
                                       reception_id uuid not null references reception(id) on delete cascade
 );
+
+ALTER TABLE product ADD COLUMN IF NOT EXISTS over_capacity boolean not null default false;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS barcode text;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS external_order_id text;
+-- статус товара на ПВЗ
+ALTER TABLE product ADD COLUMN IF NOT EXISTS status text not null default 'stored' check (status in ('stored', 'issued', 'returned', 'sent_back'));
+ALTER TABLE product ADD COLUMN IF NOT EXISTS issued_at timestamptz;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS returned_at timestamptz;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS sent_back_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS product_reception_id_idx ON product (reception_id);
+-- один штрихкод принимается в приёмку один раз, товары без штрихкода не ограничены
+CREATE UNIQUE INDEX IF NOT EXISTS product_reception_barcode_idx ON product (reception_id, barcode);
+CREATE INDEX IF NOT EXISTS product_barcode_idx ON product (barcode);
+
+-- перенос существующих товаров со старого CHECK на справочник категорий
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_type_check;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'product_type_fkey') THEN
+        ALTER TABLE product ADD CONSTRAINT product_type_fkey FOREIGN KEY (type) REFERENCES category(code);
+    END IF;
+END $$;
+
+-- ожидаемая поставка (ASN) для следующей приёмки ПВЗ, reception_id заполняется при закрытии приёмки
+CREATE TABLE IF NOT EXISTS manifest (
+                                       id uuid primary key,
+                                       pvz_id uuid not null references pvz(id) on delete cascade,
+                                       reception_id uuid unique references reception(id) on delete cascade,
+                                       created_at timestamptz not null
+);
+
+-- у ПВЗ не больше одного манифеста, ждущего приёмку
+CREATE UNIQUE INDEX IF NOT EXISTS manifest_pending_pvz_idx ON manifest (pvz_id) WHERE reception_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS manifest_item (
+                                            manifest_id uuid not null references manifest(id) on delete cascade,
+                                            barcode text not null,
+                                            type text not null,
+                                            primary key (manifest_id, barcode)
+);
+
+CREATE TABLE IF NOT EXISTS reception_discrepancy (
+                                                    reception_id uuid not null references reception(id) on delete cascade,
+                                                    kind text not null check (kind in ('shortage', 'surplus', 'wrong_category')),
+                                                    barcode text,
+                                                    product_id uuid,
+                                                    expected_type text,
+                                                    actual_type text
+);
+
+CREATE INDEX IF NOT EXISTS reception_discrepancy_reception_id_idx ON reception_discrepancy (reception_id);
+
+
+CREATE TABLE IF NOT EXISTS refresh_token (
+                                      id uuid primary key,
+                                      user_id uuid not null references "user"(id) on delete cascade,
+                                      token_hash text unique not null,
+                                      created_at timestamptz not null,
+                                      expires_at timestamptz not null,
+                                      revoked_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS refresh_token_user_id_idx ON refresh_token (user_id);
+
+-- роль берётся из пользователя при обновлении токена; столбец остался в базах, созданных до этого
+ALTER TABLE refresh_token DROP COLUMN IF EXISTS role;
+
+
+CREATE TABLE IF NOT EXISTS revoked_token (
+                                      jti text primary key,
+                                      expires_at timestamptz not null
+);
+
+
+CREATE TABLE IF NOT EXISTS user_pvz (
+                                      user_id uuid not null references "user"(id) on delete cascade,
+                                      pvz_id uuid not null references pvz(id) on delete cascade,
+                                      assigned_at timestamptz not null,
+                                      primary key (user_id, pvz_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_pvz_pvz_id_idx ON user_pvz (pvz_id);
+
+
+CREATE TABLE IF NOT EXISTS "order" (
+                                      id uuid primary key,
+                                      client_id uuid not null references "user"(id) on delete cascade,
+                                      pvz_id uuid not null references pvz(id) on delete cascade,
+                                      product_id uuid unique not null references product(id) on delete cascade,
+                                      status text not null check (status in ('waiting', 'issued')),
+                                      created_at timestamptz not null,
+                                      pickup_code_hash text,
+                                      pickup_code_expires_at timestamptz,
+                                      issued_at timestamptz,
+                                      issued_by uuid references "user"(id) on delete set null
+);
+
+CREATE INDEX IF NOT EXISTS order_client_id_idx ON "order" (client_id);
+
+-- cancelled - товар заказа отправлен обратно на склад, не дождавшись клиента
+ALTER TABLE "order" DROP CONSTRAINT IF EXISTS order_status_check;
+ALTER TABLE "order" ADD CONSTRAINT order_status_check CHECK (status in ('waiting', 'issued', 'cancelled'));
+
+-- товары заказов, выданных до появления статуса товара, считаются выданными
+UPDATE product SET status = 'issued', issued_at = o.issued_at
+FROM "order" o
+WHERE o.product_id = product.id AND o.status = 'issued' AND product.status = 'stored';
+CREATE UNIQUE INDEX IF NOT EXISTS order_pickup_code_idx ON "order" (pvz_id, pickup_code_hash);
+
+
+CREATE TABLE IF NOT EXISTS login_failure (
+                                      kind text not null check (kind in ('email', 'ip')),
+                                      subject text not null,
+                                      failures int not null,
+                                      last_failure_at timestamptz not null,
+                                      locked_until timestamptz,
+                                      primary key (kind, subject)
+);
+
+
+CREATE TABLE IF NOT EXISTS audit_log (
+                                      id uuid primary key,
+                                      actor_id uuid not null,
+                                      action text not null,
+                                      target_id uuid not null,
+                                      details jsonb not null default '{}',
+                                      created_at timestamptz not null
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_target_id_idx ON audit_log (target_id, created_at);